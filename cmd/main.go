@@ -18,16 +18,21 @@ func main() {
 	if err = cont.Mqtt.Connect(); err != nil {
 		panic(fmt.Errorf("error while connecting to mqtt: %w", err))
 	}
+	cont.DiscoveryService.LoadRetainedTopics()
 
 	entityManagerDone := cont.EntityManager.Run()
-	policyDone := cont.PolicyStorage.Run()
+	routerManagerDone := cont.RouterManager.Run()
+	haStatusDone := cont.HAStatusWatcher.Run()
+	listsDone := cont.ListsStorage.Run()
 
 	sig := []os.Signal{syscall.SIGTERM, syscall.SIGINT}
 	shutdownCh := make(chan os.Signal, len(sig))
 	signal.Notify(shutdownCh, sig...)
 
 	<-shutdownCh
-	policyDone <- struct{}{}
+	haStatusDone <- struct{}{}
+	listsDone <- struct{}{}
+	routerManagerDone <- struct{}{}
 	entityManagerDone <- struct{}{}
 
 	cont.Logger.Info("process interrupted by signal")
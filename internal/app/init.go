@@ -1,28 +1,67 @@
 package app
 
 import (
+	"fmt"
 	"log/slog"
 	"net/http/cookiejar"
 	"time"
 
 	"keeneticToMqtt/internal/clients/keenetic"
+	"keeneticToMqtt/internal/clients/keenetic/accesslist"
 	"keeneticToMqtt/internal/clients/keenetic/accessupdate"
 	"keeneticToMqtt/internal/clients/keenetic/auth"
+	"keeneticToMqtt/internal/clients/keenetic/interfaceupdate"
+	"keeneticToMqtt/internal/clients/keenetic/internet"
 	"keeneticToMqtt/internal/clients/keenetic/list"
-	"keeneticToMqtt/internal/clients/keenetic/policylist"
+	"keeneticToMqtt/internal/clients/keenetic/rci"
+	"keeneticToMqtt/internal/clients/keenetic/system"
 	"keeneticToMqtt/internal/clients/mqtt"
 	"keeneticToMqtt/internal/config"
 	"keeneticToMqtt/internal/homeassistant"
+	"keeneticToMqtt/internal/homeassistant/accesspoint"
+	"keeneticToMqtt/internal/homeassistant/attributes"
 	"keeneticToMqtt/internal/homeassistant/clientpermit"
 	"keeneticToMqtt/internal/homeassistant/clientpolicy"
+	"keeneticToMqtt/internal/homeassistant/clientschedule"
+	"keeneticToMqtt/internal/homeassistant/connectivity"
+	"keeneticToMqtt/internal/homeassistant/linkrate"
+	"keeneticToMqtt/internal/homeassistant/mcs"
+	"keeneticToMqtt/internal/homeassistant/presence"
+	"keeneticToMqtt/internal/homeassistant/router/cpuload"
+	"keeneticToMqtt/internal/homeassistant/router/defaultgateway"
+	"keeneticToMqtt/internal/homeassistant/router/firmware"
+	routerinternet "keeneticToMqtt/internal/homeassistant/router/internet"
+	"keeneticToMqtt/internal/homeassistant/router/memory"
+	"keeneticToMqtt/internal/homeassistant/router/model"
+	"keeneticToMqtt/internal/homeassistant/router/uptime"
+	"keeneticToMqtt/internal/homeassistant/router/wanip"
+	"keeneticToMqtt/internal/homeassistant/router/wanrxrate"
+	"keeneticToMqtt/internal/homeassistant/router/wantxrate"
+	"keeneticToMqtt/internal/homeassistant/router/wifi"
+	"keeneticToMqtt/internal/homeassistant/rssi"
 	"keeneticToMqtt/internal/homeassistant/rxbytes"
+	"keeneticToMqtt/internal/homeassistant/rxlimit"
+	"keeneticToMqtt/internal/homeassistant/rxrate"
+	"keeneticToMqtt/internal/homeassistant/spatialstreams"
+	"keeneticToMqtt/internal/homeassistant/ssid"
+	"keeneticToMqtt/internal/homeassistant/topic"
 	"keeneticToMqtt/internal/homeassistant/txbytes"
+	"keeneticToMqtt/internal/homeassistant/txlimit"
+	"keeneticToMqtt/internal/homeassistant/txrate"
 	"keeneticToMqtt/internal/logger"
+	"keeneticToMqtt/internal/services/availability"
 	"keeneticToMqtt/internal/services/clientlist"
+	"keeneticToMqtt/internal/services/clientstate"
 	"keeneticToMqtt/internal/services/discovery"
-	"keeneticToMqtt/internal/storages/policy"
+	"keeneticToMqtt/internal/services/hastatus"
+	"keeneticToMqtt/internal/services/routerinfo"
+	"keeneticToMqtt/internal/storages/lists"
+	"keeneticToMqtt/internal/tlsconfig"
 )
 
+// keeneticReadWindow is time to collect keenetic reads of one polling tick into one request.
+const keeneticReadWindow = 100 * time.Millisecond
+
 // Container with dependencies.
 type Container struct {
 	Logger            *slog.Logger
@@ -30,7 +69,9 @@ type Container struct {
 	ClientListService *clientlist.ClientList
 	DiscoveryService  *discovery.Discovery
 	EntityManager     *homeassistant.EntityManager
-	PolicyStorage     *policy.Storage
+	RouterManager     *homeassistant.RouterManager
+	HAStatusWatcher   *hastatus.Watcher
+	ListsStorage      *lists.Storage
 	Mqtt              *mqtt.Client
 }
 
@@ -48,36 +89,157 @@ func NewContainer() (*Container, error) {
 
 	cookie, _ := cookiejar.New(&cookiejar.Options{})
 
-	cont.Mqtt = mqtt.NewClient(cont.Config.Mqtt.Host, cont.Config.Mqtt.ClientID, cont.Config.Mqtt.Login, cont.Config.Mqtt.Password, cont.Logger)
+	mqttTLS, err := tlsconfig.New(cont.Config.Mqtt.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("error while creating mqtt tls config: %w", err)
+	}
+	bridgeAvailabilityTopic := availability.BridgeTopic(cont.Config.Mqtt.BaseTopic)
+	cont.Mqtt = mqtt.NewClient(
+		cont.Config.Mqtt.Host,
+		cont.Config.Mqtt.ClientID,
+		cont.Config.Mqtt.Login,
+		cont.Config.Mqtt.Password,
+		bridgeAvailabilityTopic,
+		mqttTLS,
+		cont.Logger,
+	)
+	routerAvailability := availability.NewAvailability(cont.Config.Mqtt.BaseTopic, cont.Mqtt)
+	cont.Mqtt.AddConnectHandler(routerAvailability.Republish)
+
+	keeneticTLS, err := tlsconfig.New(cont.Config.Keenetic.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("error while creating keenetic tls config: %w", err)
+	}
+	keeneticTransport, err := keenetic.NewTransport(keeneticTLS, cont.Config.Keenetic.Proxy)
+	if err != nil {
+		return nil, fmt.Errorf("error while creating keenetic transport: %w", err)
+	}
 
-	authClient := auth.NewAuth(cont.Config.Keenetic.Host, cont.Config.Keenetic.Login, cont.Config.Keenetic.Password, cookie)
-	keeneticClient := keenetic.NewKeenetic(authClient, cookie, cont.Config.Keenetic.Host, cont.Config.Keenetic.Login, cont.Config.Keenetic.Password, cont.Logger)
+	authClient := auth.NewAuth(
+		cont.Config.Keenetic.Host,
+		cont.Config.Keenetic.Login,
+		cont.Config.Keenetic.Password,
+		cookie,
+		keeneticTransport,
+		cont.Config.Keenetic.Timeout,
+	)
+	keeneticClient := keenetic.NewKeenetic(authClient, routerAvailability, keeneticTransport, cont.Config.Keenetic.Timeout, cookie, cont.Config.Keenetic.Host, cont.Config.Keenetic.Login, cont.Config.Keenetic.Password, cont.Logger)
 	policyClient := accessupdate.NewAccessUpdate(cont.Config.Keenetic.Host, keeneticClient)
-	policyList := policylist.NewPolicyList(cont.Config.Keenetic.Host, keeneticClient)
-	listClient := list.NewList(cont.Config.Keenetic.Host, keeneticClient)
+	// entity manager, router manager and lists storage tick together, so their reads are sent in one request
+	readCollector := rci.NewCollector(cont.Config.Keenetic.Host, keeneticClient, keeneticReadWindow)
+	accessList := accesslist.NewAccessList(readCollector)
+	listClient := list.NewList(readCollector)
+	systemClient := system.NewSystem(readCollector)
+	internetClient := internet.NewInternet(readCollector)
+	interfaceClient := interfaceupdate.NewInterfaceUpdate(cont.Config.Keenetic.Host, keeneticClient)
 
-	cont.PolicyStorage = policy.NewStorage(policyList, time.Second*10, cont.Logger)
+	cont.ListsStorage = lists.NewStorage(accessList, cont.Config.Homeassistant.UpdateInterval, cont.Logger)
 
-	cont.ClientListService = clientlist.NewClientList(listClient, cont.Config.Homeassistant.WhiteList)
-	cont.DiscoveryService = discovery.NewDiscovery("", cont.Config.Homeassistant.DeviceID, cont.Mqtt)
+	cont.ClientListService = clientlist.NewClientList(
+		listClient,
+		cont.Config.Homeassistant.Mode,
+		cont.Config.Homeassistant.WhiteList,
+		cont.Config.Homeassistant.BlackList,
+		cont.Config.Homeassistant.AwayTimeout,
+	)
+	clientTopics := topic.NewTemplate(cont.Config.Mqtt.BaseTopic, cont.Config.Homeassistant.TopicTemplate)
 
-	clientPolicy := clientpolicy.NewClientPolicy(cont.Config.Mqtt.BaseTopic, cont.DiscoveryService, policyClient, cont.PolicyStorage)
-	clientPermit := clientpermit.NewClientPermit(cont.Config.Mqtt.BaseTopic, cont.DiscoveryService, policyClient)
-	txBytes := txbytes.NewTxBytes(cont.Config.Mqtt.BaseTopic, cont.DiscoveryService)
-	rxBytes := rxbytes.NewRxBytes(cont.Config.Mqtt.BaseTopic, cont.DiscoveryService)
+	cont.DiscoveryService = discovery.NewDiscovery(
+		cont.Config.Homeassistant.DiscoveryPrefix,
+		cont.Config.Homeassistant.DeviceID,
+		[]string{bridgeAvailabilityTopic, availability.RouterTopic(cont.Config.Mqtt.BaseTopic)},
+		clientTopics,
+		cont.Mqtt,
+	)
+
+	clientPolicy := clientpolicy.NewClientPolicy(clientTopics, cont.DiscoveryService, policyClient, cont.ListsStorage.Policies())
+	clientSchedule := clientschedule.NewClientSchedule(clientTopics, cont.DiscoveryService, policyClient, cont.ListsStorage.Schedules())
+	clientPermit := clientpermit.NewClientPermit(clientTopics, cont.DiscoveryService, policyClient)
+	txBytes := txbytes.NewTxBytes(clientTopics, cont.DiscoveryService)
+	rxBytes := rxbytes.NewRxBytes(clientTopics, cont.DiscoveryService)
+	txRate := txrate.NewTxRate(clientTopics, cont.DiscoveryService)
+	rxRate := rxrate.NewRxRate(clientTopics, cont.DiscoveryService)
+	clientPresence := presence.NewPresence(clientTopics, cont.DiscoveryService)
+	clientConnectivity := connectivity.NewConnectivity(clientTopics, cont.DiscoveryService)
+	clientRSSI := rssi.NewRSSI(clientTopics, cont.DiscoveryService)
+	clientLinkRate := linkrate.NewLinkRate(clientTopics, cont.DiscoveryService)
+	clientMCS := mcs.NewMCS(clientTopics, cont.DiscoveryService)
+	clientSpatialStreams := spatialstreams.NewSpatialStreams(clientTopics, cont.DiscoveryService)
+	clientSSID := ssid.NewSSID(clientTopics, cont.DiscoveryService)
+	clientAccessPoint := accesspoint.NewAccessPoint(clientTopics, cont.DiscoveryService)
+	clientRxLimit := rxlimit.NewRxLimit(clientTopics, cont.DiscoveryService, policyClient)
+	clientTxLimit := txlimit.NewTxLimit(clientTopics, cont.DiscoveryService, policyClient)
+	clientAttributes := attributes.NewAttributes(clientTopics)
+
+	clientStatePublisher := clientstate.NewPublisher(cont.Config.Mqtt.BaseTopic, cont.Mqtt)
 
 	cont.EntityManager = homeassistant.NewEntityManager(
 		[]homeassistant.Entity{
 			clientPolicy,
+			clientSchedule,
 			clientPermit,
 			txBytes,
 			rxBytes,
+			txRate,
+			rxRate,
+			clientPresence,
+			clientConnectivity,
+			clientRSSI,
+			clientLinkRate,
+			clientMCS,
+			clientSpatialStreams,
+			clientSSID,
+			clientAccessPoint,
+			clientRxLimit,
+			clientTxLimit,
+			clientAttributes,
 		},
 		cont.ClientListService,
 		cont.Mqtt,
+		cont.DiscoveryService,
+		clientStatePublisher,
 		cont.Config.Homeassistant.UpdateInterval,
 		cont.Logger,
 	)
 
+	routerCPULoad := cpuload.NewCPULoad(cont.Config.Mqtt.BaseTopic, cont.DiscoveryService)
+	routerMemory := memory.NewMemory(cont.Config.Mqtt.BaseTopic, cont.DiscoveryService)
+	routerUptime := uptime.NewUptime(cont.Config.Mqtt.BaseTopic, cont.DiscoveryService)
+	routerFirmware := firmware.NewFirmware(cont.Config.Mqtt.BaseTopic, cont.DiscoveryService)
+	routerModel := model.NewModel(cont.Config.Mqtt.BaseTopic, cont.DiscoveryService)
+	routerInternet := routerinternet.NewInternet(cont.Config.Mqtt.BaseTopic, cont.DiscoveryService)
+	routerWanIP := wanip.NewWanIP(cont.Config.Mqtt.BaseTopic, cont.DiscoveryService)
+	routerDefaultGateway := defaultgateway.NewDefaultGateway(cont.Config.Mqtt.BaseTopic, cont.DiscoveryService)
+	routerWanRxRate := wanrxrate.NewWanRxRate(cont.Config.Mqtt.BaseTopic, cont.DiscoveryService)
+	routerWanTxRate := wantxrate.NewWanTxRate(cont.Config.Mqtt.BaseTopic, cont.DiscoveryService)
+	routerWifi := wifi.NewWifi(cont.Config.Mqtt.BaseTopic, cont.DiscoveryService, interfaceClient)
+
+	cont.RouterManager = homeassistant.NewRouterManager(
+		[]homeassistant.RouterEntity{
+			routerCPULoad,
+			routerMemory,
+			routerUptime,
+			routerFirmware,
+			routerModel,
+			routerInternet,
+			routerWanIP,
+			routerDefaultGateway,
+			routerWanRxRate,
+			routerWanTxRate,
+			routerWifi,
+		},
+		routerinfo.NewRouterInfo(systemClient, internetClient),
+		cont.Mqtt,
+		cont.Config.Homeassistant.UpdateInterval,
+		cont.Logger,
+	)
+
+	cont.HAStatusWatcher = hastatus.NewWatcher(
+		cont.Config.Homeassistant.StatusTopic,
+		cont.Mqtt,
+		[]hastatus.Listener{cont.EntityManager, cont.RouterManager},
+		cont.Logger,
+	)
+
 	return &cont, nil
 }
@@ -0,0 +1,51 @@
+package accesslist
+
+import (
+	"fmt"
+
+	"keeneticToMqtt/internal/clients/keenetic/rci"
+	"keeneticToMqtt/internal/dto/keeneticdto"
+)
+
+//go:generate mockgen -source=accesslist.go -destination=../../../../test/mocks/gomock/clients/keenetic/accesslist/accesslist.go
+
+const (
+	policyListPath   = "show/rc/ip/policy"
+	scheduleListPath = "show/rc/schedule"
+)
+
+type (
+	collector interface {
+		NewBatch() *rci.Batch
+	}
+)
+
+// AccessList struct to get keenetic policy and schedule lists.
+type AccessList struct {
+	collector collector
+}
+
+// NewAccessList creates new AccessList.
+func NewAccessList(collector collector) *AccessList {
+	return &AccessList{
+		collector: collector,
+	}
+}
+
+// GetAccessLists returns maps of policies and schedules in one batched request. Keys of maps are names.
+func (l *AccessList) GetAccessLists() (map[string]keeneticdto.Policy, map[string]keeneticdto.Schedule, error) {
+	var (
+		policies  map[string]keeneticdto.Policy
+		schedules map[string]keeneticdto.Schedule
+	)
+
+	err := l.collector.NewBatch().
+		Add(policyListPath, nil, &policies).
+		Add(scheduleListPath, nil, &schedules).
+		Send()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error in GetAccessLists request: %w", err)
+	}
+
+	return policies, schedules, nil
+}
@@ -0,0 +1,133 @@
+package accesslist
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"keeneticToMqtt/internal/clients/keenetic/rci"
+	"keeneticToMqtt/internal/dto/keeneticdto"
+	"keeneticToMqtt/internal/errs"
+	mock_rci "keeneticToMqtt/test/mocks/gomock/clients/keenetic/rci"
+)
+
+func TestAccessList_GetAccessLists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		host = "host"
+	)
+
+	someErr := errors.New("some err")
+
+	tests := []struct {
+		name              string
+		expectedPolicies  map[string]keeneticdto.Policy
+		expectedSchedules map[string]keeneticdto.Schedule
+		expectedErr       error
+		expectedErrStr    string
+		validateRequest   func(req *http.Request)
+		getResponse       func() *http.Response
+		getResponseError  func() error
+	}{
+		{
+			name: "success get access lists",
+			validateRequest: func(req *http.Request) {
+				assert.Equal(t, host+"/rci/", req.URL.String())
+				assert.Equal(t, http.MethodPost, req.Method)
+
+				body, err := io.ReadAll(req.Body)
+				assert.Nil(t, err)
+				assert.JSONEq(t, `[{"show":{"rc":{"ip":{"policy":{}}}}},{"show":{"rc":{"schedule":{}}}}]`, string(body))
+			},
+			getResponse: func() *http.Response {
+				bodyStr := `[{"show":{"rc":{"ip":{"policy":{"Policy0":{"description":"vpn"}}}}}},` +
+					`{"show":{"rc":{"schedule":{"night":{"description":"night"}}}}}]`
+				resp := http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(bodyStr)),
+				}
+				return &resp
+			},
+			getResponseError: func() error {
+				return nil
+			},
+			expectedPolicies:  map[string]keeneticdto.Policy{"Policy0": {Description: "vpn"}},
+			expectedSchedules: map[string]keeneticdto.Schedule{"night": {Description: "night"}},
+		},
+		{
+			name:            "error from client",
+			validateRequest: func(req *http.Request) {},
+			getResponse: func() *http.Response {
+				return nil
+			},
+			getResponseError: func() error {
+				return someErr
+			},
+			expectedErr: someErr,
+		},
+		{
+			name:            "http.StatusUnauthorized status code",
+			validateRequest: func(req *http.Request) {},
+			getResponse: func() *http.Response {
+				bytesReader := strings.NewReader("")
+				resp := http.Response{
+					StatusCode: http.StatusUnauthorized,
+					Body:       io.NopCloser(bytesReader),
+				}
+				return &resp
+			},
+			getResponseError: func() error {
+				return nil
+			},
+			expectedErr: errs.ErrUnauthorized,
+		},
+		{
+			name:            "error while unmarshal body",
+			validateRequest: func(req *http.Request) {},
+			getResponse: func() *http.Response {
+				resp := http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader("")),
+				}
+				return &resp
+			},
+			getResponseError: func() error {
+				return nil
+			},
+			expectedErrStr: "error in GetAccessLists request: unmarshal response error in Batch request:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := mock_rci.NewMockclient(ctrl)
+			client.EXPECT().Do(gomock.Cond(func(x any) bool {
+				req, ok := x.(*http.Request)
+				if !ok || req == nil {
+					t.Errorf("empty request")
+					return false
+				}
+				tt.validateRequest(req)
+				return true
+			})).Return(tt.getResponse(), tt.getResponseError())
+
+			accessList := NewAccessList(rci.NewCollector(host, client, 0))
+			policies, schedules, err := accessList.GetAccessLists()
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else if tt.expectedErrStr != "" {
+				assert.Regexp(t, tt.expectedErrStr+".*", err.Error())
+			} else {
+				assert.Equal(t, tt.expectedPolicies, policies)
+				assert.Equal(t, tt.expectedSchedules, schedules)
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 
+	"keeneticToMqtt/internal/clients/keenetic/rci"
 	"keeneticToMqtt/internal/dto/homeassistantdto"
 	"keeneticToMqtt/internal/errs"
 )
@@ -22,19 +23,6 @@ type (
 		Do(req *http.Request) (*http.Response, error)
 	}
 
-	responseStatus struct {
-		Status  string `json:"status"`
-		Code    string `json:"code"`
-		Ident   string `json:"ident"`
-		Message string `json:"message"`
-	}
-
-	responseClient struct {
-		Status []responseStatus `json:"status"`
-	}
-
-	response map[string]responseClient
-
 	permitTrueReq struct {
 		Mac    string `json:"mac"`
 		Permit bool   `json:"permit"`
@@ -51,6 +39,22 @@ type (
 		Mac    string `json:"mac"`
 		Policy string `json:"policy"`
 	}
+	setEmptyScheduleReq struct {
+		Mac      string `json:"mac"`
+		Schedule bool   `json:"schedule"`
+	}
+	setScheduleReq struct {
+		Mac      string `json:"mac"`
+		Schedule string `json:"schedule"`
+	}
+	trafficShape struct {
+		RX int64 `json:"rx"`
+		TX int64 `json:"tx"`
+	}
+	setTrafficShapeReq struct {
+		Mac          string       `json:"mac"`
+		TrafficShape trafficShape `json:"traffic-shape"`
+	}
 )
 
 // NewAccessUpdate creates new AccessUpdate.
@@ -103,6 +107,35 @@ func (p *AccessUpdate) SetPermit(mac string, permit bool) error {
 	return p.ipHotspotHostRequest(body)
 }
 
+// SetSchedule set keenetic client access schedule.
+func (p *AccessUpdate) SetSchedule(mac, schedule string) error {
+	var body interface{}
+	if schedule == homeassistantdto.NoneSchedule {
+		body = setEmptyScheduleReq{
+			Mac:      mac,
+			Schedule: false,
+		}
+	} else {
+		body = setScheduleReq{
+			Mac:      mac,
+			Schedule: schedule,
+		}
+	}
+
+	return p.ipHotspotHostRequest(body)
+}
+
+// SetTrafficShape set keenetic client rx and tx rate limits in kbit/s, 0 means unlimited.
+func (p *AccessUpdate) SetTrafficShape(mac string, rx, tx int64) error {
+	return p.ipHotspotHostRequest(setTrafficShapeReq{
+		Mac: mac,
+		TrafficShape: trafficShape{
+			RX: rx,
+			TX: tx,
+		},
+	})
+}
+
 func (p *AccessUpdate) ipHotspotHostRequest(body interface{}) error {
 	b, err := json.Marshal(body)
 	if err != nil {
@@ -135,13 +168,8 @@ func (p *AccessUpdate) ipHotspotHostRequest(body interface{}) error {
 		return fmt.Errorf("read response body error in access request: %w", err)
 	}
 
-	var res response
-	if err := json.Unmarshal(resBytes, &res); err != nil {
-		return fmt.Errorf("unmarshal response error in setaccess request: %w", err)
-	}
-
-	if len(res) == 0 {
-		return fmt.Errorf("no status in setaccess response: %w", err)
+	if err := rci.CheckStatus(resBytes); err != nil {
+		return fmt.Errorf("status error in setaccess request: %w", err)
 	}
 
 	return nil
@@ -25,8 +25,8 @@ func TestAccessUpdate_SetPermit(t *testing.T) {
 		mac  = "mac"
 	)
 
-	successRes := response{
-		"key": {},
+	successRes := map[string]any{
+		"key": map[string]any{},
 	}
 	someErr := errors.New("some err")
 
@@ -133,7 +133,7 @@ func TestAccessUpdate_SetPermit(t *testing.T) {
 			getResponseError: func() error {
 				return nil
 			},
-			expectedErrStr: "no status in setaccess response",
+			expectedErrStr: "status error in setaccess request: no status in response",
 		},
 		{
 			name:            "error from client",
@@ -192,7 +192,7 @@ func TestAccessUpdate_SetPermit(t *testing.T) {
 			getResponseError: func() error {
 				return nil
 			},
-			expectedErrStr: "unmarshal response error in setaccess request:",
+			expectedErrStr: "status error in setaccess request: unmarshal status error:",
 		},
 	}
 
@@ -232,8 +232,8 @@ func TestAccessUpdate_SetPolicy(t *testing.T) {
 		policy = "policy"
 	)
 
-	successRes := response{
-		"key": {},
+	successRes := map[string]any{
+		"key": map[string]any{},
 	}
 	someErr := errors.New("some err")
 
@@ -368,7 +368,24 @@ func TestAccessUpdate_SetPolicy(t *testing.T) {
 			getResponseError: func() error {
 				return nil
 			},
-			expectedErrStr: "unmarshal response error in setaccess request:",
+			expectedErrStr: "status error in setaccess request: unmarshal status error:",
+		},
+		{
+			name:            "unknown policy status",
+			validateRequest: func(req *http.Request) {},
+			policy:          policy,
+			getResponse: func() *http.Response {
+				stringReader := strings.NewReader(`{"policy":{"status":[{"status":"error","code":"1179781","ident":"Hotspot::Manager","message":"unable to find policy \"policy\"."}]}}`)
+				resp := http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(stringReader),
+				}
+				return &resp
+			},
+			getResponseError: func() error {
+				return nil
+			},
+			expectedErr: errs.ErrUnknownPolicy,
 		},
 		{
 			name:            "empty response",
@@ -388,7 +405,7 @@ func TestAccessUpdate_SetPolicy(t *testing.T) {
 			getResponseError: func() error {
 				return nil
 			},
-			expectedErrStr: "no status in setaccess response",
+			expectedErrStr: "status error in setaccess request: no status in response",
 		},
 	}
 
@@ -417,3 +434,226 @@ func TestAccessUpdate_SetPolicy(t *testing.T) {
 		})
 	}
 }
+
+func TestAccessUpdate_SetTrafficShape(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		host = "host"
+		mac  = "mac"
+	)
+
+	successRes := map[string]any{
+		"key": map[string]any{},
+	}
+	someErr := errors.New("some err")
+
+	tests := []struct {
+		name             string
+		expectedErr      error
+		expectedErrStr   string
+		validateRequest  func(req *http.Request)
+		getResponse      func() *http.Response
+		getResponseError func() error
+	}{
+		{
+			name: "success set traffic shape",
+			validateRequest: func(req *http.Request) {
+				assert.Equal(t, host+ipHotspotHostURL, req.URL.String())
+				assert.Equal(t, "application/json;charset=UTF-8", req.Header.Get("Content-Type"))
+				assert.Equal(t, http.MethodPost, req.Method)
+
+				b, err := io.ReadAll(req.Body)
+				assert.Nil(t, err)
+				assert.JSONEq(t, `{"mac":"mac","traffic-shape":{"rx":1024,"tx":0}}`, string(b))
+			},
+			getResponse: func() *http.Response {
+				body := successRes
+				bodyStr, err := json.Marshal(body)
+				assert.Nil(t, err)
+
+				bytesReader := bytes.NewReader(bodyStr)
+				resp := http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytesReader),
+				}
+				return &resp
+			},
+			getResponseError: func() error {
+				return nil
+			},
+		},
+		{
+			name:            "error from client",
+			validateRequest: func(req *http.Request) {},
+			getResponse: func() *http.Response {
+				return nil
+			},
+			getResponseError: func() error {
+				return someErr
+			},
+			expectedErr: someErr,
+		},
+		{
+			name:            "status code not 200",
+			validateRequest: func(req *http.Request) {},
+			getResponse: func() *http.Response {
+				bytesReader := strings.NewReader("")
+				resp := http.Response{
+					StatusCode: http.StatusBadRequest,
+					Body:       io.NopCloser(bytesReader),
+				}
+				return &resp
+			},
+			getResponseError: func() error {
+				return nil
+			},
+			expectedErrStr: "error in setaccess request, status code: 400",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := mock_accessupdate.NewMockclient(ctrl)
+			client.EXPECT().Do(gomock.Cond(func(x any) bool {
+				req, ok := x.(*http.Request)
+				if !ok || req == nil {
+					t.Errorf("empty request")
+					return false
+				}
+				tt.validateRequest(req)
+				return true
+			})).Return(tt.getResponse(), tt.getResponseError())
+
+			accessUpdate := NewAccessUpdate(host, client)
+			err := accessUpdate.SetTrafficShape(mac, 1024, 0)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else if tt.expectedErrStr != "" {
+				assert.Regexp(t, tt.expectedErrStr+".*", err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestAccessUpdate_SetSchedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		host     = "host"
+		mac      = "mac"
+		schedule = "schedule"
+	)
+
+	successRes := map[string]any{
+		"key": map[string]any{},
+	}
+	someErr := errors.New("some err")
+
+	tests := []struct {
+		name             string
+		expectedErr      error
+		expectedErrStr   string
+		validateRequest  func(req *http.Request)
+		getResponse      func() *http.Response
+		getResponseError func() error
+		schedule         string
+	}{
+		{
+			name: "success set schedule",
+			validateRequest: func(req *http.Request) {
+				assert.Equal(t, host+ipHotspotHostURL, req.URL.String())
+				assert.Equal(t, "application/json;charset=UTF-8", req.Header.Get("Content-Type"))
+				assert.Equal(t, http.MethodPost, req.Method)
+
+				b, err := io.ReadAll(req.Body)
+				assert.Nil(t, err)
+				assert.JSONEq(t, `{"mac":"mac","schedule":"schedule"}`, string(b))
+			},
+			schedule: schedule,
+			getResponse: func() *http.Response {
+				body := successRes
+				bodyStr, err := json.Marshal(body)
+				assert.Nil(t, err)
+
+				bytesReader := bytes.NewReader(bodyStr)
+				resp := http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytesReader),
+				}
+				return &resp
+			},
+			getResponseError: func() error {
+				return nil
+			},
+		},
+		{
+			name: "success set schedule to none",
+			validateRequest: func(req *http.Request) {
+				assert.Equal(t, host+ipHotspotHostURL, req.URL.String())
+				assert.Equal(t, http.MethodPost, req.Method)
+
+				b, err := io.ReadAll(req.Body)
+				assert.Nil(t, err)
+				assert.JSONEq(t, `{"mac":"mac","schedule":false}`, string(b))
+			},
+			schedule: homeassistantdto.NoneSchedule,
+			getResponse: func() *http.Response {
+				body := successRes
+				bodyStr, err := json.Marshal(body)
+				assert.Nil(t, err)
+
+				bytesReader := bytes.NewReader(bodyStr)
+				resp := http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytesReader),
+				}
+				return &resp
+			},
+			getResponseError: func() error {
+				return nil
+			},
+		},
+		{
+			name:            "error from client",
+			validateRequest: func(req *http.Request) {},
+			schedule:        schedule,
+			getResponse: func() *http.Response {
+				return nil
+			},
+			getResponseError: func() error {
+				return someErr
+			},
+			expectedErr: someErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := mock_accessupdate.NewMockclient(ctrl)
+			client.EXPECT().Do(gomock.Cond(func(x any) bool {
+				req, ok := x.(*http.Request)
+				if !ok || req == nil {
+					t.Errorf("empty request")
+					return false
+				}
+				tt.validateRequest(req)
+				return true
+			})).Return(tt.getResponse(), tt.getResponseError())
+
+			accessUpdate := NewAccessUpdate(host, client)
+			err := accessUpdate.SetSchedule(mac, tt.schedule)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else if tt.expectedErrStr != "" {
+				assert.Regexp(t, tt.expectedErrStr+".*", err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package keenetic
 
 import (
 	"net/http"
+	"sync"
 )
 
 //go:generate mockgen -source=auth.go -destination=../../../test/mocks/gomock/clients/keenetic/auth.go
@@ -14,17 +15,81 @@ type authClient interface {
 	RefreshAuth() error
 }
 
+// authRoundTripper sends requests with current session cookie and re-authenticates only when keenetic
+// responds with 401.
 type authRoundTripper struct {
-	proxied roundTripper
-	auth    authClient
+	proxied   roundTripper
+	auth      authClient
+	cookiejar http.CookieJar
+
+	// session is incremented after every re-authentication,
+	// so concurrent requests with expired session re-authenticate only once.
+	session uint64
+	mutex   sync.Mutex
 }
 
-// RoundTrip checks and refresh auth before requests.
+// RoundTrip sends request and, if session is expired, re-authenticates and replays request once.
 func (rt *authRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
-	if err := rt.auth.RefreshAuth(); err != nil {
+	rt.mutex.Lock()
+	session := rt.session
+	rt.mutex.Unlock()
+
+	res, err := rt.proxied.RoundTrip(req)
+	if err != nil || res.StatusCode != http.StatusUnauthorized {
+		return res, err
+	}
+	// request body is already read and can't be replayed
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return res, nil
+	}
+	res.Body.Close()
+
+	if err := rt.refreshAuth(session); err != nil {
 		return nil, err
 	}
-	res, err := rt.proxied.RoundTrip(req)
 
-	return res, err
+	replay, err := rt.replayRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return rt.proxied.RoundTrip(replay)
+}
+
+// refreshAuth re-authenticates if nobody did it after session was taken.
+func (rt *authRoundTripper) refreshAuth(session uint64) error {
+	rt.mutex.Lock()
+	defer rt.mutex.Unlock()
+
+	if rt.session != session {
+		return nil
+	}
+	if err := rt.auth.RefreshAuth(); err != nil {
+		return err
+	}
+	rt.session++
+
+	return nil
+}
+
+// replayRequest copies request with new body and new session cookie.
+func (rt *authRoundTripper) replayRequest(req *http.Request) (*http.Request, error) {
+	replay := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		replay.Body = body
+	}
+
+	// http.Client adds cookies before round trip, so cookie header contains expired session
+	replay.Header.Del("Cookie")
+	if rt.cookiejar != nil {
+		for _, cookie := range rt.cookiejar.Cookies(req.URL) {
+			replay.AddCookie(cookie)
+		}
+	}
+
+	return replay, nil
 }
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"keeneticToMqtt/internal/errs"
 )
@@ -34,12 +35,17 @@ type Auth struct {
 }
 
 // NewAuth creates new Auth.
-func NewAuth(host, login, password string, cookiejar http.CookieJar) *Auth {
+// transport is shared with rci client, timeout limits whole request time.
+func NewAuth(host, login, password string, cookiejar http.CookieJar, transport http.RoundTripper, timeout time.Duration) *Auth {
 	return &Auth{
 		login:    login,
 		password: password,
 		host:     host,
-		client:   &http.Client{Jar: cookiejar},
+		client: &http.Client{
+			Jar:       cookiejar,
+			Transport: transport,
+			Timeout:   timeout,
+		},
 	}
 }
 
@@ -8,6 +8,7 @@ import (
 	"net/http/cookiejar"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -267,7 +268,7 @@ func TestAuth_RefreshAuth(t *testing.T) {
 			}
 
 			cookie, _ := cookiejar.New(&cookiejar.Options{})
-			auth := NewAuth(host, login, password, cookie)
+			auth := NewAuth(host, login, password, cookie, http.DefaultTransport, time.Second)
 			auth.client = client
 			err := auth.RefreshAuth()
 			if tt.expectedErr != nil {
@@ -280,3 +281,16 @@ func TestAuth_RefreshAuth(t *testing.T) {
 		})
 	}
 }
+
+func TestNewAuth(t *testing.T) {
+	transport := &http.Transport{}
+	cookie, _ := cookiejar.New(&cookiejar.Options{})
+
+	auth := NewAuth("host", "login", "password", cookie, transport, time.Second)
+
+	client, ok := auth.client.(*http.Client)
+	assert.True(t, ok)
+	assert.Same(t, transport, client.Transport)
+	assert.Equal(t, time.Second, client.Timeout)
+	assert.Equal(t, cookie, client.Jar)
+}
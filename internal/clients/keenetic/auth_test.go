@@ -2,7 +2,11 @@ package keenetic
 
 import (
 	"errors"
+	"io"
 	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -14,62 +18,162 @@ func TestAuthRoundTripper_RoundTrip(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	req := &http.Request{}
-	resp := &http.Response{}
+	const (
+		body = "body"
+		host = "http://keenetic"
+	)
+
+	hostURL, _ := url.Parse(host)
 	someErr := errors.New("some err")
 
+	newRequest := func() *http.Request {
+		req, _ := http.NewRequest(http.MethodPost, host+"/rci/ip/hotspot/host", strings.NewReader(body))
+		req.Header.Set("Cookie", "session=expired")
+		return req
+	}
+	newResponse := func(statusCode int) *http.Response {
+		return &http.Response{StatusCode: statusCode, Body: io.NopCloser(strings.NewReader(""))}
+	}
+	// validateReplay checks that replayed request has body and new session cookie.
+	validateReplay := func(req *http.Request) {
+		b, err := io.ReadAll(req.Body)
+		assert.Nil(t, err)
+		assert.Equal(t, body, string(b))
+		assert.Equal(t, "session=new", req.Header.Get("Cookie"))
+	}
+
 	tests := []struct {
-		name    string
-		auth    func() authClient
-		proxied func() roundTripper
-		resp    *http.Response
-		err     error
+		name           string
+		request        func() *http.Request
+		auth           func() authClient
+		proxied        func(rt *authRoundTripper) roundTripper
+		expectedStatus int
+		expectedErr    error
 	}{
 		{
-			name: "success",
+			name:    "success without auth",
+			request: newRequest,
+			auth: func() authClient {
+				return mock_keenetic.NewMockauthClient(ctrl)
+			},
+			proxied: func(rt *authRoundTripper) roundTripper {
+				proxied := mock_keenetic.NewMockroundTripper(ctrl)
+				proxied.EXPECT().RoundTrip(gomock.Any()).Return(newResponse(http.StatusOK), nil)
+				return proxied
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "request error",
+			request: newRequest,
+			auth: func() authClient {
+				return mock_keenetic.NewMockauthClient(ctrl)
+			},
+			proxied: func(rt *authRoundTripper) roundTripper {
+				proxied := mock_keenetic.NewMockroundTripper(ctrl)
+				proxied.EXPECT().RoundTrip(gomock.Any()).Return(nil, someErr)
+				return proxied
+			},
+			expectedErr: someErr,
+		},
+		{
+			name:    "expired session, auth and replay",
+			request: newRequest,
 			auth: func() authClient {
 				auth := mock_keenetic.NewMockauthClient(ctrl)
 				auth.EXPECT().RefreshAuth().Return(nil)
 				return auth
 			},
-			proxied: func() roundTripper {
+			proxied: func(rt *authRoundTripper) roundTripper {
 				proxied := mock_keenetic.NewMockroundTripper(ctrl)
-				proxied.EXPECT().RoundTrip(req).Return(resp, nil)
+				gomock.InOrder(
+					proxied.EXPECT().RoundTrip(gomock.Any()).Return(newResponse(http.StatusUnauthorized), nil),
+					proxied.EXPECT().RoundTrip(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+						validateReplay(req)
+						return newResponse(http.StatusOK), nil
+					}),
+				)
 				return proxied
 			},
-			resp: resp,
+			expectedStatus: http.StatusOK,
 		},
 		{
-			name: "auth error",
+			name:    "expired session, concurrent request already authenticated",
+			request: newRequest,
+			auth: func() authClient {
+				return mock_keenetic.NewMockauthClient(ctrl)
+			},
+			proxied: func(rt *authRoundTripper) roundTripper {
+				proxied := mock_keenetic.NewMockroundTripper(ctrl)
+				gomock.InOrder(
+					proxied.EXPECT().RoundTrip(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+						rt.mutex.Lock()
+						rt.session++
+						rt.mutex.Unlock()
+						return newResponse(http.StatusUnauthorized), nil
+					}),
+					proxied.EXPECT().RoundTrip(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+						validateReplay(req)
+						return newResponse(http.StatusOK), nil
+					}),
+				)
+				return proxied
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "expired session, auth error",
+			request: newRequest,
 			auth: func() authClient {
 				auth := mock_keenetic.NewMockauthClient(ctrl)
 				auth.EXPECT().RefreshAuth().Return(someErr)
 				return auth
 			},
-			proxied: func() roundTripper {
+			proxied: func(rt *authRoundTripper) roundTripper {
+				proxied := mock_keenetic.NewMockroundTripper(ctrl)
+				proxied.EXPECT().RoundTrip(gomock.Any()).Return(newResponse(http.StatusUnauthorized), nil)
+				return proxied
+			},
+			expectedErr: someErr,
+		},
+		{
+			name: "expired session, body can't be replayed",
+			request: func() *http.Request {
+				req := newRequest()
+				req.GetBody = nil
+				return req
+			},
+			auth: func() authClient {
+				return mock_keenetic.NewMockauthClient(ctrl)
+			},
+			proxied: func(rt *authRoundTripper) roundTripper {
 				proxied := mock_keenetic.NewMockroundTripper(ctrl)
+				proxied.EXPECT().RoundTrip(gomock.Any()).Return(newResponse(http.StatusUnauthorized), nil)
 				return proxied
 			},
-			err: someErr,
+			expectedStatus: http.StatusUnauthorized,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			rt := authRoundTripper{
-				proxied: tt.proxied(),
-				auth:    tt.auth(),
+			jar, _ := cookiejar.New(&cookiejar.Options{})
+			jar.SetCookies(hostURL, []*http.Cookie{{Name: "session", Value: "new"}})
+
+			rt := &authRoundTripper{
+				auth:      tt.auth(),
+				cookiejar: jar,
 			}
+			rt.proxied = tt.proxied(rt)
 
-			result, err := rt.RoundTrip(req)
-			if tt.err != nil {
+			result, err := rt.RoundTrip(tt.request())
+			if tt.expectedErr != nil {
 				assert.Nil(t, result)
-				assert.ErrorIs(t, err, tt.err)
+				assert.ErrorIs(t, err, tt.expectedErr)
 			} else {
 				assert.Nil(t, err)
-				assert.Same(t, tt.resp, result)
+				assert.Equal(t, tt.expectedStatus, result.StatusCode)
 			}
-
 		})
 	}
 }
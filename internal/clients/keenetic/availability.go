@@ -0,0 +1,24 @@
+package keenetic
+
+import (
+	"net/http"
+)
+
+//go:generate mockgen -source=availability.go -destination=../../../test/mocks/gomock/clients/keenetic/availability.go
+
+type availability interface {
+	SetRouterAvailable(available bool)
+}
+
+type availabilityRoundTripper struct {
+	proxied      roundTripper
+	availability availability
+}
+
+// RoundTrip reports router as unavailable if request fails and as available otherwise.
+func (rt *availabilityRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := rt.proxied.RoundTrip(req)
+	rt.availability.SetRouterAvailable(err == nil)
+
+	return res, err
+}
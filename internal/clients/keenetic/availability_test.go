@@ -0,0 +1,75 @@
+package keenetic
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	mock_keenetic "keeneticToMqtt/test/mocks/gomock/clients/keenetic"
+)
+
+func TestAvailabilityRoundTripper_RoundTrip(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := &http.Request{}
+	resp := &http.Response{}
+	someErr := errors.New("some err")
+
+	tests := []struct {
+		name         string
+		availability func() availability
+		proxied      func() roundTripper
+		resp         *http.Response
+		err          error
+	}{
+		{
+			name: "success",
+			availability: func() availability {
+				availability := mock_keenetic.NewMockavailability(ctrl)
+				availability.EXPECT().SetRouterAvailable(true)
+				return availability
+			},
+			proxied: func() roundTripper {
+				proxied := mock_keenetic.NewMockroundTripper(ctrl)
+				proxied.EXPECT().RoundTrip(req).Return(resp, nil)
+				return proxied
+			},
+			resp: resp,
+		},
+		{
+			name: "request error",
+			availability: func() availability {
+				availability := mock_keenetic.NewMockavailability(ctrl)
+				availability.EXPECT().SetRouterAvailable(false)
+				return availability
+			},
+			proxied: func() roundTripper {
+				proxied := mock_keenetic.NewMockroundTripper(ctrl)
+				proxied.EXPECT().RoundTrip(req).Return(nil, someErr)
+				return proxied
+			},
+			err: someErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := availabilityRoundTripper{
+				proxied:      tt.proxied(),
+				availability: tt.availability(),
+			}
+
+			result, err := rt.RoundTrip(req)
+			if tt.err != nil {
+				assert.Nil(t, result)
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.Nil(t, err)
+				assert.Same(t, tt.resp, result)
+			}
+		})
+	}
+}
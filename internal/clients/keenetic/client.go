@@ -4,6 +4,7 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/cookiejar"
+	"time"
 
 	"keeneticToMqtt/internal/logger"
 )
@@ -17,19 +18,32 @@ type Keenetic struct {
 }
 
 // NewKeenetic creates new Keenetic.
-func NewKeenetic(auth authClient, cookiejar *cookiejar.Jar, host, login, password string, log *slog.Logger) *Keenetic {
+// transport is shared with auth client, timeout limits whole request time.
+func NewKeenetic(
+	auth authClient,
+	availability availability,
+	transport http.RoundTripper,
+	timeout time.Duration,
+	cookiejar *cookiejar.Jar,
+	host, login, password string,
+	log *slog.Logger,
+) *Keenetic {
 	keenetic := &Keenetic{
 		host:     host,
 		login:    login,
 		password: password,
 	}
 
-	t := &http.Transport{}
-
 	var rt http.RoundTripper
 	rt = &authRoundTripper{
-		proxied: t,
-		auth:    auth,
+		proxied:   transport,
+		auth:      auth,
+		cookiejar: cookiejar,
+	}
+
+	rt = &availabilityRoundTripper{
+		proxied:      rt,
+		availability: availability,
 	}
 
 	rt = &logger.RoundTripper{
@@ -41,6 +55,7 @@ func NewKeenetic(auth authClient, cookiejar *cookiejar.Jar, host, login, passwor
 	client := &http.Client{
 		Transport: rt,
 		Jar:       cookiejar,
+		Timeout:   timeout,
 	}
 
 	keenetic.client = client
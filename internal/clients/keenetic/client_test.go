@@ -2,8 +2,10 @@ package keenetic
 
 import (
 	"log/slog"
+	"net/http"
 	"net/http/cookiejar"
 	"testing"
+	"time"
 
 	"go.uber.org/mock/gomock"
 	mock_keenetic "keeneticToMqtt/test/mocks/gomock/clients/keenetic"
@@ -16,6 +18,7 @@ func TestNewKeenetic(t *testing.T) {
 	cookie, _ := cookiejar.New(&cookiejar.Options{})
 
 	auth := mock_keenetic.NewMockauthClient(ctrl)
+	availability := mock_keenetic.NewMockavailability(ctrl)
 
-	_ = NewKeenetic(auth, cookie, "host", "login", "pass", slog.Default())
+	_ = NewKeenetic(auth, availability, http.DefaultTransport, time.Second, cookie, "host", "login", "pass", slog.Default())
 }
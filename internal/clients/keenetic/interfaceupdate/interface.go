@@ -0,0 +1,101 @@
+package interfaceupdate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"keeneticToMqtt/internal/clients/keenetic/rci"
+	"keeneticToMqtt/internal/errs"
+)
+
+//go:generate mockgen -source=interface.go -destination=../../../../test/mocks/gomock/clients/keenetic/interfaceupdate/interface.go
+
+const (
+	interfaceURL = "/rci/interface"
+)
+
+type (
+	client interface {
+		Do(req *http.Request) (*http.Response, error)
+	}
+
+	upReq struct {
+		Name string `json:"name"`
+		Up   bool   `json:"up"`
+	}
+	downReq struct {
+		Name string `json:"name"`
+		Down bool   `json:"down"`
+	}
+)
+
+// NewInterfaceUpdate creates new InterfaceUpdate.
+func NewInterfaceUpdate(host string, client client) *InterfaceUpdate {
+	return &InterfaceUpdate{
+		host:   host,
+		client: client,
+	}
+}
+
+// InterfaceUpdate struct for controlling keenetic interfaces, for example wifi access points.
+type InterfaceUpdate struct {
+	host   string
+	client client
+}
+
+// SetUp enables or disables keenetic interface. Interface name is like WifiMaster0/AccessPoint1,
+// it is sent in request body, because rci splits url path by /.
+func (i *InterfaceUpdate) SetUp(name string, up bool) error {
+	var body interface{}
+	if up {
+		body = upReq{
+			Name: name,
+			Up:   true,
+		}
+	} else {
+		body = downReq{
+			Name: name,
+			Down: true,
+		}
+	}
+
+	b, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("RciInterface error: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, i.host+interfaceURL, bytes.NewReader(b))
+	if err != nil {
+		return fmt.Errorf("build request error in setinterface request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json;charset=UTF-8")
+
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send error in setinterface request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return errs.ErrUnauthorized
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error in setinterface request, status code: %d", resp.StatusCode)
+	}
+
+	resBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response body error in setinterface request: %w", err)
+	}
+
+	if err := rci.CheckStatus(resBytes); err != nil {
+		return fmt.Errorf("status error in setinterface request: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package interfaceupdate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"keeneticToMqtt/internal/errs"
+	mock_interfaceupdate "keeneticToMqtt/test/mocks/gomock/clients/keenetic/interfaceupdate"
+)
+
+func TestInterfaceUpdate_SetUp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		host = "host"
+		name = "WifiMaster0/AccessPoint1"
+	)
+
+	successRes := `{"up":{"status":[{"status":"message","message":"interface enabled"}]}}`
+	someErr := errors.New("some err")
+
+	okResponse := func(body string) func() *http.Response {
+		return func() *http.Response {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}
+		}
+	}
+
+	tests := []struct {
+		name             string
+		expectedErr      error
+		expectedErrStr   string
+		validateRequest  func(req *http.Request)
+		getResponse      func() *http.Response
+		getResponseError func() error
+		up               bool
+	}{
+		{
+			name: "success set interface up",
+			validateRequest: func(req *http.Request) {
+				assert.Equal(t, "host/rci/interface", req.URL.String())
+				assert.Equal(t, "application/json;charset=UTF-8", req.Header.Get("Content-Type"))
+				assert.Equal(t, http.MethodPost, req.Method)
+
+				b, err := io.ReadAll(req.Body)
+				assert.Nil(t, err)
+				assert.JSONEq(t, `{"name":"WifiMaster0/AccessPoint1","up":true}`, string(b))
+			},
+			up:          true,
+			getResponse: okResponse(successRes),
+			getResponseError: func() error {
+				return nil
+			},
+		},
+		{
+			name: "success set interface down",
+			validateRequest: func(req *http.Request) {
+				assert.Equal(t, "host/rci/interface", req.URL.String())
+				assert.Equal(t, http.MethodPost, req.Method)
+
+				b, err := io.ReadAll(req.Body)
+				assert.Nil(t, err)
+				assert.JSONEq(t, `{"name":"WifiMaster0/AccessPoint1","down":true}`, string(b))
+			},
+			up:          false,
+			getResponse: okResponse(successRes),
+			getResponseError: func() error {
+				return nil
+			},
+		},
+		{
+			name:            "permission denied status",
+			validateRequest: func(req *http.Request) {},
+			up:              true,
+			getResponse:     okResponse(`{"up":{"status":[{"status":"error","code":"7405602","ident":"Core::Authenticator","message":"permission denied."}]}}`),
+			getResponseError: func() error {
+				return nil
+			},
+			expectedErr: errs.ErrPermissionDenied,
+		},
+		{
+			name:            "empty resp",
+			validateRequest: func(req *http.Request) {},
+			up:              true,
+			getResponse: func() *http.Response {
+				bodyStr, err := json.Marshal(nil)
+				assert.Nil(t, err)
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewReader(bodyStr)),
+				}
+			},
+			getResponseError: func() error {
+				return nil
+			},
+			expectedErrStr: "status error in setinterface request: no status in response",
+		},
+		{
+			name:            "error from client",
+			validateRequest: func(req *http.Request) {},
+			getResponse: func() *http.Response {
+				return nil
+			},
+			getResponseError: func() error {
+				return someErr
+			},
+			expectedErr: someErr,
+		},
+		{
+			name:            "http.StatusUnauthorized status code",
+			validateRequest: func(req *http.Request) {},
+			getResponse: func() *http.Response {
+				return &http.Response{
+					StatusCode: http.StatusUnauthorized,
+					Body:       io.NopCloser(strings.NewReader("")),
+				}
+			},
+			getResponseError: func() error {
+				return nil
+			},
+			expectedErr: errs.ErrUnauthorized,
+		},
+		{
+			name:            "status code not 200",
+			validateRequest: func(req *http.Request) {},
+			getResponse: func() *http.Response {
+				return &http.Response{
+					StatusCode: http.StatusBadRequest,
+					Body:       io.NopCloser(strings.NewReader("")),
+				}
+			},
+			getResponseError: func() error {
+				return nil
+			},
+			expectedErrStr: "error in setinterface request, status code: 400",
+		},
+		{
+			name:            "error while unmarshal body",
+			validateRequest: func(req *http.Request) {},
+			getResponse:     okResponse(""),
+			getResponseError: func() error {
+				return nil
+			},
+			expectedErrStr: "status error in setinterface request: unmarshal status error:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := mock_interfaceupdate.NewMockclient(ctrl)
+			client.EXPECT().Do(gomock.Cond(func(x any) bool {
+				req, ok := x.(*http.Request)
+				if !ok || req == nil {
+					t.Errorf("empty request")
+					return false
+				}
+				tt.validateRequest(req)
+				return true
+			})).Return(tt.getResponse(), tt.getResponseError())
+
+			interfaceUpdate := NewInterfaceUpdate(host, client)
+			err := interfaceUpdate.SetUp(name, tt.up)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else if tt.expectedErrStr != "" {
+				assert.Regexp(t, tt.expectedErrStr+".*", err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
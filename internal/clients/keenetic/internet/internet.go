@@ -0,0 +1,52 @@
+package internet
+
+import (
+	"fmt"
+
+	"keeneticToMqtt/internal/clients/keenetic/rci"
+	"keeneticToMqtt/internal/dto/keeneticdto"
+)
+
+//go:generate mockgen -source=internet.go -destination=../../../../test/mocks/gomock/clients/keenetic/internet/internet.go
+
+const (
+	interfaceStatPath = "show/interface/stat"
+)
+
+type (
+	collector interface {
+		NewBatch() *rci.Batch
+	}
+)
+
+// Internet struct to get keenetic interface traffic counters.
+type Internet struct {
+	collector collector
+}
+
+// NewInternet creates new Internet.
+func NewInternet(collector collector) *Internet {
+	return &Internet{
+		collector: collector,
+	}
+}
+
+// GetInterfaceStats returns traffic counters of keenetic interfaces by interface name in one batched request.
+func (i *Internet) GetInterfaceStats(names []string) (map[string]keeneticdto.InterfaceStatResponse, error) {
+	stats := make([]keeneticdto.InterfaceStatResponse, len(names))
+
+	batch := i.collector.NewBatch()
+	for idx, name := range names {
+		batch.Add(interfaceStatPath, map[string]any{"name": name}, &stats[idx])
+	}
+	if err := batch.Send(); err != nil {
+		return nil, fmt.Errorf("error in GetInterfaceStats request: %w", err)
+	}
+
+	res := make(map[string]keeneticdto.InterfaceStatResponse, len(names))
+	for idx, name := range names {
+		res[name] = stats[idx]
+	}
+
+	return res, nil
+}
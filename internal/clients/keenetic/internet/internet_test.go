@@ -1,8 +1,6 @@
-package policylist
+package internet
 
 import (
-	"bytes"
-	"encoding/json"
 	"errors"
 	"io"
 	"net/http"
@@ -11,28 +9,26 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+	"keeneticToMqtt/internal/clients/keenetic/rci"
 	"keeneticToMqtt/internal/dto/keeneticdto"
 	"keeneticToMqtt/internal/errs"
-	mock_policylist "keeneticToMqtt/test/mocks/gomock/clients/keenetic/policylist"
+	mock_rci "keeneticToMqtt/test/mocks/gomock/clients/keenetic/rci"
 )
 
-func TestPolicyList_GetPolicyList(t *testing.T) {
+func TestInternet_GetInterfaceStats(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	const (
-		host   = "host"
-		policy = "policy"
+		host = "host"
 	)
 
-	successRes := map[string]keeneticdto.Policy{
-		policy: {},
-	}
 	someErr := errors.New("some err")
 
 	tests := []struct {
 		name             string
-		expected         map[string]keeneticdto.Policy
+		names            []string
+		expected         map[string]keeneticdto.InterfaceStatResponse
 		expectedErr      error
 		expectedErrStr   string
 		validateRequest  func(req *http.Request)
@@ -40,31 +36,35 @@ func TestPolicyList_GetPolicyList(t *testing.T) {
 		getResponseError func() error
 	}{
 		{
-			name: "success get policy list",
+			name:  "success get interface stats",
+			names: []string{"PPPoE0", "UsbLte0"},
 			validateRequest: func(req *http.Request) {
-				assert.Equal(t, host+policyListUrl, req.URL.String())
-				assert.Equal(t, "application/json;charset=UTF-8", req.Header.Get("Content-Type"))
-				assert.Equal(t, http.MethodGet, req.Method)
+				assert.Equal(t, host+"/rci/", req.URL.String())
+				assert.Equal(t, http.MethodPost, req.Method)
+
+				body, err := io.ReadAll(req.Body)
+				assert.Nil(t, err)
+				assert.JSONEq(t, `[{"show":{"interface":{"stat":{"name":"PPPoE0"}}}},{"show":{"interface":{"stat":{"name":"UsbLte0"}}}}]`, string(body))
 			},
 			getResponse: func() *http.Response {
-				body := successRes
-				bodyStr, err := json.Marshal(body)
-				assert.Nil(t, err)
-
-				bytesReader := bytes.NewReader(bodyStr)
+				bodyStr := `[{"show":{"interface":{"stat":{"rxbytes":100,"txbytes":200}}}},{"show":{"interface":{"stat":{"rxbytes":300,"txbytes":400}}}}]`
 				resp := http.Response{
 					StatusCode: http.StatusOK,
-					Body:       io.NopCloser(bytesReader),
+					Body:       io.NopCloser(strings.NewReader(bodyStr)),
 				}
 				return &resp
 			},
 			getResponseError: func() error {
 				return nil
 			},
-			expected: successRes,
+			expected: map[string]keeneticdto.InterfaceStatResponse{
+				"PPPoE0":  {RxBytes: 100, TxBytes: 200},
+				"UsbLte0": {RxBytes: 300, TxBytes: 400},
+			},
 		},
 		{
 			name:            "error from client",
+			names:           []string{"PPPoE0"},
 			validateRequest: func(req *http.Request) {},
 			getResponse: func() *http.Response {
 				return nil
@@ -76,6 +76,7 @@ func TestPolicyList_GetPolicyList(t *testing.T) {
 		},
 		{
 			name:            "http.StatusUnauthorized status code",
+			names:           []string{"PPPoE0"},
 			validateRequest: func(req *http.Request) {},
 			getResponse: func() *http.Response {
 				bytesReader := strings.NewReader("")
@@ -91,42 +92,26 @@ func TestPolicyList_GetPolicyList(t *testing.T) {
 			expectedErr: errs.ErrUnauthorized,
 		},
 		{
-			name:            "status code not 200",
-			validateRequest: func(req *http.Request) {},
-			getResponse: func() *http.Response {
-				bytesReader := strings.NewReader("")
-				resp := http.Response{
-					StatusCode: http.StatusBadRequest,
-					Body:       io.NopCloser(bytesReader),
-				}
-				return &resp
-			},
-			getResponseError: func() error {
-				return nil
-			},
-			expectedErrStr: "error in GetPolicyList request, status code: 400",
-		},
-		{
-			name:            "error while unmarshal body",
+			name:            "unexpected results count",
+			names:           []string{"PPPoE0", "UsbLte0"},
 			validateRequest: func(req *http.Request) {},
 			getResponse: func() *http.Response {
-				stringReader := strings.NewReader("")
 				resp := http.Response{
 					StatusCode: http.StatusOK,
-					Body:       io.NopCloser(stringReader),
+					Body:       io.NopCloser(strings.NewReader(`[{}]`)),
 				}
 				return &resp
 			},
 			getResponseError: func() error {
 				return nil
 			},
-			expectedErrStr: "unmarshal response error in setpolicy request:",
+			expectedErrStr: "error in GetInterfaceStats request: unexpected results count in Batch request: 1, expected 2",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			client := mock_policylist.NewMockclient(ctrl)
+			client := mock_rci.NewMockclient(ctrl)
 			client.EXPECT().Do(gomock.Cond(func(x any) bool {
 				req, ok := x.(*http.Request)
 				if !ok || req == nil {
@@ -137,8 +122,8 @@ func TestPolicyList_GetPolicyList(t *testing.T) {
 				return true
 			})).Return(tt.getResponse(), tt.getResponseError())
 
-			policyList := NewPolicyList(host, client)
-			res, err := policyList.GetPolicyList()
+			internet := NewInternet(rci.NewCollector(host, client, 0))
+			res, err := internet.GetInterfaceStats(tt.names)
 			if tt.expectedErr != nil {
 				assert.ErrorIs(t, err, tt.expectedErr)
 			} else if tt.expectedErrStr != "" {
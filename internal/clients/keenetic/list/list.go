@@ -1,110 +1,51 @@
 package list
 
 import (
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 
+	"keeneticToMqtt/internal/clients/keenetic/rci"
 	"keeneticToMqtt/internal/dto/keeneticdto"
-	"keeneticToMqtt/internal/errs"
 )
 
 //go:generate mockgen -source=list.go -destination=../../../../test/mocks/gomock/clients/keenetic/list/list.go
 
 const (
-	clientPolicyListUrl = "/rci/show/rc/ip/hotspot/host"
-	deviceListUrl       = "/rci/show/ip/hotspot/host"
+	clientPolicyListPath = "show/rc/ip/hotspot/host"
+	deviceListPath       = "show/ip/hotspot/host"
 )
 
 type (
-	client interface {
-		Do(req *http.Request) (*http.Response, error)
+	collector interface {
+		NewBatch() *rci.Batch
 	}
 )
 
 // List struct for get client lists from keenetic.
 type List struct {
-	host   string
-	client client
+	collector collector
 }
 
 // NewList creates new List.
-func NewList(host string, client client) *List {
+func NewList(collector collector) *List {
 	return &List{
-		host:   host,
-		client: client,
+		collector: collector,
 	}
 }
 
-// GetDeviceList returns keenetic device list.
-func (l *List) GetDeviceList() ([]keeneticdto.DeviceInfoResponse, error) {
-	req, err := http.NewRequest(http.MethodGet, l.host+deviceListUrl, nil)
+// GetClientLists returns keenetic device list and client policy list in one batched request.
+func (l *List) GetClientLists() ([]keeneticdto.DeviceInfoResponse, []keeneticdto.DevicePolicy, error) {
+	var (
+		devices  []keeneticdto.DeviceInfoResponse
+		policies []keeneticdto.DevicePolicy
+	)
+
+	err := l.collector.NewBatch().
+		Add(deviceListPath, nil, &devices).
+		Add(clientPolicyListPath, nil, &policies).
+		Send()
 	if err != nil {
-		return nil, fmt.Errorf("build request error in GetDeviceList request: %w", err)
+		return nil, nil, fmt.Errorf("error in GetClientLists request: %w", err)
 	}
 
-	req.Header.Set("Content-Type", "application/json;charset=UTF-8")
-
-	resp, err := l.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("send error in GetDeviceList request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode == http.StatusUnauthorized {
-		return nil, errs.ErrUnauthorized
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("error in GetDeviceList request, status code: %d", resp.StatusCode)
-	}
-
-	resBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("read response body error in GetDeviceList request: %w", err)
-	}
-	var res []keeneticdto.DeviceInfoResponse
-
-	if err := json.Unmarshal(resBytes, &res); err != nil {
-		return nil, fmt.Errorf("unmarshal response error in GetDeviceList request: %w", err)
-	}
-
-	return res, nil
-}
-
-// GetClientPolicyList returns keenetic policy list.
-func (l *List) GetClientPolicyList() ([]keeneticdto.DevicePolicy, error) {
-	req, err := http.NewRequest(http.MethodGet, l.host+clientPolicyListUrl, nil)
-	if err != nil {
-		return nil, fmt.Errorf("build request error in GetClientPolicyList request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json;charset=UTF-8")
-
-	resp, err := l.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("send error in GetClientPolicyList request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode == http.StatusUnauthorized {
-		return nil, errs.ErrUnauthorized
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("error in GetClientPolicyList request, status code: %d", resp.StatusCode)
-	}
-
-	resBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("read response body error in GetClientPolicyList request: %w", err)
-	}
-	var res []keeneticdto.DevicePolicy
-
-	if err := json.Unmarshal(resBytes, &res); err != nil {
-		return nil, fmt.Errorf("unmarshal response error in GetClientPolicyList request: %w", err)
-	}
-
-	return res, nil
+	return devices, policies, nil
 }
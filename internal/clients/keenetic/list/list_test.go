@@ -11,12 +11,13 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+	"keeneticToMqtt/internal/clients/keenetic/rci"
 	"keeneticToMqtt/internal/dto/keeneticdto"
 	"keeneticToMqtt/internal/errs"
-	mock_list "keeneticToMqtt/test/mocks/gomock/clients/keenetic/list"
+	mock_rci "keeneticToMqtt/test/mocks/gomock/clients/keenetic/rci"
 )
 
-func TestList_GetDeviceList(t *testing.T) {
+func TestList_GetClientLists(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -25,140 +26,8 @@ func TestList_GetDeviceList(t *testing.T) {
 		mac  = "mac"
 	)
 
-	successRes := []keeneticdto.DeviceInfoResponse{{Mac: mac}}
-	someErr := errors.New("some err")
-
-	tests := []struct {
-		name             string
-		expected         []keeneticdto.DeviceInfoResponse
-		expectedErr      error
-		expectedErrStr   string
-		validateRequest  func(req *http.Request)
-		getResponse      func() *http.Response
-		getResponseError func() error
-	}{
-		{
-			name: "success get device list",
-			validateRequest: func(req *http.Request) {
-				assert.Equal(t, host+deviceListUrl, req.URL.String())
-				assert.Equal(t, "application/json;charset=UTF-8", req.Header.Get("Content-Type"))
-				assert.Equal(t, http.MethodGet, req.Method)
-			},
-			getResponse: func() *http.Response {
-				body := successRes
-				bodyStr, err := json.Marshal(body)
-				assert.Nil(t, err)
-
-				bytesReader := bytes.NewReader(bodyStr)
-				resp := http.Response{
-					StatusCode: http.StatusOK,
-					Body:       io.NopCloser(bytesReader),
-				}
-				return &resp
-			},
-			getResponseError: func() error {
-				return nil
-			},
-			expected: successRes,
-		},
-		{
-			name:            "error from client",
-			validateRequest: func(req *http.Request) {},
-			getResponse: func() *http.Response {
-				return nil
-			},
-			getResponseError: func() error {
-				return someErr
-			},
-			expectedErr: someErr,
-		},
-		{
-			name:            "http.StatusUnauthorized status code",
-			validateRequest: func(req *http.Request) {},
-			getResponse: func() *http.Response {
-				bytesReader := strings.NewReader("")
-				resp := http.Response{
-					StatusCode: http.StatusUnauthorized,
-					Body:       io.NopCloser(bytesReader),
-				}
-				return &resp
-			},
-			getResponseError: func() error {
-				return nil
-			},
-			expectedErr: errs.ErrUnauthorized,
-		},
-		{
-			name:            "status code not 200",
-			validateRequest: func(req *http.Request) {},
-			getResponse: func() *http.Response {
-				bytesReader := strings.NewReader("")
-				resp := http.Response{
-					StatusCode: http.StatusBadRequest,
-					Body:       io.NopCloser(bytesReader),
-				}
-				return &resp
-			},
-			getResponseError: func() error {
-				return nil
-			},
-			expectedErrStr: "error in GetDeviceList request, status code: 400",
-		},
-		{
-			name:            "error while unmarshal body",
-			validateRequest: func(req *http.Request) {},
-			getResponse: func() *http.Response {
-				stringReader := strings.NewReader("")
-				resp := http.Response{
-					StatusCode: http.StatusOK,
-					Body:       io.NopCloser(stringReader),
-				}
-				return &resp
-			},
-			getResponseError: func() error {
-				return nil
-			},
-			expectedErrStr: "unmarshal response error in GetDeviceList request:",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			client := mock_list.NewMockclient(ctrl)
-			client.EXPECT().Do(gomock.Cond(func(x any) bool {
-				req, ok := x.(*http.Request)
-				if !ok || req == nil {
-					t.Errorf("empty request")
-					return false
-				}
-				tt.validateRequest(req)
-				return true
-			})).Return(tt.getResponse(), tt.getResponseError())
-
-			list := NewList(host, client)
-			res, err := list.GetDeviceList()
-			if tt.expectedErr != nil {
-				assert.ErrorIs(t, err, tt.expectedErr)
-			} else if tt.expectedErrStr != "" {
-				assert.Regexp(t, tt.expectedErrStr+".*", err.Error())
-			} else {
-				assert.Equal(t, tt.expected, res)
-				assert.Nil(t, err)
-			}
-		})
-	}
-}
-
-func TestList_GetClientPolicyList(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	const (
-		host = "host"
-		mac  = "mac"
-	)
-
-	successRes := []keeneticdto.DevicePolicy{{
+	successDevices := []keeneticdto.DeviceInfoResponse{{Mac: mac}}
+	successPolicies := []keeneticdto.DevicePolicy{{
 		Mac:    mac,
 		Permit: true,
 	}}
@@ -166,7 +35,8 @@ func TestList_GetClientPolicyList(t *testing.T) {
 
 	tests := []struct {
 		name             string
-		expected         []keeneticdto.DevicePolicy
+		expectedDevices  []keeneticdto.DeviceInfoResponse
+		expectedPolicies []keeneticdto.DevicePolicy
 		expectedErr      error
 		expectedErrStr   string
 		validateRequest  func(req *http.Request)
@@ -174,14 +44,20 @@ func TestList_GetClientPolicyList(t *testing.T) {
 		getResponseError func() error
 	}{
 		{
-			name: "success get client policy list",
+			name: "success get client lists",
 			validateRequest: func(req *http.Request) {
-				assert.Equal(t, host+clientPolicyListUrl, req.URL.String())
-				assert.Equal(t, "application/json;charset=UTF-8", req.Header.Get("Content-Type"))
-				assert.Equal(t, http.MethodGet, req.Method)
+				assert.Equal(t, host+"/rci/", req.URL.String())
+				assert.Equal(t, http.MethodPost, req.Method)
+
+				body, err := io.ReadAll(req.Body)
+				assert.Nil(t, err)
+				assert.JSONEq(t, `[{"show":{"ip":{"hotspot":{"host":{}}}}},{"show":{"rc":{"ip":{"hotspot":{"host":{}}}}}}]`, string(body))
 			},
 			getResponse: func() *http.Response {
-				body := successRes
+				body := []map[string]any{
+					{"show": map[string]any{"ip": map[string]any{"hotspot": map[string]any{"host": successDevices}}}},
+					{"show": map[string]any{"rc": map[string]any{"ip": map[string]any{"hotspot": map[string]any{"host": successPolicies}}}}},
+				}
 				bodyStr, err := json.Marshal(body)
 				assert.Nil(t, err)
 
@@ -195,7 +71,8 @@ func TestList_GetClientPolicyList(t *testing.T) {
 			getResponseError: func() error {
 				return nil
 			},
-			expected: successRes,
+			expectedDevices:  successDevices,
+			expectedPolicies: successPolicies,
 		},
 		{
 			name:            "error from client",
@@ -224,22 +101,6 @@ func TestList_GetClientPolicyList(t *testing.T) {
 			},
 			expectedErr: errs.ErrUnauthorized,
 		},
-		{
-			name:            "status code not 200",
-			validateRequest: func(req *http.Request) {},
-			getResponse: func() *http.Response {
-				bytesReader := strings.NewReader("")
-				resp := http.Response{
-					StatusCode: http.StatusBadRequest,
-					Body:       io.NopCloser(bytesReader),
-				}
-				return &resp
-			},
-			getResponseError: func() error {
-				return nil
-			},
-			expectedErrStr: "error in GetClientPolicyList request, status code: 400",
-		},
 		{
 			name:            "error while unmarshal body",
 			validateRequest: func(req *http.Request) {},
@@ -254,13 +115,13 @@ func TestList_GetClientPolicyList(t *testing.T) {
 			getResponseError: func() error {
 				return nil
 			},
-			expectedErrStr: "unmarshal response error in GetClientPolicyList request:",
+			expectedErrStr: "error in GetClientLists request: unmarshal response error in Batch request:",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			client := mock_list.NewMockclient(ctrl)
+			client := mock_rci.NewMockclient(ctrl)
 			client.EXPECT().Do(gomock.Cond(func(x any) bool {
 				req, ok := x.(*http.Request)
 				if !ok || req == nil {
@@ -271,14 +132,15 @@ func TestList_GetClientPolicyList(t *testing.T) {
 				return true
 			})).Return(tt.getResponse(), tt.getResponseError())
 
-			list := NewList(host, client)
-			res, err := list.GetClientPolicyList()
+			list := NewList(rci.NewCollector(host, client, 0))
+			devices, policies, err := list.GetClientLists()
 			if tt.expectedErr != nil {
 				assert.ErrorIs(t, err, tt.expectedErr)
 			} else if tt.expectedErrStr != "" {
 				assert.Regexp(t, tt.expectedErrStr+".*", err.Error())
 			} else {
-				assert.Equal(t, tt.expected, res)
+				assert.Equal(t, tt.expectedDevices, devices)
+				assert.Equal(t, tt.expectedPolicies, policies)
 				assert.Nil(t, err)
 			}
 		})
@@ -0,0 +1,174 @@
+package rci
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"keeneticToMqtt/internal/errs"
+)
+
+//go:generate mockgen -source=batch.go -destination=../../../../test/mocks/gomock/clients/keenetic/rci/batch.go
+
+const (
+	batchUrl = "/rci/"
+)
+
+type (
+	client interface {
+		Do(req *http.Request) (*http.Response, error)
+	}
+
+	command struct {
+		path   []string
+		params map[string]any
+		target any
+	}
+)
+
+// Batch collects rci commands and sends them to keenetic in one request.
+// Keenetic returns results in the same order, every result is unmarshalled to its command target.
+type Batch struct {
+	host      string
+	client    client
+	collector *Collector
+	commands  []command
+}
+
+// NewBatch creates new Batch.
+func NewBatch(host string, client client) *Batch {
+	return &Batch{
+		host:   host,
+		client: client,
+	}
+}
+
+// Add adds command to batch.
+// path is rci command path like "show/ip/hotspot/host", params are command arguments, can be nil.
+// target is pointer to command result, it is filled by Send.
+func (b *Batch) Add(path string, params map[string]any, target any) *Batch {
+	b.commands = append(b.commands, command{
+		path:   strings.Split(path, "/"),
+		params: params,
+		target: target,
+	})
+
+	return b
+}
+
+// Send sends all commands in one request and fills command targets.
+// Batch created by Collector is sent together with other batches of the Collector.
+func (b *Batch) Send() error {
+	if len(b.commands) == 0 {
+		return nil
+	}
+
+	if b.collector != nil {
+		return b.collector.send(b.commands)
+	}
+
+	results, err := post(b.host, b.client, b.commands)
+	if err != nil {
+		return err
+	}
+
+	return unmarshalResults(b.commands, results)
+}
+
+// post sends commands to keenetic and returns raw result of every command.
+func post(host string, client client, commands []command) ([]json.RawMessage, error) {
+	body := make([]map[string]any, 0, len(commands))
+	for _, cmd := range commands {
+		body = append(body, cmd.body())
+	}
+
+	reqBody, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("marshal request error in Batch request: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, host+batchUrl, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("build request error in Batch request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json;charset=UTF-8")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send error in Batch request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, errs.ErrUnauthorized
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error in Batch request, status code: %d", resp.StatusCode)
+	}
+
+	resBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response body error in Batch request: %w", err)
+	}
+
+	var results []json.RawMessage
+	if err := json.Unmarshal(resBytes, &results); err != nil {
+		return nil, fmt.Errorf("unmarshal response error in Batch request: %w", err)
+	}
+
+	if len(results) != len(commands) {
+		return nil, fmt.Errorf("unexpected results count in Batch request: %d, expected %d", len(results), len(commands))
+	}
+
+	return results, nil
+}
+
+// unmarshalResults fills command targets with command results.
+func unmarshalResults(commands []command, results []json.RawMessage) error {
+	for i, cmd := range commands {
+		if err := cmd.unmarshal(results[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// body returns command as nested objects, for example {"show":{"ip":{"hotspot":{"host":{}}}}}.
+func (c command) body() map[string]any {
+	body := c.params
+	if body == nil {
+		body = map[string]any{}
+	}
+	for i := len(c.path) - 1; i >= 0; i-- {
+		body = map[string]any{c.path[i]: body}
+	}
+
+	return body
+}
+
+// unmarshal unmarshals command result, which is nested the same way as command, to command target.
+func (c command) unmarshal(result json.RawMessage) error {
+	for _, key := range c.path {
+		var nested map[string]json.RawMessage
+		if err := json.Unmarshal(result, &nested); err != nil {
+			return fmt.Errorf("unmarshal %s result error in Batch request: %w", strings.Join(c.path, "/"), err)
+		}
+
+		var ok bool
+		if result, ok = nested[key]; !ok {
+			return fmt.Errorf("no %s result in Batch request", strings.Join(c.path, "/"))
+		}
+	}
+
+	if err := json.Unmarshal(result, c.target); err != nil {
+		return fmt.Errorf("unmarshal %s result error in Batch request: %w", strings.Join(c.path, "/"), err)
+	}
+
+	return nil
+}
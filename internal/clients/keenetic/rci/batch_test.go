@@ -0,0 +1,158 @@
+package rci
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"keeneticToMqtt/internal/errs"
+	mock_rci "keeneticToMqtt/test/mocks/gomock/clients/keenetic/rci"
+)
+
+type (
+	host struct {
+		Mac string `json:"mac"`
+	}
+	stat struct {
+		RxBytes int64 `json:"rxbytes"`
+	}
+)
+
+func TestBatch_Send(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		hostUrl = "host"
+	)
+
+	someErr := errors.New("some err")
+
+	tests := []struct {
+		name             string
+		expectedHosts    []host
+		expectedStat     stat
+		expectedErr      error
+		expectedErrStr   string
+		validateRequest  func(req *http.Request)
+		responseBody     string
+		responseStatus   int
+		getResponseError func() error
+	}{
+		{
+			name: "success batch",
+			validateRequest: func(req *http.Request) {
+				assert.Equal(t, hostUrl+batchUrl, req.URL.String())
+				assert.Equal(t, "application/json;charset=UTF-8", req.Header.Get("Content-Type"))
+				assert.Equal(t, http.MethodPost, req.Method)
+
+				body, err := io.ReadAll(req.Body)
+				assert.Nil(t, err)
+				assert.JSONEq(t, `[{"show":{"ip":{"hotspot":{"host":{}}}}},{"show":{"interface":{"stat":{"name":"ISP"}}}}]`, string(body))
+			},
+			responseBody:   `[{"show":{"ip":{"hotspot":{"host":[{"mac":"mac"}]}}}},{"show":{"interface":{"stat":{"rxbytes":10}}}}]`,
+			responseStatus: http.StatusOK,
+			expectedHosts:  []host{{Mac: "mac"}},
+			expectedStat:   stat{RxBytes: 10},
+		},
+		{
+			name:             "error from client",
+			validateRequest:  func(req *http.Request) {},
+			getResponseError: func() error { return someErr },
+			expectedErr:      someErr,
+		},
+		{
+			name:            "http.StatusUnauthorized status code",
+			validateRequest: func(req *http.Request) {},
+			responseStatus:  http.StatusUnauthorized,
+			expectedErr:     errs.ErrUnauthorized,
+		},
+		{
+			name:            "status code not 200",
+			validateRequest: func(req *http.Request) {},
+			responseStatus:  http.StatusBadRequest,
+			expectedErrStr:  "error in Batch request, status code: 400",
+		},
+		{
+			name:            "error while unmarshal body",
+			validateRequest: func(req *http.Request) {},
+			responseStatus:  http.StatusOK,
+			expectedErrStr:  "unmarshal response error in Batch request:",
+		},
+		{
+			name:            "unexpected results count",
+			validateRequest: func(req *http.Request) {},
+			responseBody:    `[{"show":{"ip":{"hotspot":{"host":[]}}}}]`,
+			responseStatus:  http.StatusOK,
+			expectedErrStr:  "unexpected results count in Batch request: 1, expected 2",
+		},
+		{
+			name:            "no command result",
+			validateRequest: func(req *http.Request) {},
+			responseBody:    `[{"show":{"ip":{"hotspot":{"host":[]}}}},{"show":{"interface":{}}}]`,
+			responseStatus:  http.StatusOK,
+			expectedErrStr:  "no show/interface/stat result in Batch request",
+		},
+		{
+			name:            "error while unmarshal command result",
+			validateRequest: func(req *http.Request) {},
+			responseBody:    `[{"show":{"ip":{"hotspot":{"host":{}}}}},{"show":{"interface":{"stat":{}}}}]`,
+			responseStatus:  http.StatusOK,
+			expectedErrStr:  "unmarshal show/ip/hotspot/host result error in Batch request:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp *http.Response
+			var respErr error
+			if tt.getResponseError != nil {
+				respErr = tt.getResponseError()
+			} else {
+				resp = &http.Response{
+					StatusCode: tt.responseStatus,
+					Body:       io.NopCloser(strings.NewReader(tt.responseBody)),
+				}
+			}
+
+			client := mock_rci.NewMockclient(ctrl)
+			client.EXPECT().Do(gomock.Cond(func(x any) bool {
+				req, ok := x.(*http.Request)
+				if !ok || req == nil {
+					t.Errorf("empty request")
+					return false
+				}
+				tt.validateRequest(req)
+				return true
+			})).Return(resp, respErr)
+
+			var hosts []host
+			var s stat
+			err := NewBatch(hostUrl, client).
+				Add("show/ip/hotspot/host", nil, &hosts).
+				Add("show/interface/stat", map[string]any{"name": "ISP"}, &s).
+				Send()
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else if tt.expectedErrStr != "" {
+				assert.Regexp(t, tt.expectedErrStr+".*", err.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.expectedHosts, hosts)
+				assert.Equal(t, tt.expectedStat, s)
+			}
+		})
+	}
+}
+
+func TestBatch_Send_empty(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock_rci.NewMockclient(ctrl)
+	assert.Nil(t, NewBatch("host", client).Send())
+}
@@ -0,0 +1,73 @@
+package rci
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// Collector merges batches, which are sent within collect window, into one keenetic request,
+// so reads of different pollers on the same polling tick cost one request.
+// Every batch gets its own results and the common request error.
+type Collector struct {
+	host    string
+	client  client
+	window  time.Duration
+	mutex   sync.Mutex
+	pending *collected
+}
+
+// collected is merged batch, which waits for the end of collect window.
+type collected struct {
+	commands []command
+	results  []json.RawMessage
+	err      error
+	done     chan struct{}
+}
+
+// NewCollector creates new Collector.
+func NewCollector(host string, client client, window time.Duration) *Collector {
+	return &Collector{
+		host:   host,
+		client: client,
+		window: window,
+	}
+}
+
+// NewBatch creates new Batch, which is sent by Collector.
+func (c *Collector) NewBatch() *Batch {
+	return &Batch{collector: c}
+}
+
+// send adds commands to pending request, starting it if needed, and waits for its results.
+func (c *Collector) send(commands []command) error {
+	c.mutex.Lock()
+	pending := c.pending
+	if pending == nil {
+		pending = &collected{done: make(chan struct{})}
+		c.pending = pending
+		time.AfterFunc(c.window, func() {
+			c.flush(pending)
+		})
+	}
+	offset := len(pending.commands)
+	pending.commands = append(pending.commands, commands...)
+	c.mutex.Unlock()
+
+	<-pending.done
+	if pending.err != nil {
+		return pending.err
+	}
+
+	return unmarshalResults(commands, pending.results[offset:offset+len(commands)])
+}
+
+// flush sends pending request, batches sent after that start a new one.
+func (c *Collector) flush(pending *collected) {
+	c.mutex.Lock()
+	c.pending = nil
+	c.mutex.Unlock()
+
+	pending.results, pending.err = post(c.host, c.client, pending.commands)
+	close(pending.done)
+}
@@ -0,0 +1,140 @@
+package rci
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	mock_rci "keeneticToMqtt/test/mocks/gomock/clients/keenetic/rci"
+)
+
+func TestCollector_NewBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		hostUrl = "host"
+	)
+
+	someErr := errors.New("some err")
+
+	tests := []struct {
+		name             string
+		responseBody     string
+		getResponseError func() error
+		expectedHosts    []host
+		expectedStat     stat
+		expectedHostsErr error
+		expectedStatErr  string
+	}{
+		{
+			name:          "batches are sent in one request",
+			responseBody:  `[{"show":{"ip":{"hotspot":{"host":[{"mac":"mac"}]}}}},{"show":{"interface":{"stat":{"rxbytes":10}}}}]`,
+			expectedHosts: []host{{Mac: "mac"}},
+			expectedStat:  stat{RxBytes: 10},
+		},
+		{
+			name:             "request error is returned to all batches",
+			getResponseError: func() error { return someErr },
+			expectedHostsErr: someErr,
+			expectedStatErr:  "send error in Batch request: some err",
+		},
+		{
+			name:            "command result error is returned to its batch only",
+			responseBody:    `[{"show":{"ip":{"hotspot":{"host":[{"mac":"mac"}]}}}},{"show":{"interface":{}}}]`,
+			expectedHosts:   []host{{Mac: "mac"}},
+			expectedStatErr: "no show/interface/stat result in Batch request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp *http.Response
+			var respErr error
+			if tt.getResponseError != nil {
+				respErr = tt.getResponseError()
+			} else {
+				resp = &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(tt.responseBody)),
+				}
+			}
+
+			client := mock_rci.NewMockclient(ctrl)
+			client.EXPECT().Do(gomock.Cond(func(x any) bool {
+				req, ok := x.(*http.Request)
+				if !ok || req == nil {
+					t.Errorf("empty request")
+					return false
+				}
+				body, err := io.ReadAll(req.Body)
+				assert.Nil(t, err)
+				assert.JSONEq(t, `[{"show":{"ip":{"hotspot":{"host":{}}}}},{"show":{"interface":{"stat":{"name":"ISP"}}}}]`, string(body))
+				return true
+			})).Return(resp, respErr)
+
+			collector := NewCollector(hostUrl, client, 100*time.Millisecond)
+
+			var (
+				wg       sync.WaitGroup
+				hosts    []host
+				s        stat
+				hostsErr error
+				statErr  error
+			)
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				hostsErr = collector.NewBatch().Add("show/ip/hotspot/host", nil, &hosts).Send()
+			}()
+			// second batch is sent later within window, so commands order in request is known
+			time.Sleep(10 * time.Millisecond)
+			go func() {
+				defer wg.Done()
+				statErr = collector.NewBatch().Add("show/interface/stat", map[string]any{"name": "ISP"}, &s).Send()
+			}()
+			wg.Wait()
+
+			if tt.expectedHostsErr != nil {
+				assert.ErrorIs(t, hostsErr, tt.expectedHostsErr)
+			} else {
+				assert.Nil(t, hostsErr)
+				assert.Equal(t, tt.expectedHosts, hosts)
+			}
+			if tt.expectedStatErr != "" {
+				assert.Regexp(t, tt.expectedStatErr+".*", statErr.Error())
+			} else {
+				assert.Nil(t, statErr)
+				assert.Equal(t, tt.expectedStat, s)
+			}
+		})
+	}
+}
+
+func TestCollector_NewBatch_sequential(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock_rci.NewMockclient(ctrl)
+	client.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`[{"show":{"ip":{"hotspot":{"host":[{"mac":"mac"}]}}}}]`)),
+		}, nil
+	}).Times(2)
+
+	collector := NewCollector("host", client, 0)
+
+	// batch sent after previous request started goes to a new request
+	for i := 0; i < 2; i++ {
+		var hosts []host
+		assert.Nil(t, collector.NewBatch().Add("show/ip/hotspot/host", nil, &hosts).Send())
+		assert.Equal(t, []host{{Mac: "mac"}}, hosts)
+	}
+}
@@ -0,0 +1,122 @@
+package rci
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"keeneticToMqtt/internal/errs"
+)
+
+const (
+	statusError = "error"
+)
+
+type (
+	// Status keenetic rci command status message.
+	Status struct {
+		Status  string `json:"status"`
+		Code    string `json:"code"`
+		Ident   string `json:"ident"`
+		Message string `json:"message"`
+	}
+)
+
+// StatusError error status returned by keenetic for rci command.
+// It wraps one of errs.ErrUnknownPolicy, errs.ErrInvalidMac, errs.ErrPermissionDenied or errs.ErrCommandFailed.
+type StatusError struct {
+	Status
+	err error
+}
+
+// Error returns error message.
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s: %s (code %s, ident %s)", e.err, e.Message, e.Code, e.Ident)
+}
+
+// Unwrap returns typed error.
+func (e *StatusError) Unwrap() error {
+	return e.err
+}
+
+// CheckStatus parses rci command response and returns *StatusError for the first error status.
+// Status messages may be nested in any response object, for example {"permit":{"status":[...]}}.
+// Empty response is considered error, because keenetic always returns status for write commands.
+func CheckStatus(body []byte) error {
+	var res map[string]json.RawMessage
+	if err := json.Unmarshal(body, &res); err != nil {
+		return fmt.Errorf("unmarshal status error: %w", err)
+	}
+
+	if len(res) == 0 {
+		return fmt.Errorf("no status in response: %w", errs.ErrCommandFailed)
+	}
+
+	if status, ok := findErrorStatus(body); ok {
+		return &StatusError{
+			Status: status,
+			err:    statusErr(status),
+		}
+	}
+
+	return nil
+}
+
+// findErrorStatus walks response recursively and returns first status with error.
+func findErrorStatus(value json.RawMessage) (Status, bool) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(value, &obj); err == nil {
+		var statuses []Status
+		if err := json.Unmarshal(obj["status"], &statuses); err == nil {
+			for _, status := range statuses {
+				if status.Status == statusError {
+					return status, true
+				}
+			}
+		}
+
+		keys := make([]string, 0, len(obj))
+		for key := range obj {
+			if key != "status" {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			if status, ok := findErrorStatus(obj[key]); ok {
+				return status, true
+			}
+		}
+
+		return Status{}, false
+	}
+
+	var arr []json.RawMessage
+	if err := json.Unmarshal(value, &arr); err == nil {
+		for _, nested := range arr {
+			if status, ok := findErrorStatus(nested); ok {
+				return status, true
+			}
+		}
+	}
+
+	return Status{}, false
+}
+
+// statusErr maps keenetic status message to typed error.
+func statusErr(status Status) error {
+	message := strings.ToLower(status.Message)
+	switch {
+	case strings.Contains(message, "permission denied") || strings.Contains(message, "access denied"):
+		return errs.ErrPermissionDenied
+	case strings.Contains(message, "policy") &&
+		(strings.Contains(message, "not found") || strings.Contains(message, "unable to find") || strings.Contains(message, "unknown")):
+		return errs.ErrUnknownPolicy
+	case strings.Contains(message, "mac") && strings.Contains(message, "invalid"):
+		return errs.ErrInvalidMac
+	default:
+		return errs.ErrCommandFailed
+	}
+}
@@ -0,0 +1,88 @@
+package rci
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"keeneticToMqtt/internal/errs"
+)
+
+func TestCheckStatus(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedErr    error
+		expectedErrStr string
+		expectedStatus *Status
+	}{
+		{
+			name: "success status",
+			body: `{"permit":{"status":[{"status":"message","code":"7405600","ident":"Core::Configurator","message":"done."}]}}`,
+		},
+		{
+			name:           "error status nested in array",
+			body:           `[{"status":[{"status":"message"}]}]`,
+			expectedErrStr: "unmarshal status error:",
+		},
+		{
+			name:        "empty response",
+			body:        `{}`,
+			expectedErr: errs.ErrCommandFailed,
+		},
+		{
+			name:        "unknown policy",
+			body:        `{"policy":{"status":[{"status":"error","code":"1179781","ident":"Hotspot::Manager","message":"unable to find policy \"Policy5\"."}]}}`,
+			expectedErr: errs.ErrUnknownPolicy,
+			expectedStatus: &Status{
+				Status:  "error",
+				Code:    "1179781",
+				Ident:   "Hotspot::Manager",
+				Message: `unable to find policy "Policy5".`,
+			},
+		},
+		{
+			name:        "invalid mac",
+			body:        `{"status":[{"status":"error","code":"7405601","ident":"Command::Base","message":"invalid MAC address: \"zz\"."}]}`,
+			expectedErr: errs.ErrInvalidMac,
+		},
+		{
+			name:        "permission denied",
+			body:        `{"ip":{"hotspot":{"host":{"status":[{"status":"error","code":"7405602","ident":"Core::Authenticator","message":"permission denied."}]}}}}`,
+			expectedErr: errs.ErrPermissionDenied,
+		},
+		{
+			name:        "other error in list with messages",
+			body:        `{"up":{"status":[{"status":"message","message":"ok."},{"status":"error","message":"interface is busy."}]}}`,
+			expectedErr: errs.ErrCommandFailed,
+		},
+		{
+			name:           "error message",
+			body:           `{"status":[{"status":"error","code":"1","ident":"Core","message":"failed."}]}`,
+			expectedErrStr: `command failed: failed. \(code 1, ident Core\)`,
+		},
+		{
+			name:           "invalid body",
+			body:           ``,
+			expectedErrStr: "unmarshal status error:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckStatus([]byte(tt.body))
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				if tt.expectedStatus != nil {
+					var statusErr *StatusError
+					if assert.ErrorAs(t, err, &statusErr) {
+						assert.Equal(t, *tt.expectedStatus, statusErr.Status)
+					}
+				}
+			} else if tt.expectedErrStr != "" {
+				assert.Regexp(t, tt.expectedErrStr+".*", err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package system
+
+import (
+	"fmt"
+
+	"keeneticToMqtt/internal/clients/keenetic/rci"
+	"keeneticToMqtt/internal/dto/keeneticdto"
+)
+
+//go:generate mockgen -source=system.go -destination=../../../../test/mocks/gomock/clients/keenetic/system/system.go
+
+const (
+	systemPath         = "show/system"
+	versionPath        = "show/version"
+	interfaceListPath  = "show/interface"
+	internetStatusPath = "show/internet/status"
+	interfaceStatPath  = "show/interface/stat"
+)
+
+type (
+	collector interface {
+		NewBatch() *rci.Batch
+	}
+)
+
+// System struct to get keenetic system state.
+type System struct {
+	collector collector
+}
+
+// NewSystem creates new System.
+func NewSystem(collector collector) *System {
+	return &System{
+		collector: collector,
+	}
+}
+
+// GetRouterState returns keenetic system info, version, interfaces, internet status
+// and traffic counters of statNames interfaces in one batched request.
+func (s *System) GetRouterState(statNames []string) (keeneticdto.RouterState, error) {
+	var res keeneticdto.RouterState
+	stats := make([]keeneticdto.InterfaceStatResponse, len(statNames))
+
+	batch := s.collector.NewBatch().
+		Add(systemPath, nil, &res.System).
+		Add(versionPath, nil, &res.Version).
+		Add(interfaceListPath, nil, &res.Interfaces).
+		Add(internetStatusPath, nil, &res.InternetStatus)
+	for idx, name := range statNames {
+		batch.Add(interfaceStatPath, map[string]any{"name": name}, &stats[idx])
+	}
+	if err := batch.Send(); err != nil {
+		return keeneticdto.RouterState{}, fmt.Errorf("error in GetRouterState request: %w", err)
+	}
+
+	res.InterfaceStats = make(map[string]keeneticdto.InterfaceStatResponse, len(statNames))
+	for idx, name := range statNames {
+		res.InterfaceStats[name] = stats[idx]
+	}
+
+	return res, nil
+}
@@ -0,0 +1,145 @@
+package system
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"keeneticToMqtt/internal/clients/keenetic/rci"
+	"keeneticToMqtt/internal/dto/keeneticdto"
+	"keeneticToMqtt/internal/errs"
+	mock_rci "keeneticToMqtt/test/mocks/gomock/clients/keenetic/rci"
+)
+
+func TestSystem_GetRouterState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		host = "host"
+	)
+
+	someErr := errors.New("some err")
+
+	tests := []struct {
+		name             string
+		statNames        []string
+		expected         keeneticdto.RouterState
+		expectedErr      error
+		expectedErrStr   string
+		validateRequest  func(req *http.Request)
+		getResponse      func() *http.Response
+		getResponseError func() error
+	}{
+		{
+			name:      "success get router state",
+			statNames: []string{"PPPoE0"},
+			validateRequest: func(req *http.Request) {
+				assert.Equal(t, host+"/rci/", req.URL.String())
+				assert.Equal(t, http.MethodPost, req.Method)
+
+				body, err := io.ReadAll(req.Body)
+				assert.Nil(t, err)
+				assert.JSONEq(t, `[{"show":{"system":{}}},{"show":{"version":{}}},{"show":{"interface":{}}},{"show":{"internet":{"status":{}}}},{"show":{"interface":{"stat":{"name":"PPPoE0"}}}}]`, string(body))
+			},
+			getResponse: func() *http.Response {
+				bodyStr := `[{"show":{"system":{"cpuload":10,"uptime":"100"}}},` +
+					`{"show":{"version":{"model":"Giga","title":"4.1.7"}}},` +
+					`{"show":{"interface":{"PPPoE0":{"id":"PPPoE0","global":true}}}},` +
+					`{"show":{"internet":{"status":{"internet":true}}}},` +
+					`{"show":{"interface":{"stat":{"rxbytes":100,"txbytes":200}}}}]`
+				resp := http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(bodyStr)),
+				}
+				return &resp
+			},
+			getResponseError: func() error {
+				return nil
+			},
+			expected: keeneticdto.RouterState{
+				System:  keeneticdto.SystemResponse{CPULoad: 10, Uptime: "100"},
+				Version: keeneticdto.VersionResponse{Model: "Giga", Title: "4.1.7"},
+				Interfaces: map[string]keeneticdto.InterfaceResponse{
+					"PPPoE0": {ID: "PPPoE0", Global: true},
+				},
+				InternetStatus: keeneticdto.InternetStatusResponse{Internet: true},
+				InterfaceStats: map[string]keeneticdto.InterfaceStatResponse{
+					"PPPoE0": {RxBytes: 100, TxBytes: 200},
+				},
+			},
+		},
+		{
+			name:            "error from client",
+			validateRequest: func(req *http.Request) {},
+			getResponse: func() *http.Response {
+				return nil
+			},
+			getResponseError: func() error {
+				return someErr
+			},
+			expectedErr: someErr,
+		},
+		{
+			name:            "http.StatusUnauthorized status code",
+			validateRequest: func(req *http.Request) {},
+			getResponse: func() *http.Response {
+				bytesReader := strings.NewReader("")
+				resp := http.Response{
+					StatusCode: http.StatusUnauthorized,
+					Body:       io.NopCloser(bytesReader),
+				}
+				return &resp
+			},
+			getResponseError: func() error {
+				return nil
+			},
+			expectedErr: errs.ErrUnauthorized,
+		},
+		{
+			name:            "unexpected results count",
+			validateRequest: func(req *http.Request) {},
+			getResponse: func() *http.Response {
+				resp := http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`[{}]`)),
+				}
+				return &resp
+			},
+			getResponseError: func() error {
+				return nil
+			},
+			expectedErrStr: "error in GetRouterState request: unexpected results count in Batch request: 1, expected 4",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := mock_rci.NewMockclient(ctrl)
+			client.EXPECT().Do(gomock.Cond(func(x any) bool {
+				req, ok := x.(*http.Request)
+				if !ok || req == nil {
+					t.Errorf("empty request")
+					return false
+				}
+				tt.validateRequest(req)
+				return true
+			})).Return(tt.getResponse(), tt.getResponseError())
+
+			system := NewSystem(rci.NewCollector(host, client, 0))
+			res, err := system.GetRouterState(tt.statNames)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else if tt.expectedErrStr != "" {
+				assert.Regexp(t, tt.expectedErrStr+".*", err.Error())
+			} else {
+				assert.Equal(t, tt.expected, res)
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
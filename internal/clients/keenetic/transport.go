@@ -0,0 +1,26 @@
+package keenetic
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// NewTransport creates http transport, which is shared by keenetic auth and rci clients.
+// tlsConfig is used for https host, nil means default tls config. Empty proxy means direct connection.
+func NewTransport(tlsConfig *tls.Config, proxy string) (*http.Transport, error) {
+	t := &http.Transport{
+		TLSClientConfig: tlsConfig,
+	}
+
+	if proxy != "" {
+		proxyURL, err := url.Parse(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid keenetic proxy url: %w", err)
+		}
+		t.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	return t, nil
+}
@@ -0,0 +1,57 @@
+package keenetic
+
+import (
+	"crypto/tls"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTransport(t *testing.T) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: true}
+
+	tests := []struct {
+		name          string
+		tlsConfig     *tls.Config
+		proxy         string
+		expectedProxy string
+		expectedErr   string
+	}{
+		{
+			name: "direct connection",
+		},
+		{
+			name:          "proxy and tls config",
+			tlsConfig:     tlsConfig,
+			proxy:         "http://proxy:3128",
+			expectedProxy: "http://proxy:3128",
+		},
+		{
+			name:        "invalid proxy",
+			proxy:       "http://proxy:port",
+			expectedErr: "invalid keenetic proxy url",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport, err := NewTransport(tt.tlsConfig, tt.proxy)
+			if tt.expectedErr != "" {
+				assert.Regexp(t, tt.expectedErr+".*", err.Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Same(t, tt.tlsConfig, transport.TLSClientConfig)
+
+			if tt.expectedProxy == "" {
+				assert.Nil(t, transport.Proxy)
+				return
+			}
+			req, _ := http.NewRequest(http.MethodGet, "https://keenetic", nil)
+			proxyURL, err := transport.Proxy(req)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expectedProxy, proxyURL.String())
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package mqtt
 
 import (
+	"crypto/tls"
+	"sync"
 	"time"
 
 	mqtt "github.com/eclipse/paho.mqtt.golang"
@@ -8,6 +10,14 @@ import (
 
 //go:generate mockgen -source=client.go -destination=../../../test/mocks/gomock/clients/mqtt/client.go
 
+const (
+	availabilityOnline  = "online"
+	availabilityOffline = "offline"
+
+	connectBackoff    = time.Second
+	maxConnectBackoff = time.Minute
+)
+
 type logger interface {
 	Error(msg string, args ...any)
 	Info(msg string, args ...any)
@@ -16,20 +26,50 @@ type logger interface {
 
 type mqttClient interface {
 	Connect() mqtt.Token
+	IsConnected() bool
 	Publish(topic string, qos byte, retained bool, payload interface{}) mqtt.Token
 	Subscribe(topic string, qos byte, callback mqtt.MessageHandler) mqtt.Token
+	Unsubscribe(topics ...string) mqtt.Token
+}
+
+// subscription stops message delivery to channel after unsubscribe,
+// so message handler never blocks on channel without reader.
+type subscription struct {
+	ch   chan string
+	done chan struct{}
 }
 
 // Client mqtt client.
 type Client struct {
-	topicPrefix string
-	client      mqttClient
-	logger      logger
-	broker      string
+	topicPrefix        string
+	client             mqttClient
+	logger             logger
+	broker             string
+	availabilityTopic  string
+	subscriptions      map[string]subscription
+	subscriptionsMutex sync.Mutex
+	connectHandlers    []func()
+	handlersMutex      sync.Mutex
+
+	connectBackoff, maxConnectBackoff time.Duration
+	// maxConnectAttempts limits Connect attempts, 0 means retry until connected.
+	maxConnectAttempts int
 }
 
 // NewClient creates new Client.
-func NewClient(broker, clientID, username, password string, log logger) *Client {
+// Broker sets availabilityTopic offline with last will, every successful connection sets it online.
+// Client reconnects automatically and restores subscriptions after reconnect.
+// Messages are delivered to subscription channels concurrently, so their order is not guaranteed.
+// tlsConfig is used for ssl, mqtts and wss brokers, nil means default tls config.
+func NewClient(broker, clientID, username, password, availabilityTopic string, tlsConfig *tls.Config, log logger) *Client {
+	c := &Client{
+		logger:            log,
+		broker:            broker,
+		availabilityTopic: availabilityTopic,
+		connectBackoff:    connectBackoff,
+		maxConnectBackoff: maxConnectBackoff,
+	}
+
 	opts := mqtt.
 		NewClientOptions().
 		AddBroker(broker).
@@ -37,27 +77,99 @@ func NewClient(broker, clientID, username, password string, log logger) *Client
 		SetKeepAlive(2 * time.Second).
 		SetPingTimeout(1 * time.Second).
 		SetUsername(username).
-		SetPassword(password)
+		SetPassword(password).
+		SetAutoReconnect(true).
+		SetMaxReconnectInterval(maxConnectBackoff).
+		// Handlers wait for subscription readers, which may wait for subscribe or unsubscribe of other topics.
+		// Ordered handlers run in the paho goroutine, which also processes broker acks, so they would deadlock.
+		SetOrderMatters(false).
+		SetOnConnectHandler(func(mqtt.Client) {
+			c.onConnect()
+		}).
+		SetConnectionLostHandler(func(_ mqtt.Client, err error) {
+			c.logger.Error("mqtt connection lost", "error", err, "broker", broker)
+		})
 
-	c := mqtt.NewClient(opts)
+	if tlsConfig != nil {
+		opts.SetTLSConfig(tlsConfig)
+	}
 
-	return &Client{
-		client: c,
-		logger: log,
-		broker: broker,
+	if availabilityTopic != "" {
+		opts.SetWill(availabilityTopic, availabilityOffline, 1, true)
 	}
+
+	c.client = mqtt.NewClient(opts)
+
+	return c
 }
 
-// Connect connection to mqtt broker.
+// Connect connection to mqtt broker. Connection is retried with exponential backoff.
 func (c *Client) Connect() error {
-	if token := c.client.Connect(); token.Wait() && token.Error() != nil {
-		return token.Error()
+	backoff := c.connectBackoff
+	for attempt := 1; ; attempt++ {
+		token := c.client.Connect()
+		token.Wait()
+		err := token.Error()
+		if err == nil {
+			break
+		}
+		if c.maxConnectAttempts > 0 && attempt >= c.maxConnectAttempts {
+			return err
+		}
+
+		c.logger.Error("error while connecting to mqtt, retrying",
+			"error", err,
+			"broker", c.broker,
+			"retryIn", backoff,
+		)
+		time.Sleep(backoff)
+		backoff = min(backoff*2, c.maxConnectBackoff)
 	}
 	c.logger.Info("connected to mqtt", "broker", c.broker)
 
 	return nil
 }
 
+// IsConnected returns true if client is connected to mqtt broker.
+func (c *Client) IsConnected() bool {
+	return c.client.IsConnected()
+}
+
+// AddConnectHandler adds handler, which is called after every connection, including reconnects.
+// Handlers republish retained messages, which could be lost while client was disconnected.
+func (c *Client) AddConnectHandler(handler func()) {
+	c.handlersMutex.Lock()
+	c.connectHandlers = append(c.connectHandlers, handler)
+	c.handlersMutex.Unlock()
+}
+
+// onConnect publishes availability, restores subscriptions and calls connect handlers after every connection,
+// including reconnects.
+func (c *Client) onConnect() {
+	if c.availabilityTopic != "" {
+		c.SendMessage(c.availabilityTopic, availabilityOnline, true)
+	}
+
+	c.subscriptionsMutex.Lock()
+	subscriptions := make(map[string]subscription, len(c.subscriptions))
+	for topic, sub := range c.subscriptions {
+		subscriptions[topic] = sub
+	}
+	c.subscriptionsMutex.Unlock()
+
+	for topic, sub := range subscriptions {
+		c.subscribe(topic, sub)
+	}
+
+	c.handlersMutex.Lock()
+	handlers := c.connectHandlers
+	c.handlersMutex.Unlock()
+
+	for _, handler := range handlers {
+		handler()
+	}
+}
+
 // SendMessage sends mqtt message.
 func (c *Client) SendMessage(topic, message string, retained bool) {
 	c.logger.Debug("start sending mqtt message",
@@ -85,12 +197,101 @@ func (c *Client) SendMessage(topic, message string, retained bool) {
 	)
 }
 
-// Subscribe subscribes to topic.
+// Subscribe subscribes to topic. Subscription is restored after reconnect.
 func (c *Client) Subscribe(topic string) chan string {
-	ch := make(chan string)
-	c.client.Subscribe(topic, 0, func(client mqtt.Client, message mqtt.Message) {
-		ch <- string(message.Payload())
+	sub := subscription{
+		ch:   make(chan string),
+		done: make(chan struct{}),
+	}
+
+	c.subscriptionsMutex.Lock()
+	if c.subscriptions == nil {
+		c.subscriptions = make(map[string]subscription)
+	}
+	c.subscriptions[topic] = sub
+	c.subscriptionsMutex.Unlock()
+
+	c.subscribe(topic, sub)
+
+	return sub.ch
+}
+
+// subscribe subscribes to topic in broker. Failed subscription is restored on next connect.
+func (c *Client) subscribe(topic string, sub subscription) {
+	token := c.client.Subscribe(topic, 0, func(client mqtt.Client, message mqtt.Message) {
+		select {
+		case sub.ch <- string(message.Payload()):
+		case <-sub.done:
+		}
 	})
+	<-token.Done()
+	if err := token.Error(); err != nil {
+		c.logger.Error("error while subscribing to mqtt topic",
+			"error", err,
+			"topic", topic,
+		)
+	}
+}
+
+// ReadRetained returns retained messages of topic filter by message topic.
+// Broker sends retained messages right after subscription, so they are collected during wait, then subscription is removed.
+func (c *Client) ReadRetained(topic string, wait time.Duration) map[string]string {
+	messages := make(map[string]string)
+	var mutex sync.Mutex
+
+	token := c.client.Subscribe(topic, 0, func(client mqtt.Client, message mqtt.Message) {
+		if !message.Retained() {
+			return
+		}
+		mutex.Lock()
+		messages[message.Topic()] = string(message.Payload())
+		mutex.Unlock()
+	})
+	<-token.Done()
+	if err := token.Error(); err != nil {
+		c.logger.Error("error while subscribing to mqtt topic",
+			"error", err,
+			"topic", topic,
+		)
+		return nil
+	}
+
+	time.Sleep(wait)
 
-	return ch
+	token = c.client.Unsubscribe(topic)
+	<-token.Done()
+	if err := token.Error(); err != nil {
+		c.logger.Error("error while unsubscribing from mqtt topic",
+			"error", err,
+			"topic", topic,
+		)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	res := make(map[string]string, len(messages))
+	for messageTopic, payload := range messages {
+		res[messageTopic] = payload
+	}
+
+	return res
+}
+
+// Unsubscribe unsubscribes from topic. Channel returned by Subscribe doesn't receive messages after that.
+func (c *Client) Unsubscribe(topic string) {
+	c.subscriptionsMutex.Lock()
+	if sub, ok := c.subscriptions[topic]; ok {
+		close(sub.done)
+		delete(c.subscriptions, topic)
+	}
+	c.subscriptionsMutex.Unlock()
+
+	token := c.client.Unsubscribe(topic)
+	<-token.Done()
+	if err := token.Error(); err != nil {
+		c.logger.Error("error while unsubscribing from mqtt topic",
+			"error", err,
+			"topic", topic,
+		)
+	}
 }
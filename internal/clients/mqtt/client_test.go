@@ -3,7 +3,9 @@ package mqtt
 import (
 	"errors"
 	"testing"
+	"time"
 
+	pahomqtt "github.com/eclipse/paho.mqtt.golang"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	mock_mqtt "keeneticToMqtt/test/mocks/gomock/clients/mqtt"
@@ -122,11 +124,19 @@ func TestClient_Connect(t *testing.T) {
 
 	someErr := errors.New("some err")
 
+	newToken := func(err error) *mock_mqtttoken.MockToken {
+		token := mock_mqtttoken.NewMockToken(ctrl)
+		token.EXPECT().Wait().Return(err == nil)
+		token.EXPECT().Error().Return(err)
+		return token
+	}
+
 	tests := []struct {
-		name        string
-		mqttClient  func() mqttClient
-		logger      func() logger
-		expectedErr error
+		name               string
+		maxConnectAttempts int
+		mqttClient         func() mqttClient
+		logger             func() logger
+		expectedErr        error
 	}{
 		{
 			name: "success connect",
@@ -137,40 +147,45 @@ func TestClient_Connect(t *testing.T) {
 				return log
 			},
 			mqttClient: func() mqttClient {
-				ch := make(chan struct{})
-				go func() {
-					ch <- struct{}{}
-				}()
+				client := mock_mqtt.NewMockmqttClient(ctrl)
+				client.EXPECT().Connect().Return(newToken(nil))
 
-				token := mock_mqtttoken.NewMockToken(ctrl)
-				token.EXPECT().Wait().Return(true)
-				token.EXPECT().Error().Return(nil)
+				return client
+			},
+		},
+		{
+			name: "success connect after retry",
+			logger: func() logger {
+				log := mock_mqtt.NewMocklogger(ctrl)
+				log.EXPECT().Error("error while connecting to mqtt, retrying", "error", someErr, "broker", broker, "retryIn", time.Duration(0))
+				log.EXPECT().Info("connected to mqtt", "broker", broker)
 
+				return log
+			},
+			mqttClient: func() mqttClient {
 				client := mock_mqtt.NewMockmqttClient(ctrl)
-				client.EXPECT().Connect().Return(token)
+				gomock.InOrder(
+					client.EXPECT().Connect().Return(newToken(someErr)),
+					client.EXPECT().Connect().Return(newToken(nil)),
+				)
 
 				return client
 			},
 		},
 		{
-			name: "error while connect",
+			name:               "error while connect",
+			maxConnectAttempts: 2,
 			logger: func() logger {
 				log := mock_mqtt.NewMocklogger(ctrl)
+				log.EXPECT().Error("error while connecting to mqtt, retrying", "error", someErr, "broker", broker, "retryIn", time.Duration(0))
 				return log
 			},
 			mqttClient: func() mqttClient {
-				ch := make(chan struct{})
-				go func() {
-					ch <- struct{}{}
-				}()
-
-				token := mock_mqtttoken.NewMockToken(ctrl)
-				token.EXPECT().Wait().Return(true)
-				token.EXPECT().Error().Return(someErr)
-				token.EXPECT().Error().Return(someErr)
-
 				client := mock_mqtt.NewMockmqttClient(ctrl)
-				client.EXPECT().Connect().Return(token)
+				gomock.InOrder(
+					client.EXPECT().Connect().Return(newToken(someErr)),
+					client.EXPECT().Connect().Return(newToken(someErr)),
+				)
 
 				return client
 			},
@@ -182,9 +197,10 @@ func TestClient_Connect(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 
 			mqtt := Client{
-				client: tt.mqttClient(),
-				logger: tt.logger(),
-				broker: broker,
+				client:             tt.mqttClient(),
+				logger:             tt.logger(),
+				broker:             broker,
+				maxConnectAttempts: tt.maxConnectAttempts,
 			}
 
 			err := mqtt.Connect()
@@ -197,20 +213,263 @@ func TestClient_Connect(t *testing.T) {
 	}
 }
 
-func TestClient_Subscribe(t *testing.T) {
+func TestClient_IsConnected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock_mqtt.NewMockmqttClient(ctrl)
+	client.EXPECT().IsConnected().Return(true)
+
+	mqtt := Client{client: client}
+	assert.True(t, mqtt.IsConnected())
+}
+
+func TestClient_onConnect(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	const (
-		topic = "topic"
+		topic             = "topic"
+		availabilityTopic = "base/bridge/state"
 	)
 
+	done := make(chan struct{})
+	close(done)
+
+	publishToken := mock_mqtttoken.NewMockToken(ctrl)
+	publishToken.EXPECT().Done().Return(done)
+	publishToken.EXPECT().Error().Return(nil)
+
+	subscribeToken := mock_mqtttoken.NewMockToken(ctrl)
+	subscribeToken.EXPECT().Done().Return(done)
+	subscribeToken.EXPECT().Error().Return(nil)
+
 	client := mock_mqtt.NewMockmqttClient(ctrl)
-	client.EXPECT().Subscribe(topic, byte(0), gomock.Any())
+	client.EXPECT().Publish(availabilityTopic, byte(0), true, "online").Return(publishToken)
+	client.EXPECT().Subscribe(topic, byte(0), gomock.Any()).Return(subscribeToken)
+
+	log := mock_mqtt.NewMocklogger(ctrl)
+	log.EXPECT().Debug("start sending mqtt message", "topic", availabilityTopic, "message", "online", "retained", true)
+	log.EXPECT().Info("sending mqtt message", "topic", availabilityTopic, "message", "online", "retained", true)
 
 	mqtt := Client{
-		client: client,
+		client:            client,
+		logger:            log,
+		availabilityTopic: availabilityTopic,
+		subscriptions:     map[string]subscription{topic: {}},
+	}
+
+	var handled int
+	mqtt.AddConnectHandler(func() {
+		handled++
+	})
+
+	mqtt.onConnect()
+	assert.Equal(t, 1, handled)
+}
+
+func TestClient_Subscribe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		topic = "topic"
+	)
+
+	someErr := errors.New("some err")
+
+	tests := []struct {
+		name   string
+		err    error
+		logger func() logger
+	}{
+		{
+			name: "success subscribe",
+			logger: func() logger {
+				return mock_mqtt.NewMocklogger(ctrl)
+			},
+		},
+		{
+			name: "error while subscribe",
+			err:  someErr,
+			logger: func() logger {
+				log := mock_mqtt.NewMocklogger(ctrl)
+				log.EXPECT().Error("error while subscribing to mqtt topic", "error", someErr, "topic", topic)
+				return log
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			done := make(chan struct{})
+			close(done)
+
+			token := mock_mqtttoken.NewMockToken(ctrl)
+			token.EXPECT().Done().Return(done)
+			token.EXPECT().Error().Return(tt.err)
+
+			client := mock_mqtt.NewMockmqttClient(ctrl)
+			client.EXPECT().Subscribe(topic, byte(0), gomock.Any()).Return(token)
+
+			mqtt := Client{
+				client: client,
+				logger: tt.logger(),
+			}
+
+			mqtt.Subscribe(topic)
+
+			// subscription is kept even if broker subscription fails, so it is restored after reconnect
+			assert.Contains(t, mqtt.subscriptions, topic)
+		})
+	}
+}
+
+func TestClient_Unsubscribe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		topic = "topic"
+	)
+
+	someErr := errors.New("some err")
+
+	tests := []struct {
+		name   string
+		err    error
+		logger func() logger
+	}{
+		{
+			name: "success unsubscribe",
+			logger: func() logger {
+				return mock_mqtt.NewMocklogger(ctrl)
+			},
+		},
+		{
+			name: "error while unsubscribe",
+			err:  someErr,
+			logger: func() logger {
+				log := mock_mqtt.NewMocklogger(ctrl)
+				log.EXPECT().Error("error while unsubscribing from mqtt topic", "error", someErr, "topic", topic)
+				return log
+			},
+		},
 	}
 
-	mqtt.Subscribe(topic)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			done := make(chan struct{})
+			close(done)
+
+			token := mock_mqtttoken.NewMockToken(ctrl)
+			token.EXPECT().Done().Return(done)
+			token.EXPECT().Error().Return(tt.err)
+
+			subscribeToken := mock_mqtttoken.NewMockToken(ctrl)
+			subscribeToken.EXPECT().Done().Return(done)
+			subscribeToken.EXPECT().Error().Return(nil)
+
+			client := mock_mqtt.NewMockmqttClient(ctrl)
+			client.EXPECT().Subscribe(topic, byte(0), gomock.Any()).Return(subscribeToken)
+			client.EXPECT().Unsubscribe(topic).Return(token)
+
+			mqtt := Client{
+				client: client,
+				logger: tt.logger(),
+			}
+
+			mqtt.Subscribe(topic)
+			sub := mqtt.subscriptions[topic]
+			mqtt.Unsubscribe(topic)
+
+			assert.Empty(t, mqtt.subscriptions)
+			_, ok := <-sub.done
+			assert.False(t, ok)
+		})
+	}
+}
+
+// message is mqtt.Message for tests.
+type message struct {
+	topic    string
+	payload  string
+	retained bool
+}
+
+func (m message) Duplicate() bool   { return false }
+func (m message) Qos() byte         { return 0 }
+func (m message) Retained() bool    { return m.retained }
+func (m message) Topic() string     { return m.topic }
+func (m message) MessageID() uint16 { return 0 }
+func (m message) Payload() []byte   { return []byte(m.payload) }
+func (m message) Ack()              {}
+
+func TestClient_ReadRetained(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		topic = "homeassistant/+/+/config"
+	)
+
+	someErr := errors.New("some err")
+
+	tests := []struct {
+		name         string
+		subscribeErr error
+		logger       func() logger
+		expected     map[string]string
+	}{
+		{
+			name: "retained messages are collected",
+			logger: func() logger {
+				return mock_mqtt.NewMocklogger(ctrl)
+			},
+			expected: map[string]string{"homeassistant/switch/a/config": "config"},
+		},
+		{
+			name:         "error while subscribe",
+			subscribeErr: someErr,
+			logger: func() logger {
+				log := mock_mqtt.NewMocklogger(ctrl)
+				log.EXPECT().Error("error while subscribing to mqtt topic", "error", someErr, "topic", topic)
+				return log
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			done := make(chan struct{})
+			close(done)
+
+			subscribeToken := mock_mqtttoken.NewMockToken(ctrl)
+			subscribeToken.EXPECT().Done().Return(done)
+			subscribeToken.EXPECT().Error().Return(tt.subscribeErr)
+
+			client := mock_mqtt.NewMockmqttClient(ctrl)
+			client.EXPECT().Subscribe(topic, byte(0), gomock.Any()).DoAndReturn(
+				func(_ string, _ byte, handler pahomqtt.MessageHandler) pahomqtt.Token {
+					handler(nil, message{topic: "homeassistant/switch/a/config", payload: "config", retained: true})
+					// not retained messages are published after subscription, they are skipped
+					handler(nil, message{topic: "homeassistant/switch/b/config", payload: "config"})
+					return subscribeToken
+				},
+			)
+			if tt.subscribeErr == nil {
+				unsubscribeToken := mock_mqtttoken.NewMockToken(ctrl)
+				unsubscribeToken.EXPECT().Done().Return(done)
+				unsubscribeToken.EXPECT().Error().Return(nil)
+				client.EXPECT().Unsubscribe(topic).Return(unsubscribeToken)
+			}
+
+			mqtt := Client{
+				client: client,
+				logger: tt.logger(),
+			}
+
+			assert.Equal(t, tt.expected, mqtt.ReadRetained(topic, 0))
+		})
+	}
 }
@@ -1,11 +1,23 @@
 package config
 
 import (
+	"fmt"
+	"net/url"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/spf13/viper"
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/homeassistant/topic"
+	"keeneticToMqtt/internal/tlsconfig"
+)
+
+const (
+	defaultBaseTopic       = "keeneticToMqtt"
+	defaultDiscoveryPrefix = "homeassistant"
+	defaultKeeneticTimeout = 10 * time.Second
 )
 
 var conFile string
@@ -21,20 +33,38 @@ type Keenetic struct {
 	Host     string `mapstructure:"host"`
 	Login    string `mapstructure:"login"`
 	Password string `mapstructure:"password"`
+	// Timeout limits every keenetic request.
+	Timeout time.Duration     `mapstructure:"timeout"`
+	Proxy   string            `mapstructure:"proxy"`
+	TLS     tlsconfig.Options `mapstructure:"tls"`
 }
 
 type Mqtt struct {
-	Host      string `mapstructure:"host"`
-	Login     string `mapstructure:"login"`
-	Password  string `mapstructure:"password"`
-	ClientID  string `mapstructure:"clientId"`
-	BaseTopic string `mapstructure:"baseTopic"`
+	Host      string            `mapstructure:"host"`
+	Login     string            `mapstructure:"login"`
+	Password  string            `mapstructure:"password"`
+	ClientID  string            `mapstructure:"clientId"`
+	BaseTopic string            `mapstructure:"baseTopic"`
+	TLS       tlsconfig.Options `mapstructure:"tls"`
 }
 
+// mqttSchemes broker url schemes supported by mqtt client.
+var mqttSchemes = []string{"tcp", "mqtt", "ssl", "tls", "mqtts", "ws", "wss"}
+
 type HomeAssistant struct {
 	UpdateInterval time.Duration `mapstructure:"updateInterval"`
-	WhiteList      []string      `mapstructure:"whitelist"`
-	DeviceID       string        `mapstructure:"deviceid"`
+	// Mode is client discovery mode: whitelist, registered or all.
+	Mode        string        `mapstructure:"mode"`
+	WhiteList   []string      `mapstructure:"whitelist"`
+	BlackList   []string      `mapstructure:"blacklist"`
+	DeviceID    string        `mapstructure:"deviceid"`
+	AwayTimeout time.Duration `mapstructure:"awayTimeout"`
+	// StatusTopic is home assistant birth and last will topic.
+	StatusTopic string `mapstructure:"statusTopic"`
+	// DiscoveryPrefix is home assistant mqtt discovery prefix.
+	DiscoveryPrefix string `mapstructure:"discoveryPrefix"`
+	// TopicTemplate is client entity topic template, see topic.Template.
+	TopicTemplate string `mapstructure:"topicTemplate"`
 }
 
 func SetConfigFile(path string) {
@@ -54,9 +84,97 @@ func NewDefaultConfig() (*Config, error) {
 		return nil, err
 	}
 
+	if err = config.Validate(); err != nil {
+		return nil, err
+	}
+
 	return &config, nil
 }
 
+// Validate checks config values and sets defaults for empty optional values.
+func (c *Config) Validate() error {
+	if c.Mqtt.BaseTopic == "" {
+		c.Mqtt.BaseTopic = defaultBaseTopic
+	}
+	if c.Homeassistant.DiscoveryPrefix == "" {
+		c.Homeassistant.DiscoveryPrefix = defaultDiscoveryPrefix
+	}
+	if c.Homeassistant.StatusTopic == "" {
+		c.Homeassistant.StatusTopic = c.Homeassistant.DiscoveryPrefix + "/status"
+	}
+	if err := topic.Validate(c.Homeassistant.TopicTemplate); err != nil {
+		return fmt.Errorf("invalid homeassistant topic template %q: %w", c.Homeassistant.TopicTemplate, err)
+	}
+
+	if c.Keenetic.Timeout == 0 {
+		c.Keenetic.Timeout = defaultKeeneticTimeout
+	}
+
+	if err := c.Keenetic.validate(); err != nil {
+		return err
+	}
+	if err := c.Mqtt.validate(); err != nil {
+		return err
+	}
+
+	switch c.Homeassistant.Mode {
+	case "":
+		c.Homeassistant.Mode = dto.ClientModeWhitelist
+	case dto.ClientModeWhitelist, dto.ClientModeRegistered, dto.ClientModeAll:
+	default:
+		return fmt.Errorf("invalid homeassistant mode %q, allowed modes: %s, %s, %s",
+			c.Homeassistant.Mode,
+			dto.ClientModeWhitelist,
+			dto.ClientModeRegistered,
+			dto.ClientModeAll,
+		)
+	}
+
+	return nil
+}
+
+func (k Keenetic) validate() error {
+	if k.Host != "" {
+		u, err := url.Parse(k.Host)
+		if err != nil {
+			return fmt.Errorf("invalid keenetic host %q: %w", k.Host, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("invalid keenetic host %q, allowed schemes: http, https", k.Host)
+		}
+	}
+
+	if k.Proxy != "" {
+		if _, err := url.Parse(k.Proxy); err != nil {
+			return fmt.Errorf("invalid keenetic proxy %q: %w", k.Proxy, err)
+		}
+	}
+
+	if err := k.TLS.Validate(); err != nil {
+		return fmt.Errorf("invalid keenetic tls config: %w", err)
+	}
+
+	return nil
+}
+
+func (m Mqtt) validate() error {
+	if m.Host != "" {
+		u, err := url.Parse(m.Host)
+		if err != nil {
+			return fmt.Errorf("invalid mqtt host %q: %w", m.Host, err)
+		}
+		if !slices.Contains(mqttSchemes, u.Scheme) {
+			return fmt.Errorf("invalid mqtt host %q, allowed schemes: %s", m.Host, strings.Join(mqttSchemes, ", "))
+		}
+	}
+
+	if err := m.TLS.Validate(); err != nil {
+		return fmt.Errorf("invalid mqtt tls config: %w", err)
+	}
+
+	return nil
+}
+
 func InitializeConfig() error {
 	path, err := os.Getwd()
 	if err != nil {
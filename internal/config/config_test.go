@@ -0,0 +1,168 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"keeneticToMqtt/internal/tlsconfig"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		mode         string
+		expectedMode string
+		expectedErr  string
+	}{
+		{
+			name:         "empty mode",
+			expectedMode: "whitelist",
+		},
+		{
+			name:         "registered mode",
+			mode:         "registered",
+			expectedMode: "registered",
+		},
+		{
+			name:         "all mode",
+			mode:         "all",
+			expectedMode: "all",
+		},
+		{
+			name:        "invalid mode",
+			mode:        "some",
+			expectedErr: "invalid homeassistant mode \"some\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := Config{Homeassistant: HomeAssistant{Mode: tt.mode}}
+			err := config.Validate()
+			if tt.expectedErr != "" {
+				assert.Regexp(t, tt.expectedErr+".*", err.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.expectedMode, config.Homeassistant.Mode)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_topics(t *testing.T) {
+	config := Config{}
+	assert.Nil(t, config.Validate())
+	assert.Equal(t, "keeneticToMqtt", config.Mqtt.BaseTopic)
+	assert.Equal(t, "homeassistant/status", config.Homeassistant.StatusTopic)
+
+	assert.Equal(t, "homeassistant", config.Homeassistant.DiscoveryPrefix)
+
+	config = Config{Mqtt: Mqtt{BaseTopic: "base"}, Homeassistant: HomeAssistant{StatusTopic: "ha/status"}}
+	assert.Nil(t, config.Validate())
+	assert.Equal(t, "base", config.Mqtt.BaseTopic)
+	assert.Equal(t, "ha/status", config.Homeassistant.StatusTopic)
+
+	// status topic follows discovery prefix
+	config = Config{Homeassistant: HomeAssistant{DiscoveryPrefix: "ha2"}}
+	assert.Nil(t, config.Validate())
+	assert.Equal(t, "ha2", config.Homeassistant.DiscoveryPrefix)
+	assert.Equal(t, "ha2/status", config.Homeassistant.StatusTopic)
+
+	config = Config{Homeassistant: HomeAssistant{TopicTemplate: "{base}/{client_name}_{mac}/{entity}"}}
+	assert.Nil(t, config.Validate())
+
+	config = Config{Homeassistant: HomeAssistant{TopicTemplate: "{base}/{entity}"}}
+	assert.EqualError(t, config.Validate(), `invalid homeassistant topic template "{base}/{entity}": topic template must contain {mac}`)
+}
+
+func TestConfig_Validate_mqtt(t *testing.T) {
+	tests := []struct {
+		name        string
+		mqtt        Mqtt
+		expectedErr string
+	}{
+		{
+			name: "mqtt scheme",
+			mqtt: Mqtt{Host: "mqtt://localhost:1883"},
+		},
+		{
+			name: "mqtts scheme",
+			mqtt: Mqtt{Host: "mqtts://localhost:8883", TLS: tlsconfig.Options{InsecureSkipVerify: true}},
+		},
+		{
+			name: "websocket scheme",
+			mqtt: Mqtt{Host: "wss://localhost:443/mqtt"},
+		},
+		{
+			name:        "unknown scheme",
+			mqtt:        Mqtt{Host: "http://localhost:1883"},
+			expectedErr: "invalid mqtt host \"http://localhost:1883\", allowed schemes",
+		},
+		{
+			name:        "invalid tls config",
+			mqtt:        Mqtt{Host: "mqtts://localhost:8883", TLS: tlsconfig.Options{CertFile: "cert.pem"}},
+			expectedErr: "invalid mqtt tls config",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := Config{Mqtt: tt.mqtt}
+			err := config.Validate()
+			if tt.expectedErr != "" {
+				assert.Regexp(t, tt.expectedErr+".*", err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_keenetic(t *testing.T) {
+	tests := []struct {
+		name            string
+		keenetic        Keenetic
+		expectedTimeout time.Duration
+		expectedErr     string
+	}{
+		{
+			name:            "http host with default timeout",
+			keenetic:        Keenetic{Host: "http://192.168.0.1"},
+			expectedTimeout: 10 * time.Second,
+		},
+		{
+			name:            "keendns https host with proxy and timeout",
+			keenetic:        Keenetic{Host: "https://router.keenetic.pro", Proxy: "http://proxy:3128", Timeout: time.Second},
+			expectedTimeout: time.Second,
+		},
+		{
+			name:        "unknown scheme",
+			keenetic:    Keenetic{Host: "ftp://192.168.0.1"},
+			expectedErr: "invalid keenetic host \"ftp://192.168.0.1\", allowed schemes",
+		},
+		{
+			name:        "invalid proxy",
+			keenetic:    Keenetic{Proxy: "http://proxy:port"},
+			expectedErr: "invalid keenetic proxy",
+		},
+		{
+			name:        "invalid tls config",
+			keenetic:    Keenetic{TLS: tlsconfig.Options{KeyFile: "key.pem"}},
+			expectedErr: "invalid keenetic tls config",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := Config{Keenetic: tt.keenetic}
+			err := config.Validate()
+			if tt.expectedErr != "" {
+				assert.Regexp(t, tt.expectedErr+".*", err.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.expectedTimeout, config.Keenetic.Timeout)
+			}
+		})
+	}
+}
@@ -1,10 +1,39 @@
 package dto
 
 type Client struct {
-	Mac     string `json:"mac"`
-	Policy  string `json:"policy"`
-	Name    string `json:"name"`
-	Permit  bool   `json:"permit"`
-	RxBytes int64  `json:"rxbytes"`
-	TxBytes int64  `json:"txbytes"`
+	Mac    string `json:"mac"`
+	Policy string `json:"policy"`
+	// Schedule is name of keenetic access schedule or homeassistantdto.NoneSchedule.
+	Schedule string `json:"schedule"`
+	Name     string `json:"name"`
+	Permit   bool   `json:"permit"`
+	RxBytes  int64  `json:"rxbytes"`
+	TxBytes  int64  `json:"txbytes"`
+	// RxRate and TxRate are bytes per second, calculated by bridge.
+	RxRate   int64  `json:"rxrate"`
+	TxRate   int64  `json:"txrate"`
+	Active   bool   `json:"active"`
+	Link     string `json:"link"`
+	LastSeen int64  `json:"lastSeen"`
+	Uptime   int64  `json:"uptime"`
+	Online   bool   `json:"online"`
+	// RxLimit and TxLimit are traffic shaping limits in kbit/s, 0 means unlimited.
+	RxLimit int64 `json:"rxLimit"`
+	TxLimit int64 `json:"txLimit"`
+	// wifi connection info, empty for wired clients.
+	SSID           string `json:"ssid"`
+	AP             string `json:"ap"`
+	RSSI           int    `json:"rssi"`
+	LinkRate       int    `json:"linkRate"`
+	MCS            int    `json:"mcs"`
+	SpatialStreams int    `json:"spatialStreams"`
+	// host info, which is published as home assistant entity attributes.
+	IP         string `json:"ip"`
+	Hostname   string `json:"hostname"`
+	Interface  string `json:"interface"`
+	Registered bool   `json:"registered"`
+	Access     string `json:"access"`
+	Priority   int    `json:"priority"`
+	Security   string `json:"security"`
+	FirstSeen  int64  `json:"firstSeen"`
 }
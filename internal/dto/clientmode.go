@@ -0,0 +1,11 @@
+package dto
+
+// Client discovery modes.
+const (
+	// ClientModeWhitelist handles only clients from whitelist.
+	ClientModeWhitelist = "whitelist"
+	// ClientModeRegistered handles all clients registered in keenetic.
+	ClientModeRegistered = "registered"
+	// ClientModeAll handles all clients known by keenetic.
+	ClientModeAll = "all"
+)
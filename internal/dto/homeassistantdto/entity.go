@@ -0,0 +1,21 @@
+package homeassistantdto
+
+const (
+	EntityCategoryConfig     = "config"
+	EntityCategoryDiagnostic = "diagnostic"
+)
+
+// EntityMeta home assistant entity settings, which are common for all entity types.
+type EntityMeta struct {
+	DeviceClass    string
+	StateClass     string
+	EntityCategory string
+	Icon           string
+	// SuggestedDisplayPrecision number of decimals to display, nil means home assistant default.
+	SuggestedDisplayPrecision *int
+}
+
+// Precision returns suggested display precision for EntityMeta.
+func Precision(decimals int) *int {
+	return &decimals
+}
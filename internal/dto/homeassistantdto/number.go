@@ -0,0 +1,11 @@
+package homeassistantdto
+
+// NumberMeta home assistant number settings.
+type NumberMeta struct {
+	Unit string
+	Min  int64
+	Max  int64
+	Step int64
+	Mode string
+	EntityMeta
+}
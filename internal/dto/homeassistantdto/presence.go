@@ -0,0 +1,6 @@
+package homeassistantdto
+
+const (
+	PayloadHome    = "home"
+	PayloadNotHome = "not_home"
+)
@@ -0,0 +1,3 @@
+package homeassistantdto
+
+const NoneSchedule = "none"
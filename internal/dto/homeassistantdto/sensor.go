@@ -0,0 +1,10 @@
+package homeassistantdto
+
+// PayloadNone resets home assistant sensor state to unknown.
+const PayloadNone = "None"
+
+// SensorMeta home assistant sensor settings.
+type SensorMeta struct {
+	Unit string
+	EntityMeta
+}
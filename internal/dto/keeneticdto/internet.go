@@ -0,0 +1,45 @@
+package keeneticdto
+
+type InterfaceResponse struct {
+	ID            string `json:"id"`
+	Type          string `json:"type"`
+	Description   string `json:"description"`
+	InterfaceName string `json:"interface-name"`
+	Link          string `json:"link"`
+	Connected     string `json:"connected"`
+	State         string `json:"state"`
+	Address       string `json:"address"`
+	Mask          string `json:"mask"`
+	Uptime        int64  `json:"uptime"`
+	Global        bool   `json:"global"`
+	DefaultGw     bool   `json:"defaultgw"`
+	SecurityLevel string `json:"security-level"`
+	// SSID is set for wifi access point interfaces only.
+	SSID string `json:"ssid"`
+}
+
+type InterfaceStatResponse struct {
+	RxPackets int64 `json:"rxpackets"`
+	RxBytes   int64 `json:"rxbytes"`
+	TxPackets int64 `json:"txpackets"`
+	TxBytes   int64 `json:"txbytes"`
+}
+
+type InternetStatusResponse struct {
+	Checked           string          `json:"checked"`
+	Enabled           bool            `json:"enabled"`
+	Reliable          bool            `json:"reliable"`
+	GatewayAccessible bool            `json:"gateway-accessible"`
+	DNSAccessible     bool            `json:"dns-accessible"`
+	HostAccessible    bool            `json:"host-accessible"`
+	Internet          bool            `json:"internet"`
+	Gateway           InternetGateway `json:"gateway"`
+}
+
+type InternetGateway struct {
+	Interface  string `json:"interface"`
+	Address    string `json:"address"`
+	Failures   int    `json:"failures"`
+	Accessible bool   `json:"accessible"`
+	Excluded   bool   `json:"excluded"`
+}
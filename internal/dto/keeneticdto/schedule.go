@@ -0,0 +1,13 @@
+package keeneticdto
+
+type Schedule struct {
+	Description string           `json:"description"`
+	Action      []ScheduleAction `json:"action"`
+}
+
+type ScheduleAction struct {
+	Type string `json:"type"`
+	Min  int    `json:"min"`
+	Hour int    `json:"hour"`
+	Dow  string `json:"dow"`
+}
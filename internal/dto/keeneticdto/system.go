@@ -0,0 +1,41 @@
+package keeneticdto
+
+type SystemResponse struct {
+	Hostname   string `json:"hostname"`
+	Domainname string `json:"domainname"`
+	CPULoad    int    `json:"cpuload"`
+	Memory     string `json:"memory"`
+	MemTotal   int64  `json:"memtotal"`
+	MemFree    int64  `json:"memfree"`
+	MemBuffers int64  `json:"membuffers"`
+	MemCache   int64  `json:"memcache"`
+	SwapTotal  int64  `json:"swaptotal"`
+	SwapFree   int64  `json:"swapfree"`
+	Uptime     string `json:"uptime"`
+}
+
+type VersionResponse struct {
+	Release      string `json:"release"`
+	Sandbox      string `json:"sandbox"`
+	Title        string `json:"title"`
+	Arch         string `json:"arch"`
+	Manufacturer string `json:"manufacturer"`
+	Vendor       string `json:"vendor"`
+	Series       string `json:"series"`
+	Model        string `json:"model"`
+	HwVersion    string `json:"hw_version"`
+	HwID         string `json:"hw_id"`
+	Device       string `json:"device"`
+	Class        string `json:"class"`
+	Region       string `json:"region"`
+}
+
+// RouterState is keenetic router state read in one batched request.
+type RouterState struct {
+	System         SystemResponse
+	Version        VersionResponse
+	Interfaces     map[string]InterfaceResponse
+	InternetStatus InternetStatusResponse
+	// InterfaceStats are traffic counters by interface name.
+	InterfaceStats map[string]InterfaceStatResponse
+}
@@ -0,0 +1,40 @@
+package dto
+
+type Router struct {
+	Manufacturer string `json:"manufacturer"`
+	Model        string `json:"model"`
+	Firmware     string `json:"firmware"`
+	Hostname     string `json:"hostname"`
+	// CPULoad and MemoryUsage are percents.
+	CPULoad     int   `json:"cpuLoad"`
+	MemoryUsage int   `json:"memoryUsage"`
+	Uptime      int64 `json:"uptime"`
+
+	Internet bool   `json:"internet"`
+	WanIP    string `json:"wanIp"`
+	// DefaultGateway is id of wan interface, which is used as default gateway.
+	DefaultGateway string `json:"defaultGateway"`
+	Wans           []Wan  `json:"wans"`
+
+	AccessPoints []AccessPoint `json:"accessPoints"`
+}
+
+type Wan struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	Address     string `json:"address"`
+	Connected   bool   `json:"connected"`
+	RxBytes     int64  `json:"rxBytes"`
+	TxBytes     int64  `json:"txBytes"`
+	// RxRate and TxRate are bytes per second.
+	RxRate int64 `json:"rxrate"`
+	TxRate int64 `json:"txrate"`
+}
+
+type AccessPoint struct {
+	// ID is keenetic interface id, for example WifiMaster0/AccessPoint1.
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	SSID        string `json:"ssid"`
+	Up          bool   `json:"up"`
+}
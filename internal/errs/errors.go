@@ -5,4 +5,12 @@ import "errors"
 var (
 	// ErrUnauthorized ошибка авторизации от keenetic.
 	ErrUnauthorized = errors.New("unauthorized")
+	// ErrCommandFailed keenetic rejected rci command.
+	ErrCommandFailed = errors.New("command failed")
+	// ErrUnknownPolicy keenetic does not know requested policy.
+	ErrUnknownPolicy = errors.New("unknown policy")
+	// ErrInvalidMac keenetic rejected client mac address.
+	ErrInvalidMac = errors.New("invalid mac")
+	// ErrPermissionDenied keenetic user has no permission for rci command.
+	ErrPermissionDenied = errors.New("permission denied")
 )
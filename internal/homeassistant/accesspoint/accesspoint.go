@@ -0,0 +1,78 @@
+package accesspoint
+
+import (
+	"fmt"
+
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	"keeneticToMqtt/internal/homeassistant/topic"
+)
+
+//go:generate mockgen -source=accesspoint.go -destination=../../../test/mocks/gomock/homeassistant/accesspoint/accesspoint.go
+
+const (
+	entityTypeName = "accesspoint"
+	icon           = "mdi:access-point"
+)
+
+type (
+	discovery interface {
+		SendDiscoverySensor(stateTopic string, client dto.Client, entityType string, meta homeassistantdto.SensorMeta) error
+	}
+)
+
+// AccessPoint struct for handle home assistant client wifi access point entities.
+type AccessPoint struct {
+	topics          *topic.Template
+	discoveryClient discovery
+}
+
+// NewAccessPoint creates new AccessPoint.
+func NewAccessPoint(
+	topics *topic.Template,
+	discoveryClient discovery,
+) *AccessPoint {
+	return &AccessPoint{
+		topics:          topics,
+		discoveryClient: discoveryClient,
+	}
+}
+
+// SendDiscoveryMessage sends homeassistant discovery message.
+func (a *AccessPoint) SendDiscoveryMessage(client dto.Client) error {
+	stateTopic := a.GetStateTopic(client)
+	meta := homeassistantdto.SensorMeta{
+		EntityMeta: homeassistantdto.EntityMeta{
+			Icon:           icon,
+			EntityCategory: homeassistantdto.EntityCategoryDiagnostic,
+		},
+	}
+	if err := a.discoveryClient.SendDiscoverySensor(stateTopic, client, entityTypeName, meta); err != nil {
+		return fmt.Errorf("AccessPoint SendDiscoveryMessage error: %w", err)
+	}
+
+	return nil
+}
+
+// GetState returns entity state. Wired clients have no state.
+func (a *AccessPoint) GetState(client dto.Client) (string, error) {
+	if client.AP == "" {
+		return homeassistantdto.PayloadNone, nil
+	}
+	return client.AP, nil
+}
+
+// Consume consumes message.
+func (a *AccessPoint) Consume(_ dto.Client, _ string) error {
+	return nil
+}
+
+// GetStateTopic returns state topic.
+func (a *AccessPoint) GetStateTopic(client dto.Client) string {
+	return a.topics.StateTopic(client, entityTypeName)
+}
+
+// GetCommandTopic returns command topic.
+func (a *AccessPoint) GetCommandTopic(_ dto.Client) string {
+	return ""
+}
@@ -0,0 +1,128 @@
+package accesspoint
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	"keeneticToMqtt/internal/homeassistant/topic"
+	mock_accesspoint "keeneticToMqtt/test/mocks/gomock/homeassistant/accesspoint"
+)
+
+func TestAccessPoint_SendDiscoveryMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	meta := homeassistantdto.SensorMeta{
+		EntityMeta: homeassistantdto.EntityMeta{
+			Icon:           icon,
+			EntityCategory: homeassistantdto.EntityCategoryDiagnostic,
+		},
+	}
+
+	const (
+		mac       = "mac"
+		name      = "name"
+		basetopic = "basetopic"
+	)
+	someErr := errors.New("some error")
+
+	client := dto.Client{Mac: mac, Name: name}
+
+	tests := []struct {
+		name        string
+		expectedErr error
+		discovery   func() discovery
+	}{
+		{
+			name: "success send discovery message",
+			discovery: func() discovery {
+				discovery := mock_accesspoint.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendDiscoverySensor(
+						gomock.Eq("basetopic/mac_accesspoint/state"),
+						gomock.Eq(client),
+						gomock.Eq("accesspoint"),
+						gomock.Eq(meta),
+					).
+					Return(nil)
+
+				return discovery
+			},
+		},
+		{
+			name: "error while send discovery message",
+			discovery: func() discovery {
+				discovery := mock_accesspoint.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendDiscoverySensor(
+						gomock.Eq("basetopic/mac_accesspoint/state"),
+						gomock.Eq(client),
+						gomock.Eq("accesspoint"),
+						gomock.Eq(meta),
+					).
+					Return(someErr)
+
+				return discovery
+			},
+			expectedErr: someErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accesspoint := NewAccessPoint(topic.NewTemplate(basetopic, ""), tt.discovery())
+			err := accesspoint.SendDiscoveryMessage(client)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestAccessPoint_GetState(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		client   dto.Client
+	}{
+		{
+			name: "wifi client",
+			client: dto.Client{
+				AP: "WifiMaster0/AccessPoint0",
+			},
+			expected: "WifiMaster0/AccessPoint0",
+		},
+		{
+			name:     "wired client",
+			client:   dto.Client{},
+			expected: homeassistantdto.PayloadNone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accesspoint := AccessPoint{}
+			res, err := accesspoint.GetState(tt.client)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, res)
+		})
+	}
+}
+
+func TestAccessPoint_Consume(t *testing.T) {
+	accesspoint := AccessPoint{}
+
+	err := accesspoint.Consume(dto.Client{}, "")
+	assert.Nil(t, err)
+}
+
+func TestAccessPoint_GetCommandTopic(t *testing.T) {
+	accesspoint := AccessPoint{}
+	assert.Empty(t, accesspoint.GetCommandTopic(dto.Client{}))
+}
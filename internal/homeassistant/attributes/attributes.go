@@ -0,0 +1,82 @@
+package attributes
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/homeassistant/topic"
+)
+
+// attributes json attributes of client, which are shown on every client entity in home assistant.
+type attributes struct {
+	Mac        string `json:"mac"`
+	IP         string `json:"ip"`
+	Hostname   string `json:"hostname"`
+	Interface  string `json:"interface"`
+	Registered bool   `json:"registered"`
+	Access     string `json:"access"`
+	Priority   int    `json:"priority"`
+	Security   string `json:"security"`
+	FirstSeen  int64  `json:"first_seen"`
+	LastSeen   int64  `json:"last_seen"`
+}
+
+// Attributes struct for handle client json attributes topic.
+// It has no home assistant entity, attributes topic is added to discovery messages of other client entities.
+type Attributes struct {
+	topics *topic.Template
+}
+
+// NewAttributes creates new Attributes.
+func NewAttributes(topics *topic.Template) *Attributes {
+	return &Attributes{
+		topics: topics,
+	}
+}
+
+// SendDiscoveryMessage does nothing, because attributes have no own home assistant entity.
+func (a *Attributes) SendDiscoveryMessage(_ dto.Client) error {
+	return nil
+}
+
+// GetState returns client json attributes.
+func (a *Attributes) GetState(client dto.Client) (string, error) {
+	state, err := json.Marshal(attributes{
+		Mac:        client.Mac,
+		IP:         client.IP,
+		Hostname:   client.Hostname,
+		Interface:  client.Interface,
+		Registered: client.Registered,
+		Access:     client.Access,
+		Priority:   client.Priority,
+		Security:   client.Security,
+		FirstSeen:  client.FirstSeen,
+		LastSeen:   client.LastSeen,
+	})
+	if err != nil {
+		return "", fmt.Errorf("Attributes GetState error: %w", err)
+	}
+
+	return string(state), nil
+}
+
+// Consume consumes message.
+func (a *Attributes) Consume(_ dto.Client, _ string) error {
+	return nil
+}
+
+// GetStateTopic returns attributes topic.
+func (a *Attributes) GetStateTopic(client dto.Client) string {
+	return a.topics.AttributesTopic(client)
+}
+
+// GetCommandTopic returns command topic.
+func (a *Attributes) GetCommandTopic(_ dto.Client) string {
+	return ""
+}
+
+// Retained returns true, because attributes are published as retained message.
+func (a *Attributes) Retained() bool {
+	return true
+}
@@ -0,0 +1,76 @@
+package attributes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/homeassistant/topic"
+)
+
+func TestAttributes_SendDiscoveryMessage(t *testing.T) {
+	attributes := Attributes{}
+	assert.Nil(t, attributes.SendDiscoveryMessage(dto.Client{}))
+}
+
+func TestAttributes_GetState(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		client   dto.Client
+	}{
+		{
+			name: "client with host info",
+			client: dto.Client{
+				Mac:        "mac",
+				Name:       "name",
+				IP:         "192.168.1.10",
+				Hostname:   "hostname",
+				Interface:  "Home",
+				Registered: true,
+				Access:     "permit",
+				Priority:   6,
+				Security:   "wpa2-psk",
+				FirstSeen:  1000,
+				LastSeen:   10,
+			},
+			expected: `{"mac":"mac","ip":"192.168.1.10","hostname":"hostname","interface":"Home","registered":true,"access":"permit","priority":6,"security":"wpa2-psk","first_seen":1000,"last_seen":10}`,
+		},
+		{
+			name:     "empty client",
+			client:   dto.Client{},
+			expected: `{"mac":"","ip":"","hostname":"","interface":"","registered":false,"access":"","priority":0,"security":"","first_seen":0,"last_seen":0}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attributes := Attributes{}
+			res, err := attributes.GetState(tt.client)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, res)
+		})
+	}
+}
+
+func TestAttributes_Consume(t *testing.T) {
+	attributes := Attributes{}
+
+	err := attributes.Consume(dto.Client{}, "")
+	assert.Nil(t, err)
+}
+
+func TestAttributes_GetStateTopic(t *testing.T) {
+	attributes := NewAttributes(topic.NewTemplate("basetopic", ""))
+	assert.Equal(t, "basetopic/mac_attributes", attributes.GetStateTopic(dto.Client{Mac: "mac"}))
+}
+
+func TestAttributes_GetCommandTopic(t *testing.T) {
+	attributes := Attributes{}
+	assert.Empty(t, attributes.GetCommandTopic(dto.Client{}))
+}
+
+func TestAttributes_Retained(t *testing.T) {
+	attributes := Attributes{}
+	assert.True(t, attributes.Retained())
+}
@@ -2,9 +2,10 @@ package clientpermit
 
 import (
 	"fmt"
-	"strings"
 
 	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	"keeneticToMqtt/internal/homeassistant/topic"
 )
 
 //go:generate mockgen -source=permit.go -destination=../../../test/mocks/gomock/homeassistant/clientpermit/permit.go
@@ -13,11 +14,12 @@ const (
 	entityTypeName = "permit"
 	offPayload     = "OFF"
 	onPayload      = "ON"
+	icon           = "mdi:web"
 )
 
 type (
 	discovery interface {
-		SendDiscoverySwitch(commandTopic, stateTopic, deviceName, name string) error
+		SendDiscoverySwitch(commandTopic, stateTopic string, client dto.Client, entityType string, meta homeassistantdto.EntityMeta) error
 	}
 	accessUpdate interface {
 		SetPermit(mac string, permit bool) error
@@ -26,19 +28,19 @@ type (
 
 // ClientPermit struct for handle home assistant client permit entities.
 type ClientPermit struct {
-	basetopic       string
+	topics          *topic.Template
 	discoveryClient discovery
 	accessUpdate    accessUpdate
 }
 
 // NewClientPermit creates new ClientPermit.
 func NewClientPermit(
-	basetopic string,
+	topics *topic.Template,
 	discoveryClient discovery,
 	accessUpdate accessUpdate,
 ) *ClientPermit {
 	return &ClientPermit{
-		basetopic:       basetopic,
+		topics:          topics,
 		discoveryClient: discoveryClient,
 		accessUpdate:    accessUpdate,
 	}
@@ -49,7 +51,10 @@ func (p *ClientPermit) SendDiscoveryMessage(client dto.Client) error {
 	commandTopic := p.GetCommandTopic(client)
 	stateTopic := p.GetStateTopic(client)
 
-	if err := p.discoveryClient.SendDiscoverySwitch(commandTopic, stateTopic, client.Name, client.Name+"_"+entityTypeName); err != nil {
+	meta := homeassistantdto.EntityMeta{
+		Icon: icon,
+	}
+	if err := p.discoveryClient.SendDiscoverySwitch(commandTopic, stateTopic, client, entityTypeName, meta); err != nil {
 		return fmt.Errorf("ClientPermit SendDiscoveryMessage error: %w", err)
 	}
 
@@ -58,14 +63,12 @@ func (p *ClientPermit) SendDiscoveryMessage(client dto.Client) error {
 
 // GetStateTopic returns state topic.
 func (p *ClientPermit) GetStateTopic(client dto.Client) string {
-	mac := strings.Replace(client.Mac, ":", "_", -1)
-	return fmt.Sprintf("%s/%s_%s/state", p.basetopic, mac, entityTypeName)
+	return p.topics.StateTopic(client, entityTypeName)
 }
 
 // GetCommandTopic returns command topic.
 func (p *ClientPermit) GetCommandTopic(client dto.Client) string {
-	mac := strings.Replace(client.Mac, ":", "_", -1)
-	return fmt.Sprintf("%s/%s_%s/command", p.basetopic, mac, entityTypeName)
+	return p.topics.CommandTopic(client, entityTypeName)
 }
 
 // GetState returns client permit state.
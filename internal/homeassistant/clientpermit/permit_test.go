@@ -7,6 +7,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	"keeneticToMqtt/internal/homeassistant/topic"
 	mock_clientpermit "keeneticToMqtt/test/mocks/gomock/homeassistant/clientpermit"
 )
 
@@ -14,6 +16,10 @@ func TestClientPermit_SendDiscoveryMessage(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	meta := homeassistantdto.EntityMeta{
+		Icon: icon,
+	}
+
 	const (
 		mac       = "mac"
 		name      = "name"
@@ -32,7 +38,7 @@ func TestClientPermit_SendDiscoveryMessage(t *testing.T) {
 			discovery: func() discovery {
 				discovery := mock_clientpermit.NewMockdiscovery(ctrl)
 				discovery.EXPECT().
-					SendDiscoverySwitch(gomock.Eq("basetopic/mac_permit/command"), gomock.Eq("basetopic/mac_permit/state"), gomock.Eq("name"), gomock.Eq("name_permit")).
+					SendDiscoverySwitch(gomock.Eq("basetopic/mac_permit/command"), gomock.Eq("basetopic/mac_permit/state"), gomock.Eq(client), gomock.Eq("permit"), gomock.Eq(meta)).
 					Return(nil)
 
 				return discovery
@@ -43,7 +49,7 @@ func TestClientPermit_SendDiscoveryMessage(t *testing.T) {
 			discovery: func() discovery {
 				discovery := mock_clientpermit.NewMockdiscovery(ctrl)
 				discovery.EXPECT().
-					SendDiscoverySwitch(gomock.Eq("basetopic/mac_permit/command"), gomock.Eq("basetopic/mac_permit/state"), gomock.Eq("name"), gomock.Eq("name_permit")).
+					SendDiscoverySwitch(gomock.Eq("basetopic/mac_permit/command"), gomock.Eq("basetopic/mac_permit/state"), gomock.Eq(client), gomock.Eq("permit"), gomock.Eq(meta)).
 					Return(someErr)
 
 				return discovery
@@ -55,7 +61,7 @@ func TestClientPermit_SendDiscoveryMessage(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			perimt := ClientPermit{
-				basetopic:       basetopic,
+				topics:          topic.NewTemplate(basetopic, ""),
 				discoveryClient: tt.discovery(),
 			}
 
@@ -166,7 +172,7 @@ func TestClientPermit_Consume(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			discovery := mock_clientpermit.NewMockdiscovery(ctrl)
 			permit := NewClientPermit(
-				basetopic,
+				topic.NewTemplate(basetopic, ""),
 				discovery,
 				tt.accessUpdate(),
 			)
@@ -2,32 +2,35 @@ package clientpolicy
 
 import (
 	"fmt"
-	"strings"
 
 	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	"keeneticToMqtt/internal/homeassistant/topic"
 )
 
 //go:generate mockgen -source=policy.go -destination=../../../test/mocks/gomock/homeassistant/clientpolicy/policy.go
 
 const (
 	entityTypeName = "policy"
+	icon           = "mdi:shield-account"
 )
 
 type (
 	discovery interface {
-		SendDiscoverySelect(commandTopic, stateTopic, deviceName, name string, options []string) error
+		SendDiscoverySelect(commandTopic, stateTopic string, client dto.Client, entityType string, options []string, meta homeassistantdto.EntityMeta) error
 	}
 	accessUpdate interface {
 		SetPolicy(mac, policy string) error
 	}
 	policyStorage interface {
-		GetPolicyList() []string
+		GetList() []string
+		Subscribe() <-chan struct{}
 	}
 )
 
 // ClientPolicy struct for handle home assistant client policy entities.
 type ClientPolicy struct {
-	basetopic       string
+	topics          *topic.Template
 	discoveryClient discovery
 	accessUpdate    accessUpdate
 	policyStorage   policyStorage
@@ -35,13 +38,13 @@ type ClientPolicy struct {
 
 // NewClientPolicy creates new ClientPolicy.
 func NewClientPolicy(
-	basetopic string,
+	topics *topic.Template,
 	discoveryClient discovery,
 	accessUpdate accessUpdate,
 	policyStorage policyStorage,
 ) *ClientPolicy {
 	return &ClientPolicy{
-		basetopic:       basetopic,
+		topics:          topics,
 		discoveryClient: discoveryClient,
 		accessUpdate:    accessUpdate,
 		policyStorage:   policyStorage,
@@ -52,15 +55,25 @@ func NewClientPolicy(
 func (p *ClientPolicy) SendDiscoveryMessage(client dto.Client) error {
 	commandTopic := p.GetCommandTopic(client)
 	stateTopic := p.GetStateTopic(client)
-	policies := p.policyStorage.GetPolicyList()
+	policies := p.policyStorage.GetList()
 
-	if err := p.discoveryClient.SendDiscoverySelect(commandTopic, stateTopic, client.Name, client.Name+"_"+entityTypeName, policies); err != nil {
+	meta := homeassistantdto.EntityMeta{
+		Icon:           icon,
+		EntityCategory: homeassistantdto.EntityCategoryConfig,
+	}
+	if err := p.discoveryClient.SendDiscoverySelect(commandTopic, stateTopic, client, entityTypeName, policies, meta); err != nil {
 		return fmt.Errorf("ClientPolicy SendDiscoveryMessage error: %w", err)
 	}
 
 	return nil
 }
 
+// DiscoveryChanges returns channel, which receives notification when policy list changes
+// and discovery message must be resent.
+func (p *ClientPolicy) DiscoveryChanges() <-chan struct{} {
+	return p.policyStorage.Subscribe()
+}
+
 // GetState returns entity state.
 func (p *ClientPolicy) GetState(client dto.Client) (string, error) {
 	return client.Policy, nil
@@ -76,12 +89,10 @@ func (p *ClientPolicy) Consume(client dto.Client, message string) error {
 
 // GetStateTopic returns state topic.
 func (p *ClientPolicy) GetStateTopic(client dto.Client) string {
-	mac := strings.Replace(client.Mac, ":", "_", -1)
-	return fmt.Sprintf("%s/%s_%s/state", p.basetopic, mac, entityTypeName)
+	return p.topics.StateTopic(client, entityTypeName)
 }
 
 // GetCommandTopic returns command topic.
 func (p *ClientPolicy) GetCommandTopic(client dto.Client) string {
-	mac := strings.Replace(client.Mac, ":", "_", -1)
-	return fmt.Sprintf("%s/%s_%s/command", p.basetopic, mac, entityTypeName)
+	return p.topics.CommandTopic(client, entityTypeName)
 }
@@ -8,6 +8,8 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	"keeneticToMqtt/internal/homeassistant/topic"
 	mock_clientpolicy "keeneticToMqtt/test/mocks/gomock/homeassistant/clientpolicy"
 )
 
@@ -15,6 +17,11 @@ func TestClientPolicy_SendDiscoveryMessage(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	meta := homeassistantdto.EntityMeta{
+		Icon:           icon,
+		EntityCategory: homeassistantdto.EntityCategoryConfig,
+	}
+
 	const (
 		mac       = "mac"
 		name      = "name"
@@ -39,9 +46,10 @@ func TestClientPolicy_SendDiscoveryMessage(t *testing.T) {
 					SendDiscoverySelect(
 						gomock.Eq("basetopic/mac_policy/command"),
 						gomock.Eq("basetopic/mac_policy/state"),
-						gomock.Eq(name),
-						gomock.Eq("name_policy"),
+						gomock.Eq(client),
+						gomock.Eq("policy"),
 						gomock.Eq(policies),
+						gomock.Eq(meta),
 					).
 					Return(nil)
 
@@ -49,7 +57,7 @@ func TestClientPolicy_SendDiscoveryMessage(t *testing.T) {
 			},
 			policyStorage: func() policyStorage {
 				policyStorage := mock_clientpolicy.NewMockpolicyStorage(ctrl)
-				policyStorage.EXPECT().GetPolicyList().Return(policies)
+				policyStorage.EXPECT().GetList().Return(policies)
 
 				return policyStorage
 			},
@@ -62,9 +70,10 @@ func TestClientPolicy_SendDiscoveryMessage(t *testing.T) {
 					SendDiscoverySelect(
 						gomock.Eq("basetopic/mac_policy/command"),
 						gomock.Eq("basetopic/mac_policy/state"),
-						gomock.Eq(name),
-						gomock.Eq("name_policy"),
+						gomock.Eq(client),
+						gomock.Eq("policy"),
 						gomock.Eq(policies),
+						gomock.Eq(meta),
 					).
 					Return(someErr)
 
@@ -72,7 +81,7 @@ func TestClientPolicy_SendDiscoveryMessage(t *testing.T) {
 			},
 			policyStorage: func() policyStorage {
 				policyStorage := mock_clientpolicy.NewMockpolicyStorage(ctrl)
-				policyStorage.EXPECT().GetPolicyList().Return(policies)
+				policyStorage.EXPECT().GetList().Return(policies)
 
 				return policyStorage
 			},
@@ -83,7 +92,7 @@ func TestClientPolicy_SendDiscoveryMessage(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			policy := ClientPolicy{
-				basetopic:       basetopic,
+				topics:          topic.NewTemplate(basetopic, ""),
 				discoveryClient: tt.discovery(),
 				policyStorage:   tt.policyStorage(),
 			}
@@ -98,6 +107,18 @@ func TestClientPolicy_SendDiscoveryMessage(t *testing.T) {
 	}
 }
 
+func TestClientPolicy_DiscoveryChanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ch := make(chan struct{})
+	policyStorage := mock_clientpolicy.NewMockpolicyStorage(ctrl)
+	policyStorage.EXPECT().Subscribe().Return(ch)
+
+	clientPolicy := NewClientPolicy(topic.NewTemplate("basetopic", ""), nil, nil, policyStorage)
+	assert.Equal(t, (<-chan struct{})(ch), clientPolicy.DiscoveryChanges())
+}
+
 func TestClientPolicy_GetState(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -186,7 +207,7 @@ func TestClientPolicy_Consume(t *testing.T) {
 			discovery := mock_clientpolicy.NewMockdiscovery(ctrl)
 			policyStorage := mock_clientpolicy.NewMockpolicyStorage(ctrl)
 			policy := NewClientPolicy(
-				basetopic,
+				topic.NewTemplate(basetopic, ""),
 				discovery,
 				tt.accessUpdate(),
 				policyStorage,
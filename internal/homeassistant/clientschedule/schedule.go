@@ -0,0 +1,98 @@
+package clientschedule
+
+import (
+	"fmt"
+
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	"keeneticToMqtt/internal/homeassistant/topic"
+)
+
+//go:generate mockgen -source=schedule.go -destination=../../../test/mocks/gomock/homeassistant/clientschedule/schedule.go
+
+const (
+	entityTypeName = "schedule"
+	icon           = "mdi:calendar-clock"
+)
+
+type (
+	discovery interface {
+		SendDiscoverySelect(commandTopic, stateTopic string, client dto.Client, entityType string, options []string, meta homeassistantdto.EntityMeta) error
+	}
+	accessUpdate interface {
+		SetSchedule(mac, schedule string) error
+	}
+	scheduleStorage interface {
+		GetList() []string
+		Subscribe() <-chan struct{}
+	}
+)
+
+// ClientSchedule struct for handle home assistant client access schedule entities.
+type ClientSchedule struct {
+	topics          *topic.Template
+	discoveryClient discovery
+	accessUpdate    accessUpdate
+	scheduleStorage scheduleStorage
+}
+
+// NewClientSchedule creates new ClientSchedule.
+func NewClientSchedule(
+	topics *topic.Template,
+	discoveryClient discovery,
+	accessUpdate accessUpdate,
+	scheduleStorage scheduleStorage,
+) *ClientSchedule {
+	return &ClientSchedule{
+		topics:          topics,
+		discoveryClient: discoveryClient,
+		accessUpdate:    accessUpdate,
+		scheduleStorage: scheduleStorage,
+	}
+}
+
+// SendDiscoveryMessage sends homeassistant discovery message.
+func (s *ClientSchedule) SendDiscoveryMessage(client dto.Client) error {
+	commandTopic := s.GetCommandTopic(client)
+	stateTopic := s.GetStateTopic(client)
+	schedules := s.scheduleStorage.GetList()
+
+	meta := homeassistantdto.EntityMeta{
+		Icon:           icon,
+		EntityCategory: homeassistantdto.EntityCategoryConfig,
+	}
+	if err := s.discoveryClient.SendDiscoverySelect(commandTopic, stateTopic, client, entityTypeName, schedules, meta); err != nil {
+		return fmt.Errorf("ClientSchedule SendDiscoveryMessage error: %w", err)
+	}
+
+	return nil
+}
+
+// DiscoveryChanges returns channel, which receives notification when schedule list changes
+// and discovery message must be resent.
+func (s *ClientSchedule) DiscoveryChanges() <-chan struct{} {
+	return s.scheduleStorage.Subscribe()
+}
+
+// GetState returns entity state.
+func (s *ClientSchedule) GetState(client dto.Client) (string, error) {
+	return client.Schedule, nil
+}
+
+// Consume consumes message.
+func (s *ClientSchedule) Consume(client dto.Client, message string) error {
+	if err := s.accessUpdate.SetSchedule(client.Mac, message); err != nil {
+		return fmt.Errorf("client error while setting schedule: %w", err)
+	}
+	return nil
+}
+
+// GetStateTopic returns state topic.
+func (s *ClientSchedule) GetStateTopic(client dto.Client) string {
+	return s.topics.StateTopic(client, entityTypeName)
+}
+
+// GetCommandTopic returns command topic.
+func (s *ClientSchedule) GetCommandTopic(client dto.Client) string {
+	return s.topics.CommandTopic(client, entityTypeName)
+}
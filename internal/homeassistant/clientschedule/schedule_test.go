@@ -0,0 +1,224 @@
+package clientschedule
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	"keeneticToMqtt/internal/homeassistant/topic"
+	mock_clientschedule "keeneticToMqtt/test/mocks/gomock/homeassistant/clientschedule"
+)
+
+func TestClientSchedule_SendDiscoveryMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	meta := homeassistantdto.EntityMeta{
+		Icon:           icon,
+		EntityCategory: homeassistantdto.EntityCategoryConfig,
+	}
+
+	const (
+		mac       = "mac"
+		name      = "name"
+		basetopic = "basetopic"
+	)
+	someErr := errors.New("some error")
+	schedules := []string{name}
+
+	client := dto.Client{Mac: mac, Name: name}
+
+	tests := []struct {
+		name            string
+		expectedErr     error
+		discovery       func() discovery
+		scheduleStorage func() scheduleStorage
+	}{
+		{
+			name: "success send discovery message",
+			discovery: func() discovery {
+				discovery := mock_clientschedule.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendDiscoverySelect(
+						gomock.Eq("basetopic/mac_schedule/command"),
+						gomock.Eq("basetopic/mac_schedule/state"),
+						gomock.Eq(client),
+						gomock.Eq("schedule"),
+						gomock.Eq(schedules),
+						gomock.Eq(meta),
+					).
+					Return(nil)
+
+				return discovery
+			},
+			scheduleStorage: func() scheduleStorage {
+				scheduleStorage := mock_clientschedule.NewMockscheduleStorage(ctrl)
+				scheduleStorage.EXPECT().GetList().Return(schedules)
+
+				return scheduleStorage
+			},
+		},
+		{
+			name: "error while send discovery message",
+			discovery: func() discovery {
+				discovery := mock_clientschedule.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendDiscoverySelect(
+						gomock.Eq("basetopic/mac_schedule/command"),
+						gomock.Eq("basetopic/mac_schedule/state"),
+						gomock.Eq(client),
+						gomock.Eq("schedule"),
+						gomock.Eq(schedules),
+						gomock.Eq(meta),
+					).
+					Return(someErr)
+
+				return discovery
+			},
+			scheduleStorage: func() scheduleStorage {
+				scheduleStorage := mock_clientschedule.NewMockscheduleStorage(ctrl)
+				scheduleStorage.EXPECT().GetList().Return(schedules)
+
+				return scheduleStorage
+			},
+			expectedErr: someErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := ClientSchedule{
+				topics:          topic.NewTemplate(basetopic, ""),
+				discoveryClient: tt.discovery(),
+				scheduleStorage: tt.scheduleStorage(),
+			}
+
+			err := schedule.SendDiscoveryMessage(client)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestClientSchedule_DiscoveryChanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ch := make(chan struct{})
+	scheduleStorage := mock_clientschedule.NewMockscheduleStorage(ctrl)
+	scheduleStorage.EXPECT().Subscribe().Return(ch)
+
+	clientSchedule := NewClientSchedule(topic.NewTemplate("basetopic", ""), nil, nil, scheduleStorage)
+	assert.Equal(t, (<-chan struct{})(ch), clientSchedule.DiscoveryChanges())
+}
+
+func TestClientSchedule_GetState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		mac      = "mac"
+		schedule = "schedule"
+		name     = "name"
+	)
+
+	tests := []struct {
+		name     string
+		expected string
+		client   dto.Client
+	}{
+		{
+			name: "success schedule get",
+			client: dto.Client{
+				Mac:      mac,
+				Schedule: schedule,
+				Name:     name,
+			},
+			expected: schedule,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientSchedule := ClientSchedule{}
+
+			res, err := clientSchedule.GetState(tt.client)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, res)
+		})
+	}
+}
+
+func TestClientSchedule_Consume(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		mac       = "mac"
+		name      = "name"
+		basetopic = "basetopic"
+	)
+
+	someErr := errors.New("some error")
+
+	tests := []struct {
+		name         string
+		expectedErr  error
+		client       dto.Client
+		accessUpdate func() accessUpdate
+		payload      string
+	}{
+		{
+			name:   "run schedule consumer",
+			client: dto.Client{Mac: mac},
+			accessUpdate: func() accessUpdate {
+				accessUpdate := mock_clientschedule.NewMockaccessUpdate(ctrl)
+				accessUpdate.EXPECT().
+					SetSchedule(gomock.Eq(mac), gomock.Eq(name)).
+					Return(nil)
+				return accessUpdate
+			},
+			payload: name,
+		},
+		{
+			name:   "error while setting schedule",
+			client: dto.Client{Mac: mac},
+			accessUpdate: func() accessUpdate {
+				accessUpdate := mock_clientschedule.NewMockaccessUpdate(ctrl)
+				accessUpdate.EXPECT().
+					SetSchedule(gomock.Eq(mac), gomock.Eq(name)).
+					Return(someErr)
+				return accessUpdate
+			},
+			payload:     name,
+			expectedErr: someErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discovery := mock_clientschedule.NewMockdiscovery(ctrl)
+			scheduleStorage := mock_clientschedule.NewMockscheduleStorage(ctrl)
+			schedule := NewClientSchedule(
+				topic.NewTemplate(basetopic, ""),
+				discovery,
+				tt.accessUpdate(),
+				scheduleStorage,
+			)
+
+			err := schedule.Consume(tt.client, tt.payload)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package homeassistant
+
+import (
+	"encoding/json"
+	"errors"
+
+	"keeneticToMqtt/internal/errs"
+)
+
+// resultTopicSuffix suffix of command result topic, result topic is <commandTopic>/result.
+const resultTopicSuffix = "/result"
+
+// error codes of failed command result.
+const (
+	resultCodeUnauthorized     = "unauthorized"
+	resultCodeUnknownPolicy    = "unknown_policy"
+	resultCodeInvalidMac       = "invalid_mac"
+	resultCodePermissionDenied = "permission_denied"
+	resultCodeCommandFailed    = "command_failed"
+	resultCodeError            = "error"
+)
+
+type commandResult struct {
+	Success bool   `json:"success"`
+	Command string `json:"command"`
+	Code    string `json:"code,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ResultTopic returns topic for results of commands sent to commandTopic.
+func ResultTopic(commandTopic string) string {
+	return commandTopic + resultTopicSuffix
+}
+
+// resultMessage returns json result of command message, err is nil for successful command.
+func resultMessage(message string, err error) string {
+	result := commandResult{
+		Success: err == nil,
+		Command: message,
+	}
+	if err != nil {
+		result.Code = resultCode(err)
+		result.Error = err.Error()
+	}
+
+	b, _ := json.Marshal(result)
+	return string(b)
+}
+
+// resultCode returns machine readable code of command error.
+func resultCode(err error) string {
+	switch {
+	case errors.Is(err, errs.ErrUnauthorized):
+		return resultCodeUnauthorized
+	case errors.Is(err, errs.ErrUnknownPolicy):
+		return resultCodeUnknownPolicy
+	case errors.Is(err, errs.ErrInvalidMac):
+		return resultCodeInvalidMac
+	case errors.Is(err, errs.ErrPermissionDenied):
+		return resultCodePermissionDenied
+	case errors.Is(err, errs.ErrCommandFailed):
+		return resultCodeCommandFailed
+	default:
+		return resultCodeError
+	}
+}
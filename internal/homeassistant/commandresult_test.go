@@ -0,0 +1,67 @@
+package homeassistant
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"keeneticToMqtt/internal/errs"
+)
+
+func TestResultTopic(t *testing.T) {
+	assert.Equal(t, "base/mac_policy/command/result", ResultTopic("base/mac_policy/command"))
+}
+
+func TestResultMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		err      error
+		expected string
+	}{
+		{
+			name:     "success",
+			message:  "ON",
+			expected: `{"success":true,"command":"ON"}`,
+		},
+		{
+			name:     "unknown policy",
+			message:  "Policy5",
+			err:      fmt.Errorf("client error while setting policy: %w", errs.ErrUnknownPolicy),
+			expected: `{"success":false,"command":"Policy5","code":"unknown_policy","error":"client error while setting policy: unknown policy"}`,
+		},
+		{
+			name:     "invalid mac",
+			err:      errs.ErrInvalidMac,
+			expected: `{"success":false,"command":"","code":"invalid_mac","error":"invalid mac"}`,
+		},
+		{
+			name:     "permission denied",
+			err:      errs.ErrPermissionDenied,
+			expected: `{"success":false,"command":"","code":"permission_denied","error":"permission denied"}`,
+		},
+		{
+			name:     "unauthorized",
+			err:      errs.ErrUnauthorized,
+			expected: `{"success":false,"command":"","code":"unauthorized","error":"unauthorized"}`,
+		},
+		{
+			name:     "command failed",
+			err:      errs.ErrCommandFailed,
+			expected: `{"success":false,"command":"","code":"command_failed","error":"command failed"}`,
+		},
+		{
+			name:     "other error",
+			message:  "abc",
+			err:      errors.New("invalid limit"),
+			expected: `{"success":false,"command":"abc","code":"error","error":"invalid limit"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, resultMessage(tt.message, tt.err))
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package connectivity
+
+import (
+	"fmt"
+
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	"keeneticToMqtt/internal/homeassistant/topic"
+)
+
+//go:generate mockgen -source=connectivity.go -destination=../../../test/mocks/gomock/homeassistant/connectivity/connectivity.go
+
+const (
+	entityTypeName = "connectivity"
+	deviceClass    = "connectivity"
+	offPayload     = "OFF"
+	onPayload      = "ON"
+)
+
+type (
+	discovery interface {
+		SendDiscoveryBinarySensor(stateTopic string, client dto.Client, entityType string, meta homeassistantdto.EntityMeta) error
+	}
+)
+
+// Connectivity struct for handle home assistant client connectivity entities.
+type Connectivity struct {
+	topics          *topic.Template
+	discoveryClient discovery
+}
+
+// NewConnectivity creates new Connectivity.
+func NewConnectivity(
+	topics *topic.Template,
+	discoveryClient discovery,
+) *Connectivity {
+	return &Connectivity{
+		topics:          topics,
+		discoveryClient: discoveryClient,
+	}
+}
+
+// SendDiscoveryMessage sends homeassistant discovery message.
+func (c *Connectivity) SendDiscoveryMessage(client dto.Client) error {
+	stateTopic := c.GetStateTopic(client)
+	meta := homeassistantdto.EntityMeta{
+		DeviceClass: deviceClass,
+	}
+	if err := c.discoveryClient.SendDiscoveryBinarySensor(stateTopic, client, entityTypeName, meta); err != nil {
+		return fmt.Errorf("Connectivity SendDiscoveryMessage error: %w", err)
+	}
+
+	return nil
+}
+
+// GetState returns entity state.
+func (c *Connectivity) GetState(client dto.Client) (string, error) {
+	msg := offPayload
+	if client.Online {
+		msg = onPayload
+	}
+	return msg, nil
+}
+
+// Consume consumes message.
+func (c *Connectivity) Consume(_ dto.Client, _ string) error {
+	return nil
+}
+
+// GetStateTopic returns state topic.
+func (c *Connectivity) GetStateTopic(client dto.Client) string {
+	return c.topics.StateTopic(client, entityTypeName)
+}
+
+// GetCommandTopic returns command topic.
+func (c *Connectivity) GetCommandTopic(_ dto.Client) string {
+	return ""
+}
@@ -0,0 +1,123 @@
+package connectivity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	"keeneticToMqtt/internal/homeassistant/topic"
+	mock_connectivity "keeneticToMqtt/test/mocks/gomock/homeassistant/connectivity"
+)
+
+func TestConnectivity_SendDiscoveryMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	meta := homeassistantdto.EntityMeta{
+		DeviceClass: deviceClass,
+	}
+
+	const (
+		mac       = "mac"
+		name      = "name"
+		basetopic = "basetopic"
+	)
+	someErr := errors.New("some error")
+
+	client := dto.Client{Mac: mac, Name: name}
+
+	tests := []struct {
+		name        string
+		expectedErr error
+		discovery   func() discovery
+	}{
+		{
+			name: "success send discovery message",
+			discovery: func() discovery {
+				discovery := mock_connectivity.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendDiscoveryBinarySensor(
+						gomock.Eq("basetopic/mac_connectivity/state"),
+						gomock.Eq(client),
+						gomock.Eq("connectivity"),
+						gomock.Eq(meta),
+					).
+					Return(nil)
+
+				return discovery
+			},
+		},
+		{
+			name: "error while send discovery message",
+			discovery: func() discovery {
+				discovery := mock_connectivity.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendDiscoveryBinarySensor(
+						gomock.Eq("basetopic/mac_connectivity/state"),
+						gomock.Eq(client),
+						gomock.Eq("connectivity"),
+						gomock.Eq(meta),
+					).
+					Return(someErr)
+
+				return discovery
+			},
+			expectedErr: someErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connectivity := NewConnectivity(topic.NewTemplate(basetopic, ""), tt.discovery())
+			err := connectivity.SendDiscoveryMessage(client)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestConnectivity_GetState(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		client   dto.Client
+	}{
+		{
+			name:     "client is online",
+			client:   dto.Client{Online: true},
+			expected: onPayload,
+		},
+		{
+			name:     "client is offline",
+			client:   dto.Client{Online: false},
+			expected: offPayload,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connectivity := Connectivity{}
+			res, err := connectivity.GetState(tt.client)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, res)
+		})
+	}
+}
+
+func TestConnectivity_Consume(t *testing.T) {
+	connectivity := Connectivity{}
+
+	err := connectivity.Consume(dto.Client{}, "")
+	assert.Nil(t, err)
+}
+
+func TestConnectivity_GetCommandTopic(t *testing.T) {
+	connectivity := Connectivity{}
+	assert.Empty(t, connectivity.GetCommandTopic(dto.Client{}))
+}
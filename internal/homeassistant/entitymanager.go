@@ -12,7 +12,9 @@ import (
 
 type mqtt interface {
 	Subscribe(topic string) chan string
+	Unsubscribe(topic string)
 	SendMessage(topic, message string, retained bool)
+	IsConnected() bool
 }
 
 // Entity home assistant entity.
@@ -24,25 +26,60 @@ type Entity interface {
 	GetState(client dto.Client) (string, error)
 }
 
+// DiscoveryNotifier is implemented by entities, which discovery message depends on data changing in runtime,
+// for example on keenetic policy list.
+type DiscoveryNotifier interface {
+	DiscoveryChanges() <-chan struct{}
+}
+
+// RetainedEntity is implemented by entities, which state is published as retained message,
+// for example client json attributes.
+type RetainedEntity interface {
+	Retained() bool
+}
+
 type clientList interface {
 	GetClientList() ([]dto.Client, error)
 }
 
+type discovery interface {
+	RemoveClientDiscovery(mac string)
+	RemoveStaleClients(clients []dto.Client)
+}
+
+type statePublisher interface {
+	Publish(clients []dto.Client) error
+}
+
 type logger interface {
 	Info(msg string, args ...any)
 	Error(msg string, args ...any)
 }
 
+// clientState identifies sent state of client entity.
+type clientState struct {
+	topic string
+	mac   string
+}
+
 // EntityManager entity manager for keenetic client entities in home assistant.
 type EntityManager struct {
-	entities          []Entity
-	clientList        clientList
-	mqtt              mqtt
-	pollingInterval   time.Duration
-	logger            logger
-	clients           map[string]dto.Client
-	entityStates      map[string]map[string]string
-	entityStatesMutex sync.RWMutex
+	entities        []Entity
+	clientList      clientList
+	mqtt            mqtt
+	discovery       discovery
+	statePublisher  statePublisher
+	pollingInterval time.Duration
+	logger          logger
+	clients         map[string]dto.Client
+	clientStops     map[string]chan struct{}
+	updateMutex     sync.Mutex
+	staleRemoved    bool
+	states          *stateCache[clientState]
+
+	trafficSamples      map[string]trafficSample
+	trafficSamplesMutex sync.Mutex
+	now                 func() time.Time
 }
 
 // NewEntityManager creates new EntityManager.
@@ -50,6 +87,8 @@ func NewEntityManager(
 	entities []Entity,
 	clientList clientList,
 	mqtt mqtt,
+	discovery discovery,
+	statePublisher statePublisher,
 	pollingInterval time.Duration,
 	logger logger,
 ) *EntityManager {
@@ -57,34 +96,38 @@ func NewEntityManager(
 		entities:        entities,
 		clientList:      clientList,
 		mqtt:            mqtt,
+		discovery:       discovery,
+		statePublisher:  statePublisher,
 		pollingInterval: pollingInterval,
 		logger:          logger,
 		clients:         map[string]dto.Client{},
-		entityStates:    make(map[string]map[string]string),
+		clientStops:     make(map[string]chan struct{}),
+		states:          newStateCache[clientState](),
+		trafficSamples:  make(map[string]trafficSample),
+		now:             time.Now,
 	}
 }
 
 // Run entity updates and command consumer.
 func (m *EntityManager) Run() chan struct{} {
-	done := make(chan struct{})
-	ticker := time.NewTicker(m.pollingInterval)
-
-	go func() {
-		for {
-			select {
-			case <-done:
-				m.logger.Info("shutdown entitymanager")
-				return
-			case _ = <-ticker.C:
-				m.update()
-			}
+	stop := make(chan struct{})
+
+	for _, entity := range m.entities {
+		if notifier, ok := entity.(DiscoveryNotifier); ok {
+			go m.runDiscoveryNotifier(entity, notifier.DiscoveryChanges(), stop)
 		}
-	}()
+	}
 
-	return done
+	return runUpdates(m.pollingInterval, m.mqtt, m.update, func() {
+		close(stop)
+		m.logger.Info("shutdown entitymanager")
+	})
 }
 
 func (m *EntityManager) update() {
+	m.updateMutex.Lock()
+	defer m.updateMutex.Unlock()
+
 	clients, err := m.clientList.GetClientList()
 	if err != nil {
 		m.logger.Error("Entity manager get state error", "error", err)
@@ -92,17 +135,138 @@ func (m *EntityManager) update() {
 	}
 	m.logger.Info("Entity manager update", "clients", clients)
 
+	actual := make(map[string]bool, len(clients))
+	published := make([]dto.Client, 0, len(clients))
 	for _, client := range clients {
-		_, ok := m.clients[client.Mac]
+		actual[client.Mac] = true
+		client = m.fillTrafficRates(client)
+		published = append(published, client)
+		previous, ok := m.clients[client.Mac]
 		if !ok {
 			m.runClient(client)
+		} else if previous.Name != client.Name {
+			m.renameClient(previous, client)
 		}
 		// update client because it can change
 		m.clients[client.Mac] = client
 
 		m.updateEntitiesState(client)
 	}
-	return
+
+	for mac, client := range m.clients {
+		if !actual[mac] {
+			m.removeClient(client)
+		}
+	}
+
+	// clients removed while bridge was down are known only after the first successful client list
+	if !m.staleRemoved {
+		m.discovery.RemoveStaleClients(clients)
+		m.staleRemoved = true
+	}
+
+	if err := m.statePublisher.Publish(published); err != nil {
+		m.logger.Error("Entity manager publish client states error", "error", err)
+	}
+}
+
+// Resync resends discovery messages and all states, for example after home assistant restart.
+func (m *EntityManager) Resync() {
+	m.updateMutex.Lock()
+	m.states.reset()
+
+	for _, client := range m.clients {
+		for _, entity := range m.entities {
+			m.sendDiscovery(client, entity)
+		}
+	}
+	m.updateMutex.Unlock()
+
+	m.update()
+}
+
+// removeClient removes home assistant entities of client, which disappeared from client list,
+// and stops client consumers.
+func (m *EntityManager) removeClient(client dto.Client) {
+	m.logger.Info("Entity manager remove client", "client", client)
+
+	m.stopClient(client)
+	m.discovery.RemoveClientDiscovery(client.Mac)
+
+	m.trafficSamplesMutex.Lock()
+	delete(m.trafficSamples, client.Mac)
+	m.trafficSamplesMutex.Unlock()
+
+	delete(m.clients, client.Mac)
+}
+
+// stopClient stops client consumers, unsubscribes from client command topics and clears client states.
+func (m *EntityManager) stopClient(client dto.Client) {
+	if stop, ok := m.clientStops[client.Mac]; ok {
+		close(stop)
+		delete(m.clientStops, client.Mac)
+	}
+
+	for _, entity := range m.entities {
+		if commandTopic := entity.GetCommandTopic(client); commandTopic != "" {
+			m.mqtt.Unsubscribe(commandTopic)
+		}
+		if stateTopic := entity.GetStateTopic(client); stateTopic != "" {
+			m.states.forget(clientState{topic: stateTopic, mac: client.Mac})
+			m.mqtt.SendMessage(stateTopic, "", true)
+		}
+	}
+}
+
+// renameClient resends discovery messages of client with new name.
+// Entity unique ids depend on client mac, so home assistant renames existing entities.
+// Client is restarted, when topic template contains client name and entity topics change.
+func (m *EntityManager) renameClient(previous, client dto.Client) {
+	m.logger.Info("Entity manager rename client", "client", client, "previousName", previous.Name)
+
+	if m.topicsChanged(previous, client) {
+		m.stopClient(previous)
+		m.runClient(client)
+		return
+	}
+
+	for _, entity := range m.entities {
+		go m.sendDiscovery(client, entity)
+	}
+}
+
+// topicsChanged reports whether any entity topic of client changed.
+func (m *EntityManager) topicsChanged(previous, client dto.Client) bool {
+	for _, entity := range m.entities {
+		if entity.GetStateTopic(previous) != entity.GetStateTopic(client) ||
+			entity.GetCommandTopic(previous) != entity.GetCommandTopic(client) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// runDiscoveryNotifier resends entity discovery messages for all clients on every entity notification.
+func (m *EntityManager) runDiscoveryNotifier(e Entity, changes <-chan struct{}, stop chan struct{}) {
+	for {
+		select {
+		case <-stop:
+			return
+		case <-changes:
+		}
+
+		m.updateMutex.Lock()
+		clients := make([]dto.Client, 0, len(m.clients))
+		for _, client := range m.clients {
+			clients = append(clients, client)
+		}
+		m.updateMutex.Unlock()
+
+		for _, client := range clients {
+			m.sendDiscovery(client, e)
+		}
+	}
 }
 
 // updateEntitiesState sends mqtt messages with updates to state topic only if state changes.
@@ -119,40 +283,45 @@ func (m *EntityManager) updateEntitiesState(client dto.Client) {
 				)
 				return
 			}
-			m.entityStatesMutex.Lock()
-			entityStorage, ok := m.entityStates[stateTopic]
-			if ok {
-				storageState, ok := entityStorage[client.Mac]
-				if ok && storageState == state {
-					continue
-				}
-			}
-			if m.entityStates[stateTopic] == nil {
-				m.entityStates[stateTopic] = make(map[string]string)
+			if !m.states.changed(clientState{topic: stateTopic, mac: client.Mac}, state) {
+				continue
 			}
-			m.entityStates[stateTopic][client.Mac] = state
-			m.entityStatesMutex.Unlock()
-			m.mqtt.SendMessage(stateTopic, state, false)
+			m.mqtt.SendMessage(stateTopic, state, isRetained(entity))
 		}
 	}
 }
 
 func (m *EntityManager) runClient(client dto.Client) {
+	stop := make(chan struct{})
+	m.clientStops[client.Mac] = stop
 	for _, entity := range m.entities {
 		e := entity
-		go m.runClientEntityConsumer(e, client)
+		go m.runClientEntityConsumer(e, client, stop)
 		go m.sendDiscovery(client, e)
 	}
 }
 
-func (m *EntityManager) runClientEntityConsumer(e Entity, client dto.Client) {
+func (m *EntityManager) runClientEntityConsumer(e Entity, client dto.Client, stop chan struct{}) {
 	commandTopic := e.GetCommandTopic(client)
 	if commandTopic == "" {
 		return
 	}
 	ch := m.mqtt.Subscribe(commandTopic)
 	for {
-		message := <-ch
+		var message string
+		select {
+		case <-stop:
+			return
+		case message = <-ch:
+		}
+
+		// client could change since consumer start
+		m.updateMutex.Lock()
+		if actual, ok := m.clients[client.Mac]; ok {
+			client = actual
+		}
+		m.updateMutex.Unlock()
+
 		err := e.Consume(client, message)
 		if err != nil {
 			m.logger.Error("error while entity consume",
@@ -162,7 +331,9 @@ func (m *EntityManager) runClientEntityConsumer(e Entity, client dto.Client) {
 				"error", err,
 			)
 		}
-		m.update()
+		finishCommand(m.mqtt, commandTopic, message, err, func() {
+			m.states.forget(clientState{topic: e.GetStateTopic(client), mac: client.Mac})
+		}, m.update)
 	}
 }
 
@@ -175,3 +346,9 @@ func (m *EntityManager) sendDiscovery(client dto.Client, e Entity) {
 		)
 	}
 }
+
+// isRetained checks if entity state is published as retained message.
+func isRetained(entity Entity) bool {
+	retained, ok := entity.(RetainedEntity)
+	return ok && retained.Retained()
+}
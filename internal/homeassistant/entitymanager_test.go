@@ -19,15 +19,19 @@ func TestEntityManager_Run(t *testing.T) {
 		macNew          = "macNew"
 		stateTopic      = "stateTopic"
 		stateTopicNew   = "stateTopicNew"
+		commandTopic    = "commandTopic"
 		commandTopicNew = "commandTopicNew"
-		storageState    = "storageState"
-		state           = "state"
-		stateNew        = "stateNew"
-		command         = "command"
+		// topics of renamed client, when topic template contains client name
+		stateTopicRenamed   = "stateTopicRenamed"
+		commandTopicRenamed = "commandTopicRenamed"
+		storageState        = "storageState"
+		state               = "state"
+		stateNew            = "stateNew"
+		command             = "command"
 	)
 
-	clientDto := dto.Client{Mac: mac}
-	clientDtoNew := dto.Client{Mac: macNew}
+	clientDto := dto.Client{Mac: mac, Name: "name"}
+	clientDtoNew := dto.Client{Mac: macNew, Name: "nameNew"}
 
 	clients := []dto.Client{
 		clientDto,
@@ -38,6 +42,10 @@ func TestEntityManager_Run(t *testing.T) {
 	clientsOnlyNew := []dto.Client{
 		clientDtoNew,
 	}
+	clientDtoRenamed := dto.Client{Mac: mac, Name: "nameRenamed"}
+	clientsRenamed := []dto.Client{
+		clientDtoRenamed,
+	}
 
 	someErr := errors.New("some error")
 	tests := []struct {
@@ -45,6 +53,7 @@ func TestEntityManager_Run(t *testing.T) {
 		entities     func() []Entity
 		clientList   func() clientList
 		mqtt         func() mqtt
+		discovery    func() discovery
 		logger       func() logger
 		clients      map[string]dto.Client
 		entityStates map[string]map[string]string
@@ -229,6 +238,7 @@ func TestEntityManager_Run(t *testing.T) {
 				}()
 				mqtt := mock_homeassistant.NewMockmqtt(ctrl)
 				mqtt.EXPECT().SendMessage(stateTopicNew, stateNew, false)
+				mqtt.EXPECT().SendMessage(ResultTopic(commandTopicNew), `{"success":true,"command":"command"}`, false)
 				mqtt.EXPECT().Subscribe(commandTopicNew).Return(ch)
 				return mqtt
 			},
@@ -252,6 +262,7 @@ func TestEntityManager_Run(t *testing.T) {
 				entity.EXPECT().GetState(clientDtoNew).Return(stateNew, nil)
 				entity.EXPECT().GetState(clientDtoNew).Return(stateNew, nil)
 				entity.EXPECT().SendDiscoveryMessage(clientDtoNew).Return(nil)
+				entity.EXPECT().GetStateTopic(clientDtoNew).Return(stateTopicNew)
 				entity.EXPECT().Consume(clientDtoNew, command).Return(someErr)
 				return []Entity{entity}
 			},
@@ -267,7 +278,9 @@ func TestEntityManager_Run(t *testing.T) {
 					ch <- command
 				}()
 				mqtt := mock_homeassistant.NewMockmqtt(ctrl)
-				mqtt.EXPECT().SendMessage(stateTopicNew, stateNew, false)
+				// state is resent after rollback of rejected command
+				mqtt.EXPECT().SendMessage(stateTopicNew, stateNew, false).Times(2)
+				mqtt.EXPECT().SendMessage(ResultTopic(commandTopicNew), `{"success":false,"command":"command","code":"error","error":"some error"}`, false)
 				mqtt.EXPECT().Subscribe(commandTopicNew).Return(ch)
 				return mqtt
 			},
@@ -353,21 +366,185 @@ func TestEntityManager_Run(t *testing.T) {
 			},
 			entityStates: map[string]map[string]string{},
 		},
+		{
+			name: "remove disappeared client",
+			entities: func() []Entity {
+				entity := mock_homeassistant.NewMockEntity(ctrl)
+				entity.EXPECT().GetStateTopic(clientDtoNew).Return(stateTopicNew)
+				entity.EXPECT().GetState(clientDtoNew).Return(stateNew, nil)
+				entity.EXPECT().GetCommandTopic(clientDto).Return(commandTopic)
+				entity.EXPECT().GetStateTopic(clientDto).Return(stateTopic)
+				return []Entity{entity}
+			},
+			clientList: func() clientList {
+				clientList := mock_homeassistant.NewMockclientList(ctrl)
+				clientList.EXPECT().GetClientList().Return(clientsOnlyNew, nil)
+				return clientList
+			},
+			mqtt: func() mqtt {
+				mqtt := mock_homeassistant.NewMockmqtt(ctrl)
+				mqtt.EXPECT().SendMessage(stateTopicNew, stateNew, false)
+				mqtt.EXPECT().Unsubscribe(commandTopic)
+				mqtt.EXPECT().SendMessage(stateTopic, "", true)
+				return mqtt
+			},
+			discovery: func() discovery {
+				discovery := mock_homeassistant.NewMockdiscovery(ctrl)
+				discovery.EXPECT().RemoveClientDiscovery(clientDto.Mac)
+				return discovery
+			},
+			logger: func() logger {
+				logger := mock_homeassistant.NewMocklogger(ctrl)
+				logger.EXPECT().Info("Entity manager update", "clients", clientsOnlyNew)
+				logger.EXPECT().Info("Entity manager remove client", "client", clientDto)
+				logger.EXPECT().Info("shutdown entitymanager")
+				return logger
+			},
+			clients: map[string]dto.Client{
+				mac:    clientDto,
+				macNew: clientDtoNew,
+			},
+			entityStates: map[string]map[string]string{
+				stateTopic: {mac: state},
+			},
+		},
+		{
+			name: "several entities with same state as in storage",
+			entities: func() []Entity {
+				entity := mock_homeassistant.NewMockEntity(ctrl)
+				entity.EXPECT().GetStateTopic(clientDto).Return(stateTopic)
+				entity.EXPECT().GetState(clientDto).Return(storageState, nil)
+				entitySecond := mock_homeassistant.NewMockEntity(ctrl)
+				entitySecond.EXPECT().GetStateTopic(clientDto).Return(stateTopicNew)
+				entitySecond.EXPECT().GetState(clientDto).Return(storageState, nil)
+				return []Entity{entity, entitySecond}
+			},
+			clientList: func() clientList {
+				clientList := mock_homeassistant.NewMockclientList(ctrl)
+				clientList.EXPECT().GetClientList().Return(clients, nil)
+				return clientList
+			},
+			mqtt: func() mqtt {
+				mqtt := mock_homeassistant.NewMockmqtt(ctrl)
+				return mqtt
+			},
+			logger: func() logger {
+				logger := mock_homeassistant.NewMocklogger(ctrl)
+				logger.EXPECT().Info("Entity manager update", "clients", clients)
+				logger.EXPECT().Info("shutdown entitymanager")
+				return logger
+			},
+			clients: map[string]dto.Client{
+				mac: clientDto,
+			},
+			entityStates: map[string]map[string]string{
+				stateTopic:    {mac: storageState},
+				stateTopicNew: {mac: storageState},
+			},
+		},
+		{
+			name: "client renamed",
+			entities: func() []Entity {
+				entity := mock_homeassistant.NewMockEntity(ctrl)
+				entity.EXPECT().SendDiscoveryMessage(clientDtoRenamed).Return(nil)
+				entity.EXPECT().GetStateTopic(clientDto).Return(stateTopic)
+				entity.EXPECT().GetStateTopic(clientDtoRenamed).Return(stateTopic).Times(2)
+				entity.EXPECT().GetCommandTopic(clientDto).Return(commandTopic)
+				entity.EXPECT().GetCommandTopic(clientDtoRenamed).Return(commandTopic)
+				entity.EXPECT().GetState(clientDtoRenamed).Return(state, nil)
+				return []Entity{entity}
+			},
+			clientList: func() clientList {
+				clientList := mock_homeassistant.NewMockclientList(ctrl)
+				clientList.EXPECT().GetClientList().Return(clientsRenamed, nil)
+				return clientList
+			},
+			mqtt: func() mqtt {
+				mqtt := mock_homeassistant.NewMockmqtt(ctrl)
+				mqtt.EXPECT().SendMessage(stateTopic, state, false)
+				return mqtt
+			},
+			logger: func() logger {
+				logger := mock_homeassistant.NewMocklogger(ctrl)
+				logger.EXPECT().Info("Entity manager update", "clients", clientsRenamed)
+				logger.EXPECT().Info("Entity manager rename client", "client", clientDtoRenamed, "previousName", clientDto.Name)
+				logger.EXPECT().Info("shutdown entitymanager")
+				return logger
+			},
+			clients: map[string]dto.Client{
+				mac: clientDto,
+			},
+			entityStates: map[string]map[string]string{},
+		},
+		{
+			name: "client renamed with topics change",
+			entities: func() []Entity {
+				entity := mock_homeassistant.NewMockEntity(ctrl)
+				entity.EXPECT().GetStateTopic(clientDto).Return(stateTopic).Times(2)
+				entity.EXPECT().GetStateTopic(clientDtoRenamed).Return(stateTopicRenamed).Times(2)
+				entity.EXPECT().GetCommandTopic(clientDto).Return(commandTopic)
+				entity.EXPECT().GetCommandTopic(clientDtoRenamed).Return(commandTopicRenamed)
+				entity.EXPECT().SendDiscoveryMessage(clientDtoRenamed).Return(nil)
+				entity.EXPECT().GetState(clientDtoRenamed).Return(state, nil)
+				return []Entity{entity}
+			},
+			clientList: func() clientList {
+				clientList := mock_homeassistant.NewMockclientList(ctrl)
+				clientList.EXPECT().GetClientList().Return(clientsRenamed, nil)
+				return clientList
+			},
+			mqtt: func() mqtt {
+				mqtt := mock_homeassistant.NewMockmqtt(ctrl)
+				mqtt.EXPECT().Unsubscribe(commandTopic)
+				mqtt.EXPECT().SendMessage(stateTopic, "", true)
+				mqtt.EXPECT().Subscribe(commandTopicRenamed).Return(make(chan string))
+				mqtt.EXPECT().SendMessage(stateTopicRenamed, state, false)
+				return mqtt
+			},
+			logger: func() logger {
+				logger := mock_homeassistant.NewMocklogger(ctrl)
+				logger.EXPECT().Info("Entity manager update", "clients", clientsRenamed)
+				logger.EXPECT().Info("Entity manager rename client", "client", clientDtoRenamed, "previousName", clientDto.Name)
+				logger.EXPECT().Info("shutdown entitymanager")
+				return logger
+			},
+			clients: map[string]dto.Client{
+				mac: clientDto,
+			},
+			entityStates: map[string]map[string]string{},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			var d discovery = mock_homeassistant.NewMockdiscovery(ctrl)
+			if tt.discovery != nil {
+				d = tt.discovery()
+			}
+			if mock, ok := d.(*mock_homeassistant.Mockdiscovery); ok {
+				mock.EXPECT().RemoveStaleClients(gomock.Any()).MaxTimes(1)
+			}
+
+			mqtt := tt.mqtt()
+			if mock, ok := mqtt.(*mock_homeassistant.Mockmqtt); ok {
+				mock.EXPECT().IsConnected().Return(true).AnyTimes()
+			}
+
+			publisher := mock_homeassistant.NewMockstatePublisher(ctrl)
+			publisher.EXPECT().Publish(gomock.Any()).Return(nil).AnyTimes()
 
 			manager := NewEntityManager(
 				tt.entities(),
 				tt.clientList(),
-				tt.mqtt(),
+				mqtt,
+				d,
+				publisher,
 				100*time.Millisecond,
 				tt.logger(),
 			)
 
 			manager.clients = tt.clients
-			manager.entityStates = tt.entityStates
+			manager.states = clientStates(tt.entityStates)
 
 			var stopChan chan struct{}
 			go func() {
@@ -383,3 +560,212 @@ func TestEntityManager_Run(t *testing.T) {
 		})
 	}
 }
+
+func TestEntityManager_Resync(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		stateTopic = "stateTopic"
+		state      = "state"
+	)
+
+	clientDto := dto.Client{Mac: "mac", Name: "name"}
+	clients := []dto.Client{clientDto}
+
+	entity := mock_homeassistant.NewMockEntity(ctrl)
+	gomock.InOrder(
+		entity.EXPECT().SendDiscoveryMessage(clientDto).Return(nil),
+		entity.EXPECT().GetStateTopic(clientDto).Return(stateTopic),
+		entity.EXPECT().GetState(clientDto).Return(state, nil),
+	)
+
+	clientList := mock_homeassistant.NewMockclientList(ctrl)
+	clientList.EXPECT().GetClientList().Return(clients, nil)
+
+	mqtt := mock_homeassistant.NewMockmqtt(ctrl)
+	mqtt.EXPECT().SendMessage(stateTopic, state, false)
+
+	logger := mock_homeassistant.NewMocklogger(ctrl)
+	logger.EXPECT().Info("Entity manager update", "clients", clients)
+
+	publisher := mock_homeassistant.NewMockstatePublisher(ctrl)
+	publisher.EXPECT().Publish(clients).Return(nil)
+
+	discovery := mock_homeassistant.NewMockdiscovery(ctrl)
+	discovery.EXPECT().RemoveStaleClients(clients)
+
+	manager := NewEntityManager([]Entity{entity}, clientList, mqtt, discovery, publisher, time.Second, logger)
+	manager.clients = map[string]dto.Client{clientDto.Mac: clientDto}
+	manager.states = clientStates(map[string]map[string]string{stateTopic: {clientDto.Mac: state}})
+
+	manager.Resync()
+}
+
+type notifierEntity struct {
+	*mock_homeassistant.MockEntity
+	*mock_homeassistant.MockDiscoveryNotifier
+}
+
+func TestEntityManager_runDiscoveryNotifier(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	clientDto := dto.Client{Mac: "mac", Name: "name"}
+	changes := make(chan struct{})
+	sent := make(chan struct{})
+	stopped := make(chan struct{})
+
+	entity := notifierEntity{
+		MockEntity:            mock_homeassistant.NewMockEntity(ctrl),
+		MockDiscoveryNotifier: mock_homeassistant.NewMockDiscoveryNotifier(ctrl),
+	}
+	entity.MockDiscoveryNotifier.EXPECT().DiscoveryChanges().Return(changes)
+	entity.MockEntity.EXPECT().SendDiscoveryMessage(clientDto).DoAndReturn(func(dto.Client) error {
+		close(sent)
+		return nil
+	})
+
+	logger := mock_homeassistant.NewMocklogger(ctrl)
+	logger.EXPECT().Info("shutdown entitymanager").Do(func(string, ...any) {
+		close(stopped)
+	})
+
+	manager := NewEntityManager([]Entity{entity}, nil, nil, nil, nil, time.Hour, logger)
+	manager.clients = map[string]dto.Client{clientDto.Mac: clientDto}
+
+	done := manager.Run()
+	changes <- struct{}{}
+	<-sent
+	done <- struct{}{}
+	<-stopped
+}
+
+type retainedEntity struct {
+	*mock_homeassistant.MockEntity
+	*mock_homeassistant.MockRetainedEntity
+}
+
+func TestEntityManager_updateEntitiesState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		stateTopic = "stateTopic"
+		state      = "state"
+	)
+
+	clientDto := dto.Client{Mac: "mac", Name: "name"}
+
+	tests := []struct {
+		name     string
+		retained bool
+	}{
+		{
+			name:     "retained entity",
+			retained: true,
+		},
+		{
+			name:     "not retained entity",
+			retained: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entity := retainedEntity{
+				MockEntity:         mock_homeassistant.NewMockEntity(ctrl),
+				MockRetainedEntity: mock_homeassistant.NewMockRetainedEntity(ctrl),
+			}
+			entity.MockEntity.EXPECT().GetStateTopic(clientDto).Return(stateTopic)
+			entity.MockEntity.EXPECT().GetState(clientDto).Return(state, nil)
+			entity.MockRetainedEntity.EXPECT().Retained().Return(tt.retained)
+
+			mqtt := mock_homeassistant.NewMockmqtt(ctrl)
+			mqtt.EXPECT().SendMessage(stateTopic, state, tt.retained)
+
+			manager := NewEntityManager([]Entity{entity}, nil, mqtt, nil, nil, time.Second, nil)
+			manager.updateEntitiesState(clientDto)
+		})
+	}
+}
+
+func TestEntityManager_update_publishStates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	someErr := errors.New("some error")
+	now := time.Now()
+
+	clientDto := dto.Client{Mac: "mac", Name: "name", RxBytes: 3000, TxBytes: 1000}
+	clients := []dto.Client{clientDto}
+
+	tests := []struct {
+		name      string
+		publisher func() statePublisher
+		logger    func() logger
+	}{
+		{
+			name: "client states with traffic rates are published",
+			publisher: func() statePublisher {
+				published := clientDto
+				published.RxRate = 1000
+				published.TxRate = 500
+
+				publisher := mock_homeassistant.NewMockstatePublisher(ctrl)
+				publisher.EXPECT().Publish([]dto.Client{published}).Return(nil)
+				return publisher
+			},
+			logger: func() logger {
+				logger := mock_homeassistant.NewMocklogger(ctrl)
+				logger.EXPECT().Info("Entity manager update", "clients", clients)
+				return logger
+			},
+		},
+		{
+			name: "publish error",
+			publisher: func() statePublisher {
+				publisher := mock_homeassistant.NewMockstatePublisher(ctrl)
+				publisher.EXPECT().Publish(gomock.Any()).Return(someErr)
+				return publisher
+			},
+			logger: func() logger {
+				logger := mock_homeassistant.NewMocklogger(ctrl)
+				logger.EXPECT().Info("Entity manager update", "clients", clients)
+				logger.EXPECT().Error("Entity manager publish client states error", "error", someErr)
+				return logger
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientList := mock_homeassistant.NewMockclientList(ctrl)
+			clientList.EXPECT().GetClientList().Return(clients, nil)
+
+			discovery := mock_homeassistant.NewMockdiscovery(ctrl)
+			discovery.EXPECT().RemoveStaleClients(clients)
+
+			manager := NewEntityManager(nil, clientList, nil, discovery, tt.publisher(), time.Second, tt.logger())
+			manager.clients = map[string]dto.Client{clientDto.Mac: clientDto}
+			manager.now = func() time.Time { return now }
+			manager.trafficSamples = map[string]trafficSample{
+				clientDto.Mac: {rxBytes: 1000, txBytes: 0, time: now.Add(-2 * time.Second)},
+			}
+
+			manager.update()
+		})
+	}
+}
+
+// clientStates builds cache of sent states keyed by state topic and client mac.
+func clientStates(states map[string]map[string]string) *stateCache[clientState] {
+	cache := newStateCache[clientState]()
+	for topic, clients := range states {
+		for mac, state := range clients {
+			cache.states[clientState{topic: topic, mac: mac}] = state
+		}
+	}
+
+	return cache
+}
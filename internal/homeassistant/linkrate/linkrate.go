@@ -0,0 +1,85 @@
+package linkrate
+
+import (
+	"fmt"
+	"strconv"
+
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	"keeneticToMqtt/internal/homeassistant/topic"
+)
+
+//go:generate mockgen -source=linkrate.go -destination=../../../test/mocks/gomock/homeassistant/linkrate/linkrate.go
+
+const (
+	entityTypeName = "linkrate"
+	unit           = "Mbit/s"
+	deviceClass    = "data_rate"
+	stateClass     = "measurement"
+	precision      = 0
+)
+
+type (
+	discovery interface {
+		SendDiscoverySensor(stateTopic string, client dto.Client, entityType string, meta homeassistantdto.SensorMeta) error
+	}
+)
+
+// LinkRate struct for handle home assistant client wifi link rate entities.
+type LinkRate struct {
+	topics          *topic.Template
+	discoveryClient discovery
+}
+
+// NewLinkRate creates new LinkRate.
+func NewLinkRate(
+	topics *topic.Template,
+	discoveryClient discovery,
+) *LinkRate {
+	return &LinkRate{
+		topics:          topics,
+		discoveryClient: discoveryClient,
+	}
+}
+
+// SendDiscoveryMessage sends homeassistant discovery message.
+func (l *LinkRate) SendDiscoveryMessage(client dto.Client) error {
+	stateTopic := l.GetStateTopic(client)
+	meta := homeassistantdto.SensorMeta{
+		Unit: unit,
+		EntityMeta: homeassistantdto.EntityMeta{
+			DeviceClass:               deviceClass,
+			StateClass:                stateClass,
+			SuggestedDisplayPrecision: homeassistantdto.Precision(precision),
+			EntityCategory:            homeassistantdto.EntityCategoryDiagnostic,
+		},
+	}
+	if err := l.discoveryClient.SendDiscoverySensor(stateTopic, client, entityTypeName, meta); err != nil {
+		return fmt.Errorf("LinkRate SendDiscoveryMessage error: %w", err)
+	}
+
+	return nil
+}
+
+// GetState returns entity state. Wired clients have no state.
+func (l *LinkRate) GetState(client dto.Client) (string, error) {
+	if client.AP == "" {
+		return homeassistantdto.PayloadNone, nil
+	}
+	return strconv.Itoa(client.LinkRate), nil
+}
+
+// Consume consumes message.
+func (l *LinkRate) Consume(_ dto.Client, _ string) error {
+	return nil
+}
+
+// GetStateTopic returns state topic.
+func (l *LinkRate) GetStateTopic(client dto.Client) string {
+	return l.topics.StateTopic(client, entityTypeName)
+}
+
+// GetCommandTopic returns command topic.
+func (l *LinkRate) GetCommandTopic(_ dto.Client) string {
+	return ""
+}
@@ -0,0 +1,132 @@
+package linkrate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	"keeneticToMqtt/internal/homeassistant/topic"
+	mock_linkrate "keeneticToMqtt/test/mocks/gomock/homeassistant/linkrate"
+)
+
+func TestLinkRate_SendDiscoveryMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	meta := homeassistantdto.SensorMeta{
+		Unit: unit,
+		EntityMeta: homeassistantdto.EntityMeta{
+			DeviceClass:               deviceClass,
+			StateClass:                stateClass,
+			SuggestedDisplayPrecision: homeassistantdto.Precision(precision),
+			EntityCategory:            homeassistantdto.EntityCategoryDiagnostic,
+		},
+	}
+
+	const (
+		mac       = "mac"
+		name      = "name"
+		basetopic = "basetopic"
+	)
+	someErr := errors.New("some error")
+
+	client := dto.Client{Mac: mac, Name: name}
+
+	tests := []struct {
+		name        string
+		expectedErr error
+		discovery   func() discovery
+	}{
+		{
+			name: "success send discovery message",
+			discovery: func() discovery {
+				discovery := mock_linkrate.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendDiscoverySensor(
+						gomock.Eq("basetopic/mac_linkrate/state"),
+						gomock.Eq(client),
+						gomock.Eq("linkrate"),
+						gomock.Eq(meta),
+					).
+					Return(nil)
+
+				return discovery
+			},
+		},
+		{
+			name: "error while send discovery message",
+			discovery: func() discovery {
+				discovery := mock_linkrate.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendDiscoverySensor(
+						gomock.Eq("basetopic/mac_linkrate/state"),
+						gomock.Eq(client),
+						gomock.Eq("linkrate"),
+						gomock.Eq(meta),
+					).
+					Return(someErr)
+
+				return discovery
+			},
+			expectedErr: someErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			linkrate := NewLinkRate(topic.NewTemplate(basetopic, ""), tt.discovery())
+			err := linkrate.SendDiscoveryMessage(client)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestLinkRate_GetState(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		client   dto.Client
+	}{
+		{
+			name: "wifi client",
+			client: dto.Client{
+				AP:       "WifiMaster0/AccessPoint0",
+				LinkRate: 433,
+			},
+			expected: "433",
+		},
+		{
+			name:     "wired client",
+			client:   dto.Client{},
+			expected: homeassistantdto.PayloadNone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			linkrate := LinkRate{}
+			res, err := linkrate.GetState(tt.client)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, res)
+		})
+	}
+}
+
+func TestLinkRate_Consume(t *testing.T) {
+	linkrate := LinkRate{}
+
+	err := linkrate.Consume(dto.Client{}, "")
+	assert.Nil(t, err)
+}
+
+func TestLinkRate_GetCommandTopic(t *testing.T) {
+	linkrate := LinkRate{}
+	assert.Empty(t, linkrate.GetCommandTopic(dto.Client{}))
+}
@@ -0,0 +1,83 @@
+package mcs
+
+import (
+	"fmt"
+	"strconv"
+
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	"keeneticToMqtt/internal/homeassistant/topic"
+)
+
+//go:generate mockgen -source=mcs.go -destination=../../../test/mocks/gomock/homeassistant/mcs/mcs.go
+
+const (
+	entityTypeName = "mcs"
+	stateClass     = "measurement"
+	icon           = "mdi:signal"
+	precision      = 0
+)
+
+type (
+	discovery interface {
+		SendDiscoverySensor(stateTopic string, client dto.Client, entityType string, meta homeassistantdto.SensorMeta) error
+	}
+)
+
+// MCS struct for handle home assistant client wifi modulation and coding scheme index entities.
+type MCS struct {
+	topics          *topic.Template
+	discoveryClient discovery
+}
+
+// NewMCS creates new MCS.
+func NewMCS(
+	topics *topic.Template,
+	discoveryClient discovery,
+) *MCS {
+	return &MCS{
+		topics:          topics,
+		discoveryClient: discoveryClient,
+	}
+}
+
+// SendDiscoveryMessage sends homeassistant discovery message.
+func (m *MCS) SendDiscoveryMessage(client dto.Client) error {
+	stateTopic := m.GetStateTopic(client)
+	meta := homeassistantdto.SensorMeta{
+		EntityMeta: homeassistantdto.EntityMeta{
+			StateClass:                stateClass,
+			Icon:                      icon,
+			SuggestedDisplayPrecision: homeassistantdto.Precision(precision),
+			EntityCategory:            homeassistantdto.EntityCategoryDiagnostic,
+		},
+	}
+	if err := m.discoveryClient.SendDiscoverySensor(stateTopic, client, entityTypeName, meta); err != nil {
+		return fmt.Errorf("MCS SendDiscoveryMessage error: %w", err)
+	}
+
+	return nil
+}
+
+// GetState returns entity state. Wired clients have no state.
+func (m *MCS) GetState(client dto.Client) (string, error) {
+	if client.AP == "" {
+		return homeassistantdto.PayloadNone, nil
+	}
+	return strconv.Itoa(client.MCS), nil
+}
+
+// Consume consumes message.
+func (m *MCS) Consume(_ dto.Client, _ string) error {
+	return nil
+}
+
+// GetStateTopic returns state topic.
+func (m *MCS) GetStateTopic(client dto.Client) string {
+	return m.topics.StateTopic(client, entityTypeName)
+}
+
+// GetCommandTopic returns command topic.
+func (m *MCS) GetCommandTopic(_ dto.Client) string {
+	return ""
+}
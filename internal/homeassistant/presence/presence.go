@@ -0,0 +1,73 @@
+package presence
+
+import (
+	"fmt"
+
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	"keeneticToMqtt/internal/homeassistant/topic"
+)
+
+//go:generate mockgen -source=presence.go -destination=../../../test/mocks/gomock/homeassistant/presence/presence.go
+
+const (
+	entityTypeName = "presence"
+)
+
+type (
+	discovery interface {
+		SendDiscoveryDeviceTracker(stateTopic string, client dto.Client, entityType string, meta homeassistantdto.EntityMeta) error
+	}
+)
+
+// Presence struct for handle home assistant client device tracker entities.
+type Presence struct {
+	topics          *topic.Template
+	discoveryClient discovery
+}
+
+// NewPresence creates new Presence.
+func NewPresence(
+	topics *topic.Template,
+	discoveryClient discovery,
+) *Presence {
+	return &Presence{
+		topics:          topics,
+		discoveryClient: discoveryClient,
+	}
+}
+
+// SendDiscoveryMessage sends homeassistant discovery message.
+func (p *Presence) SendDiscoveryMessage(client dto.Client) error {
+	stateTopic := p.GetStateTopic(client)
+	meta := homeassistantdto.EntityMeta{}
+	if err := p.discoveryClient.SendDiscoveryDeviceTracker(stateTopic, client, entityTypeName, meta); err != nil {
+		return fmt.Errorf("Presence SendDiscoveryMessage error: %w", err)
+	}
+
+	return nil
+}
+
+// GetState returns entity state.
+func (p *Presence) GetState(client dto.Client) (string, error) {
+	msg := homeassistantdto.PayloadNotHome
+	if client.Online {
+		msg = homeassistantdto.PayloadHome
+	}
+	return msg, nil
+}
+
+// Consume consumes message.
+func (p *Presence) Consume(_ dto.Client, _ string) error {
+	return nil
+}
+
+// GetStateTopic returns state topic.
+func (p *Presence) GetStateTopic(client dto.Client) string {
+	return p.topics.StateTopic(client, entityTypeName)
+}
+
+// GetCommandTopic returns command topic.
+func (p *Presence) GetCommandTopic(_ dto.Client) string {
+	return ""
+}
@@ -0,0 +1,121 @@
+package presence
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	"keeneticToMqtt/internal/homeassistant/topic"
+	mock_presence "keeneticToMqtt/test/mocks/gomock/homeassistant/presence"
+)
+
+func TestPresence_SendDiscoveryMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	meta := homeassistantdto.EntityMeta{}
+
+	const (
+		mac       = "mac"
+		name      = "name"
+		basetopic = "basetopic"
+	)
+	someErr := errors.New("some error")
+
+	client := dto.Client{Mac: mac, Name: name}
+
+	tests := []struct {
+		name        string
+		expectedErr error
+		discovery   func() discovery
+	}{
+		{
+			name: "success send discovery message",
+			discovery: func() discovery {
+				discovery := mock_presence.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendDiscoveryDeviceTracker(
+						gomock.Eq("basetopic/mac_presence/state"),
+						gomock.Eq(client),
+						gomock.Eq("presence"),
+						gomock.Eq(meta),
+					).
+					Return(nil)
+
+				return discovery
+			},
+		},
+		{
+			name: "error while send discovery message",
+			discovery: func() discovery {
+				discovery := mock_presence.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendDiscoveryDeviceTracker(
+						gomock.Eq("basetopic/mac_presence/state"),
+						gomock.Eq(client),
+						gomock.Eq("presence"),
+						gomock.Eq(meta),
+					).
+					Return(someErr)
+
+				return discovery
+			},
+			expectedErr: someErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			presence := NewPresence(topic.NewTemplate(basetopic, ""), tt.discovery())
+			err := presence.SendDiscoveryMessage(client)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestPresence_GetState(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		client   dto.Client
+	}{
+		{
+			name:     "client is home",
+			client:   dto.Client{Online: true},
+			expected: homeassistantdto.PayloadHome,
+		},
+		{
+			name:     "client is not home",
+			client:   dto.Client{Online: false},
+			expected: homeassistantdto.PayloadNotHome,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			presence := Presence{}
+			res, err := presence.GetState(tt.client)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, res)
+		})
+	}
+}
+
+func TestPresence_Consume(t *testing.T) {
+	presence := Presence{}
+
+	err := presence.Consume(dto.Client{}, "")
+	assert.Nil(t, err)
+}
+
+func TestPresence_GetCommandTopic(t *testing.T) {
+	presence := Presence{}
+	assert.Empty(t, presence.GetCommandTopic(dto.Client{}))
+}
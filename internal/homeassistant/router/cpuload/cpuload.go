@@ -0,0 +1,81 @@
+package cpuload
+
+import (
+	"fmt"
+	"strconv"
+
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+)
+
+//go:generate mockgen -source=cpuload.go -destination=../../../../test/mocks/gomock/homeassistant/router/cpuload/cpuload.go
+
+const (
+	entityTypeName = "cpuload"
+	unit           = "%"
+	stateClass     = "measurement"
+	icon           = "mdi:cpu-64-bit"
+	precision      = 0
+)
+
+type (
+	discovery interface {
+		SendRouterDiscoverySensor(stateTopic, name string, router dto.Router, meta homeassistantdto.SensorMeta) error
+	}
+)
+
+// CPULoad struct for handle home assistant router cpu load entity.
+type CPULoad struct {
+	basetopic       string
+	discoveryClient discovery
+}
+
+// NewCPULoad creates new CPULoad.
+func NewCPULoad(
+	basetopic string,
+	discoveryClient discovery,
+) *CPULoad {
+	return &CPULoad{
+		basetopic:       basetopic,
+		discoveryClient: discoveryClient,
+	}
+}
+
+// SendDiscoveryMessage sends homeassistant discovery message.
+func (c *CPULoad) SendDiscoveryMessage(router dto.Router) error {
+	meta := homeassistantdto.SensorMeta{
+		Unit: unit,
+		EntityMeta: homeassistantdto.EntityMeta{
+			StateClass:                stateClass,
+			Icon:                      icon,
+			SuggestedDisplayPrecision: homeassistantdto.Precision(precision),
+			EntityCategory:            homeassistantdto.EntityCategoryDiagnostic,
+		},
+	}
+	if err := c.discoveryClient.SendRouterDiscoverySensor(c.getStateTopic(), "router_"+entityTypeName, router, meta); err != nil {
+		return fmt.Errorf("CPULoad SendDiscoveryMessage error: %w", err)
+	}
+
+	return nil
+}
+
+// GetStates returns entity state.
+func (c *CPULoad) GetStates(router dto.Router) (map[string]string, error) {
+	return map[string]string{
+		c.getStateTopic(): strconv.Itoa(router.CPULoad),
+	}, nil
+}
+
+// GetCommandTopics returns command topics.
+func (c *CPULoad) GetCommandTopics(_ dto.Router) []string {
+	return nil
+}
+
+// Consume consumes message.
+func (c *CPULoad) Consume(_ dto.Router, _, _ string) error {
+	return nil
+}
+
+func (c *CPULoad) getStateTopic() string {
+	return fmt.Sprintf("%s/router_%s/state", c.basetopic, entityTypeName)
+}
@@ -0,0 +1,106 @@
+package cpuload
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	mock_cpuload "keeneticToMqtt/test/mocks/gomock/homeassistant/router/cpuload"
+)
+
+func TestCPULoad_SendDiscoveryMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	meta := homeassistantdto.SensorMeta{
+		Unit: unit,
+		EntityMeta: homeassistantdto.EntityMeta{
+			StateClass:                stateClass,
+			Icon:                      icon,
+			SuggestedDisplayPrecision: homeassistantdto.Precision(precision),
+			EntityCategory:            homeassistantdto.EntityCategoryDiagnostic,
+		},
+	}
+
+	const (
+		basetopic = "basetopic"
+	)
+	someErr := errors.New("some error")
+
+	router := dto.Router{Model: "Giga"}
+
+	tests := []struct {
+		name        string
+		expectedErr error
+		discovery   func() discovery
+	}{
+		{
+			name: "success send discovery message",
+			discovery: func() discovery {
+				discovery := mock_cpuload.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendRouterDiscoverySensor(
+						gomock.Eq("basetopic/router_cpuload/state"),
+						gomock.Eq("router_cpuload"),
+						gomock.Eq(router),
+						gomock.Eq(meta),
+					).
+					Return(nil)
+
+				return discovery
+			},
+		},
+		{
+			name: "error while send discovery message",
+			discovery: func() discovery {
+				discovery := mock_cpuload.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendRouterDiscoverySensor(
+						gomock.Eq("basetopic/router_cpuload/state"),
+						gomock.Eq("router_cpuload"),
+						gomock.Eq(router),
+						gomock.Eq(meta),
+					).
+					Return(someErr)
+
+				return discovery
+			},
+			expectedErr: someErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpuload := NewCPULoad(basetopic, tt.discovery())
+			err := cpuload.SendDiscoveryMessage(router)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestCPULoad_GetStates(t *testing.T) {
+	cpuload := NewCPULoad("basetopic", nil)
+
+	res, err := cpuload.GetStates(dto.Router{CPULoad: 12})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"basetopic/router_cpuload/state": "12"}, res)
+}
+
+func TestCPULoad_Consume(t *testing.T) {
+	cpuload := CPULoad{}
+
+	err := cpuload.Consume(dto.Router{}, "", "")
+	assert.Nil(t, err)
+}
+
+func TestCPULoad_GetCommandTopics(t *testing.T) {
+	cpuload := CPULoad{}
+	assert.Empty(t, cpuload.GetCommandTopics(dto.Router{}))
+}
@@ -0,0 +1,74 @@
+package defaultgateway
+
+import (
+	"fmt"
+
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+)
+
+//go:generate mockgen -source=defaultgateway.go -destination=../../../../test/mocks/gomock/homeassistant/router/defaultgateway/defaultgateway.go
+
+const (
+	entityTypeName = "defaultgateway"
+	icon           = "mdi:router-network"
+)
+
+type (
+	discovery interface {
+		SendRouterDiscoverySensor(stateTopic, name string, router dto.Router, meta homeassistantdto.SensorMeta) error
+	}
+)
+
+// DefaultGateway struct for handle home assistant router default gateway interface entity.
+type DefaultGateway struct {
+	basetopic       string
+	discoveryClient discovery
+}
+
+// NewDefaultGateway creates new DefaultGateway.
+func NewDefaultGateway(
+	basetopic string,
+	discoveryClient discovery,
+) *DefaultGateway {
+	return &DefaultGateway{
+		basetopic:       basetopic,
+		discoveryClient: discoveryClient,
+	}
+}
+
+// SendDiscoveryMessage sends homeassistant discovery message.
+func (d *DefaultGateway) SendDiscoveryMessage(router dto.Router) error {
+	meta := homeassistantdto.SensorMeta{
+		EntityMeta: homeassistantdto.EntityMeta{
+			Icon:           icon,
+			EntityCategory: homeassistantdto.EntityCategoryDiagnostic,
+		},
+	}
+	if err := d.discoveryClient.SendRouterDiscoverySensor(d.getStateTopic(), "router_"+entityTypeName, router, meta); err != nil {
+		return fmt.Errorf("DefaultGateway SendDiscoveryMessage error: %w", err)
+	}
+
+	return nil
+}
+
+// GetStates returns entity state.
+func (d *DefaultGateway) GetStates(router dto.Router) (map[string]string, error) {
+	return map[string]string{
+		d.getStateTopic(): router.DefaultGateway,
+	}, nil
+}
+
+// GetCommandTopics returns command topics.
+func (d *DefaultGateway) GetCommandTopics(_ dto.Router) []string {
+	return nil
+}
+
+// Consume consumes message.
+func (d *DefaultGateway) Consume(_ dto.Router, _, _ string) error {
+	return nil
+}
+
+func (d *DefaultGateway) getStateTopic() string {
+	return fmt.Sprintf("%s/router_%s/state", d.basetopic, entityTypeName)
+}
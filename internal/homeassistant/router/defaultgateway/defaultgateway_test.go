@@ -0,0 +1,103 @@
+package defaultgateway
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	mock_defaultgateway "keeneticToMqtt/test/mocks/gomock/homeassistant/router/defaultgateway"
+)
+
+func TestDefaultGateway_SendDiscoveryMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	meta := homeassistantdto.SensorMeta{
+		EntityMeta: homeassistantdto.EntityMeta{
+			Icon:           icon,
+			EntityCategory: homeassistantdto.EntityCategoryDiagnostic,
+		},
+	}
+
+	const (
+		basetopic = "basetopic"
+	)
+	someErr := errors.New("some error")
+
+	router := dto.Router{Model: "Giga"}
+
+	tests := []struct {
+		name        string
+		expectedErr error
+		discovery   func() discovery
+	}{
+		{
+			name: "success send discovery message",
+			discovery: func() discovery {
+				discovery := mock_defaultgateway.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendRouterDiscoverySensor(
+						gomock.Eq("basetopic/router_defaultgateway/state"),
+						gomock.Eq("router_defaultgateway"),
+						gomock.Eq(router),
+						gomock.Eq(meta),
+					).
+					Return(nil)
+
+				return discovery
+			},
+		},
+		{
+			name: "error while send discovery message",
+			discovery: func() discovery {
+				discovery := mock_defaultgateway.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendRouterDiscoverySensor(
+						gomock.Eq("basetopic/router_defaultgateway/state"),
+						gomock.Eq("router_defaultgateway"),
+						gomock.Eq(router),
+						gomock.Eq(meta),
+					).
+					Return(someErr)
+
+				return discovery
+			},
+			expectedErr: someErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defaultgateway := NewDefaultGateway(basetopic, tt.discovery())
+			err := defaultgateway.SendDiscoveryMessage(router)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestDefaultGateway_GetStates(t *testing.T) {
+	defaultgateway := NewDefaultGateway("basetopic", nil)
+
+	res, err := defaultgateway.GetStates(dto.Router{DefaultGateway: "PPPoE0"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"basetopic/router_defaultgateway/state": "PPPoE0"}, res)
+}
+
+func TestDefaultGateway_Consume(t *testing.T) {
+	defaultgateway := DefaultGateway{}
+
+	err := defaultgateway.Consume(dto.Router{}, "", "")
+	assert.Nil(t, err)
+}
+
+func TestDefaultGateway_GetCommandTopics(t *testing.T) {
+	defaultgateway := DefaultGateway{}
+	assert.Empty(t, defaultgateway.GetCommandTopics(dto.Router{}))
+}
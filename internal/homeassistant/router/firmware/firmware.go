@@ -0,0 +1,74 @@
+package firmware
+
+import (
+	"fmt"
+
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+)
+
+//go:generate mockgen -source=firmware.go -destination=../../../../test/mocks/gomock/homeassistant/router/firmware/firmware.go
+
+const (
+	entityTypeName = "firmware"
+	icon           = "mdi:chip"
+)
+
+type (
+	discovery interface {
+		SendRouterDiscoverySensor(stateTopic, name string, router dto.Router, meta homeassistantdto.SensorMeta) error
+	}
+)
+
+// Firmware struct for handle home assistant router firmware version entity.
+type Firmware struct {
+	basetopic       string
+	discoveryClient discovery
+}
+
+// NewFirmware creates new Firmware.
+func NewFirmware(
+	basetopic string,
+	discoveryClient discovery,
+) *Firmware {
+	return &Firmware{
+		basetopic:       basetopic,
+		discoveryClient: discoveryClient,
+	}
+}
+
+// SendDiscoveryMessage sends homeassistant discovery message.
+func (f *Firmware) SendDiscoveryMessage(router dto.Router) error {
+	meta := homeassistantdto.SensorMeta{
+		EntityMeta: homeassistantdto.EntityMeta{
+			Icon:           icon,
+			EntityCategory: homeassistantdto.EntityCategoryDiagnostic,
+		},
+	}
+	if err := f.discoveryClient.SendRouterDiscoverySensor(f.getStateTopic(), "router_"+entityTypeName, router, meta); err != nil {
+		return fmt.Errorf("Firmware SendDiscoveryMessage error: %w", err)
+	}
+
+	return nil
+}
+
+// GetStates returns entity state.
+func (f *Firmware) GetStates(router dto.Router) (map[string]string, error) {
+	return map[string]string{
+		f.getStateTopic(): router.Firmware,
+	}, nil
+}
+
+// GetCommandTopics returns command topics.
+func (f *Firmware) GetCommandTopics(_ dto.Router) []string {
+	return nil
+}
+
+// Consume consumes message.
+func (f *Firmware) Consume(_ dto.Router, _, _ string) error {
+	return nil
+}
+
+func (f *Firmware) getStateTopic() string {
+	return fmt.Sprintf("%s/router_%s/state", f.basetopic, entityTypeName)
+}
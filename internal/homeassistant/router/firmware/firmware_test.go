@@ -0,0 +1,103 @@
+package firmware
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	mock_firmware "keeneticToMqtt/test/mocks/gomock/homeassistant/router/firmware"
+)
+
+func TestFirmware_SendDiscoveryMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	meta := homeassistantdto.SensorMeta{
+		EntityMeta: homeassistantdto.EntityMeta{
+			Icon:           icon,
+			EntityCategory: homeassistantdto.EntityCategoryDiagnostic,
+		},
+	}
+
+	const (
+		basetopic = "basetopic"
+	)
+	someErr := errors.New("some error")
+
+	router := dto.Router{Model: "Giga"}
+
+	tests := []struct {
+		name        string
+		expectedErr error
+		discovery   func() discovery
+	}{
+		{
+			name: "success send discovery message",
+			discovery: func() discovery {
+				discovery := mock_firmware.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendRouterDiscoverySensor(
+						gomock.Eq("basetopic/router_firmware/state"),
+						gomock.Eq("router_firmware"),
+						gomock.Eq(router),
+						gomock.Eq(meta),
+					).
+					Return(nil)
+
+				return discovery
+			},
+		},
+		{
+			name: "error while send discovery message",
+			discovery: func() discovery {
+				discovery := mock_firmware.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendRouterDiscoverySensor(
+						gomock.Eq("basetopic/router_firmware/state"),
+						gomock.Eq("router_firmware"),
+						gomock.Eq(router),
+						gomock.Eq(meta),
+					).
+					Return(someErr)
+
+				return discovery
+			},
+			expectedErr: someErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			firmware := NewFirmware(basetopic, tt.discovery())
+			err := firmware.SendDiscoveryMessage(router)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestFirmware_GetStates(t *testing.T) {
+	firmware := NewFirmware("basetopic", nil)
+
+	res, err := firmware.GetStates(dto.Router{Firmware: "4.1.7"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"basetopic/router_firmware/state": "4.1.7"}, res)
+}
+
+func TestFirmware_Consume(t *testing.T) {
+	firmware := Firmware{}
+
+	err := firmware.Consume(dto.Router{}, "", "")
+	assert.Nil(t, err)
+}
+
+func TestFirmware_GetCommandTopics(t *testing.T) {
+	firmware := Firmware{}
+	assert.Empty(t, firmware.GetCommandTopics(dto.Router{}))
+}
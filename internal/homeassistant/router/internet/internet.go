@@ -0,0 +1,78 @@
+package internet
+
+import (
+	"fmt"
+
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+)
+
+//go:generate mockgen -source=internet.go -destination=../../../../test/mocks/gomock/homeassistant/router/internet/internet.go
+
+const (
+	entityTypeName = "internet"
+	deviceClass    = "connectivity"
+	stateOn        = "ON"
+	stateOff       = "OFF"
+)
+
+type (
+	discovery interface {
+		SendRouterDiscoveryBinarySensor(stateTopic, name string, router dto.Router, meta homeassistantdto.EntityMeta) error
+	}
+)
+
+// Internet struct for handle home assistant router internet reachability entity.
+type Internet struct {
+	basetopic       string
+	discoveryClient discovery
+}
+
+// NewInternet creates new Internet.
+func NewInternet(
+	basetopic string,
+	discoveryClient discovery,
+) *Internet {
+	return &Internet{
+		basetopic:       basetopic,
+		discoveryClient: discoveryClient,
+	}
+}
+
+// SendDiscoveryMessage sends homeassistant discovery message.
+func (i *Internet) SendDiscoveryMessage(router dto.Router) error {
+	meta := homeassistantdto.EntityMeta{
+		DeviceClass: deviceClass,
+	}
+	if err := i.discoveryClient.SendRouterDiscoveryBinarySensor(i.getStateTopic(), "router_"+entityTypeName, router, meta); err != nil {
+		return fmt.Errorf("Internet SendDiscoveryMessage error: %w", err)
+	}
+
+	return nil
+}
+
+// GetStates returns entity state.
+func (i *Internet) GetStates(router dto.Router) (map[string]string, error) {
+	state := stateOff
+	if router.Internet {
+		state = stateOn
+	}
+
+	return map[string]string{
+		i.getStateTopic(): state,
+	}, nil
+}
+
+// GetCommandTopics returns command topics.
+func (i *Internet) GetCommandTopics(_ dto.Router) []string {
+	return nil
+}
+
+// Consume consumes message.
+func (i *Internet) Consume(_ dto.Router, _, _ string) error {
+	return nil
+}
+
+func (i *Internet) getStateTopic() string {
+	return fmt.Sprintf("%s/router_%s/state", i.basetopic, entityTypeName)
+}
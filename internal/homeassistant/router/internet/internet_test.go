@@ -0,0 +1,121 @@
+package internet
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	mock_internet "keeneticToMqtt/test/mocks/gomock/homeassistant/router/internet"
+)
+
+func TestInternet_SendDiscoveryMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	meta := homeassistantdto.EntityMeta{
+		DeviceClass: deviceClass,
+	}
+
+	const (
+		basetopic = "basetopic"
+	)
+	someErr := errors.New("some error")
+
+	router := dto.Router{Model: "Giga"}
+
+	tests := []struct {
+		name        string
+		expectedErr error
+		discovery   func() discovery
+	}{
+		{
+			name: "success send discovery message",
+			discovery: func() discovery {
+				discovery := mock_internet.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendRouterDiscoveryBinarySensor(
+						gomock.Eq("basetopic/router_internet/state"),
+						gomock.Eq("router_internet"),
+						gomock.Eq(router),
+						gomock.Eq(meta),
+					).
+					Return(nil)
+
+				return discovery
+			},
+		},
+		{
+			name: "error while send discovery message",
+			discovery: func() discovery {
+				discovery := mock_internet.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendRouterDiscoveryBinarySensor(
+						gomock.Eq("basetopic/router_internet/state"),
+						gomock.Eq("router_internet"),
+						gomock.Eq(router),
+						gomock.Eq(meta),
+					).
+					Return(someErr)
+
+				return discovery
+			},
+			expectedErr: someErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			internet := NewInternet(basetopic, tt.discovery())
+			err := internet.SendDiscoveryMessage(router)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestInternet_GetStates(t *testing.T) {
+	tests := []struct {
+		name     string
+		router   dto.Router
+		expected string
+	}{
+		{
+			name:     "internet is reachable",
+			router:   dto.Router{Internet: true},
+			expected: "ON",
+		},
+		{
+			name:     "internet is not reachable",
+			router:   dto.Router{},
+			expected: "OFF",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			internet := NewInternet("basetopic", nil)
+
+			res, err := internet.GetStates(tt.router)
+			assert.Nil(t, err)
+			assert.Equal(t, map[string]string{"basetopic/router_internet/state": tt.expected}, res)
+		})
+	}
+}
+
+func TestInternet_Consume(t *testing.T) {
+	internet := Internet{}
+
+	err := internet.Consume(dto.Router{}, "", "")
+	assert.Nil(t, err)
+}
+
+func TestInternet_GetCommandTopics(t *testing.T) {
+	internet := Internet{}
+	assert.Empty(t, internet.GetCommandTopics(dto.Router{}))
+}
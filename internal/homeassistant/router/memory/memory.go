@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"fmt"
+	"strconv"
+
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+)
+
+//go:generate mockgen -source=memory.go -destination=../../../../test/mocks/gomock/homeassistant/router/memory/memory.go
+
+const (
+	entityTypeName = "memory"
+	unit           = "%"
+	stateClass     = "measurement"
+	icon           = "mdi:memory"
+	precision      = 0
+)
+
+type (
+	discovery interface {
+		SendRouterDiscoverySensor(stateTopic, name string, router dto.Router, meta homeassistantdto.SensorMeta) error
+	}
+)
+
+// Memory struct for handle home assistant router memory usage entity.
+type Memory struct {
+	basetopic       string
+	discoveryClient discovery
+}
+
+// NewMemory creates new Memory.
+func NewMemory(
+	basetopic string,
+	discoveryClient discovery,
+) *Memory {
+	return &Memory{
+		basetopic:       basetopic,
+		discoveryClient: discoveryClient,
+	}
+}
+
+// SendDiscoveryMessage sends homeassistant discovery message.
+func (m *Memory) SendDiscoveryMessage(router dto.Router) error {
+	meta := homeassistantdto.SensorMeta{
+		Unit: unit,
+		EntityMeta: homeassistantdto.EntityMeta{
+			StateClass:                stateClass,
+			Icon:                      icon,
+			SuggestedDisplayPrecision: homeassistantdto.Precision(precision),
+			EntityCategory:            homeassistantdto.EntityCategoryDiagnostic,
+		},
+	}
+	if err := m.discoveryClient.SendRouterDiscoverySensor(m.getStateTopic(), "router_"+entityTypeName, router, meta); err != nil {
+		return fmt.Errorf("Memory SendDiscoveryMessage error: %w", err)
+	}
+
+	return nil
+}
+
+// GetStates returns entity state.
+func (m *Memory) GetStates(router dto.Router) (map[string]string, error) {
+	return map[string]string{
+		m.getStateTopic(): strconv.Itoa(router.MemoryUsage),
+	}, nil
+}
+
+// GetCommandTopics returns command topics.
+func (m *Memory) GetCommandTopics(_ dto.Router) []string {
+	return nil
+}
+
+// Consume consumes message.
+func (m *Memory) Consume(_ dto.Router, _, _ string) error {
+	return nil
+}
+
+func (m *Memory) getStateTopic() string {
+	return fmt.Sprintf("%s/router_%s/state", m.basetopic, entityTypeName)
+}
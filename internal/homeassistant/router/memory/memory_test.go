@@ -0,0 +1,106 @@
+package memory
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	mock_memory "keeneticToMqtt/test/mocks/gomock/homeassistant/router/memory"
+)
+
+func TestMemory_SendDiscoveryMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	meta := homeassistantdto.SensorMeta{
+		Unit: unit,
+		EntityMeta: homeassistantdto.EntityMeta{
+			StateClass:                stateClass,
+			Icon:                      icon,
+			SuggestedDisplayPrecision: homeassistantdto.Precision(precision),
+			EntityCategory:            homeassistantdto.EntityCategoryDiagnostic,
+		},
+	}
+
+	const (
+		basetopic = "basetopic"
+	)
+	someErr := errors.New("some error")
+
+	router := dto.Router{Model: "Giga"}
+
+	tests := []struct {
+		name        string
+		expectedErr error
+		discovery   func() discovery
+	}{
+		{
+			name: "success send discovery message",
+			discovery: func() discovery {
+				discovery := mock_memory.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendRouterDiscoverySensor(
+						gomock.Eq("basetopic/router_memory/state"),
+						gomock.Eq("router_memory"),
+						gomock.Eq(router),
+						gomock.Eq(meta),
+					).
+					Return(nil)
+
+				return discovery
+			},
+		},
+		{
+			name: "error while send discovery message",
+			discovery: func() discovery {
+				discovery := mock_memory.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendRouterDiscoverySensor(
+						gomock.Eq("basetopic/router_memory/state"),
+						gomock.Eq("router_memory"),
+						gomock.Eq(router),
+						gomock.Eq(meta),
+					).
+					Return(someErr)
+
+				return discovery
+			},
+			expectedErr: someErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memory := NewMemory(basetopic, tt.discovery())
+			err := memory.SendDiscoveryMessage(router)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestMemory_GetStates(t *testing.T) {
+	memory := NewMemory("basetopic", nil)
+
+	res, err := memory.GetStates(dto.Router{MemoryUsage: 50})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"basetopic/router_memory/state": "50"}, res)
+}
+
+func TestMemory_Consume(t *testing.T) {
+	memory := Memory{}
+
+	err := memory.Consume(dto.Router{}, "", "")
+	assert.Nil(t, err)
+}
+
+func TestMemory_GetCommandTopics(t *testing.T) {
+	memory := Memory{}
+	assert.Empty(t, memory.GetCommandTopics(dto.Router{}))
+}
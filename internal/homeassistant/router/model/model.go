@@ -0,0 +1,74 @@
+package model
+
+import (
+	"fmt"
+
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+)
+
+//go:generate mockgen -source=model.go -destination=../../../../test/mocks/gomock/homeassistant/router/model/model.go
+
+const (
+	entityTypeName = "model"
+	icon           = "mdi:router-wireless"
+)
+
+type (
+	discovery interface {
+		SendRouterDiscoverySensor(stateTopic, name string, router dto.Router, meta homeassistantdto.SensorMeta) error
+	}
+)
+
+// Model struct for handle home assistant router model entity.
+type Model struct {
+	basetopic       string
+	discoveryClient discovery
+}
+
+// NewModel creates new Model.
+func NewModel(
+	basetopic string,
+	discoveryClient discovery,
+) *Model {
+	return &Model{
+		basetopic:       basetopic,
+		discoveryClient: discoveryClient,
+	}
+}
+
+// SendDiscoveryMessage sends homeassistant discovery message.
+func (m *Model) SendDiscoveryMessage(router dto.Router) error {
+	meta := homeassistantdto.SensorMeta{
+		EntityMeta: homeassistantdto.EntityMeta{
+			Icon:           icon,
+			EntityCategory: homeassistantdto.EntityCategoryDiagnostic,
+		},
+	}
+	if err := m.discoveryClient.SendRouterDiscoverySensor(m.getStateTopic(), "router_"+entityTypeName, router, meta); err != nil {
+		return fmt.Errorf("Model SendDiscoveryMessage error: %w", err)
+	}
+
+	return nil
+}
+
+// GetStates returns entity state.
+func (m *Model) GetStates(router dto.Router) (map[string]string, error) {
+	return map[string]string{
+		m.getStateTopic(): router.Model,
+	}, nil
+}
+
+// GetCommandTopics returns command topics.
+func (m *Model) GetCommandTopics(_ dto.Router) []string {
+	return nil
+}
+
+// Consume consumes message.
+func (m *Model) Consume(_ dto.Router, _, _ string) error {
+	return nil
+}
+
+func (m *Model) getStateTopic() string {
+	return fmt.Sprintf("%s/router_%s/state", m.basetopic, entityTypeName)
+}
@@ -0,0 +1,103 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	mock_model "keeneticToMqtt/test/mocks/gomock/homeassistant/router/model"
+)
+
+func TestModel_SendDiscoveryMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	meta := homeassistantdto.SensorMeta{
+		EntityMeta: homeassistantdto.EntityMeta{
+			Icon:           icon,
+			EntityCategory: homeassistantdto.EntityCategoryDiagnostic,
+		},
+	}
+
+	const (
+		basetopic = "basetopic"
+	)
+	someErr := errors.New("some error")
+
+	router := dto.Router{Model: "Giga"}
+
+	tests := []struct {
+		name        string
+		expectedErr error
+		discovery   func() discovery
+	}{
+		{
+			name: "success send discovery message",
+			discovery: func() discovery {
+				discovery := mock_model.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendRouterDiscoverySensor(
+						gomock.Eq("basetopic/router_model/state"),
+						gomock.Eq("router_model"),
+						gomock.Eq(router),
+						gomock.Eq(meta),
+					).
+					Return(nil)
+
+				return discovery
+			},
+		},
+		{
+			name: "error while send discovery message",
+			discovery: func() discovery {
+				discovery := mock_model.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendRouterDiscoverySensor(
+						gomock.Eq("basetopic/router_model/state"),
+						gomock.Eq("router_model"),
+						gomock.Eq(router),
+						gomock.Eq(meta),
+					).
+					Return(someErr)
+
+				return discovery
+			},
+			expectedErr: someErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			modelEntity := NewModel(basetopic, tt.discovery())
+			err := modelEntity.SendDiscoveryMessage(router)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestModel_GetStates(t *testing.T) {
+	modelEntity := NewModel("basetopic", nil)
+
+	res, err := modelEntity.GetStates(dto.Router{Model: "Giga"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"basetopic/router_model/state": "Giga"}, res)
+}
+
+func TestModel_Consume(t *testing.T) {
+	modelEntity := Model{}
+
+	err := modelEntity.Consume(dto.Router{}, "", "")
+	assert.Nil(t, err)
+}
+
+func TestModel_GetCommandTopics(t *testing.T) {
+	modelEntity := Model{}
+	assert.Empty(t, modelEntity.GetCommandTopics(dto.Router{}))
+}
@@ -0,0 +1,79 @@
+package uptime
+
+import (
+	"fmt"
+	"strconv"
+
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+)
+
+//go:generate mockgen -source=uptime.go -destination=../../../../test/mocks/gomock/homeassistant/router/uptime/uptime.go
+
+const (
+	entityTypeName = "uptime"
+	unit           = "s"
+	deviceClass    = "duration"
+	precision      = 0
+)
+
+type (
+	discovery interface {
+		SendRouterDiscoverySensor(stateTopic, name string, router dto.Router, meta homeassistantdto.SensorMeta) error
+	}
+)
+
+// Uptime struct for handle home assistant router uptime entity.
+type Uptime struct {
+	basetopic       string
+	discoveryClient discovery
+}
+
+// NewUptime creates new Uptime.
+func NewUptime(
+	basetopic string,
+	discoveryClient discovery,
+) *Uptime {
+	return &Uptime{
+		basetopic:       basetopic,
+		discoveryClient: discoveryClient,
+	}
+}
+
+// SendDiscoveryMessage sends homeassistant discovery message.
+func (u *Uptime) SendDiscoveryMessage(router dto.Router) error {
+	meta := homeassistantdto.SensorMeta{
+		Unit: unit,
+		EntityMeta: homeassistantdto.EntityMeta{
+			DeviceClass:               deviceClass,
+			SuggestedDisplayPrecision: homeassistantdto.Precision(precision),
+			EntityCategory:            homeassistantdto.EntityCategoryDiagnostic,
+		},
+	}
+	if err := u.discoveryClient.SendRouterDiscoverySensor(u.getStateTopic(), "router_"+entityTypeName, router, meta); err != nil {
+		return fmt.Errorf("Uptime SendDiscoveryMessage error: %w", err)
+	}
+
+	return nil
+}
+
+// GetStates returns entity state.
+func (u *Uptime) GetStates(router dto.Router) (map[string]string, error) {
+	return map[string]string{
+		u.getStateTopic(): strconv.FormatInt(router.Uptime, 10),
+	}, nil
+}
+
+// GetCommandTopics returns command topics.
+func (u *Uptime) GetCommandTopics(_ dto.Router) []string {
+	return nil
+}
+
+// Consume consumes message.
+func (u *Uptime) Consume(_ dto.Router, _, _ string) error {
+	return nil
+}
+
+func (u *Uptime) getStateTopic() string {
+	return fmt.Sprintf("%s/router_%s/state", u.basetopic, entityTypeName)
+}
@@ -0,0 +1,105 @@
+package uptime
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	mock_uptime "keeneticToMqtt/test/mocks/gomock/homeassistant/router/uptime"
+)
+
+func TestUptime_SendDiscoveryMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	meta := homeassistantdto.SensorMeta{
+		Unit: unit,
+		EntityMeta: homeassistantdto.EntityMeta{
+			DeviceClass:               deviceClass,
+			SuggestedDisplayPrecision: homeassistantdto.Precision(precision),
+			EntityCategory:            homeassistantdto.EntityCategoryDiagnostic,
+		},
+	}
+
+	const (
+		basetopic = "basetopic"
+	)
+	someErr := errors.New("some error")
+
+	router := dto.Router{Model: "Giga"}
+
+	tests := []struct {
+		name        string
+		expectedErr error
+		discovery   func() discovery
+	}{
+		{
+			name: "success send discovery message",
+			discovery: func() discovery {
+				discovery := mock_uptime.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendRouterDiscoverySensor(
+						gomock.Eq("basetopic/router_uptime/state"),
+						gomock.Eq("router_uptime"),
+						gomock.Eq(router),
+						gomock.Eq(meta),
+					).
+					Return(nil)
+
+				return discovery
+			},
+		},
+		{
+			name: "error while send discovery message",
+			discovery: func() discovery {
+				discovery := mock_uptime.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendRouterDiscoverySensor(
+						gomock.Eq("basetopic/router_uptime/state"),
+						gomock.Eq("router_uptime"),
+						gomock.Eq(router),
+						gomock.Eq(meta),
+					).
+					Return(someErr)
+
+				return discovery
+			},
+			expectedErr: someErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uptime := NewUptime(basetopic, tt.discovery())
+			err := uptime.SendDiscoveryMessage(router)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestUptime_GetStates(t *testing.T) {
+	uptime := NewUptime("basetopic", nil)
+
+	res, err := uptime.GetStates(dto.Router{Uptime: 3600})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"basetopic/router_uptime/state": "3600"}, res)
+}
+
+func TestUptime_Consume(t *testing.T) {
+	uptime := Uptime{}
+
+	err := uptime.Consume(dto.Router{}, "", "")
+	assert.Nil(t, err)
+}
+
+func TestUptime_GetCommandTopics(t *testing.T) {
+	uptime := Uptime{}
+	assert.Empty(t, uptime.GetCommandTopics(dto.Router{}))
+}
@@ -0,0 +1,74 @@
+package wanip
+
+import (
+	"fmt"
+
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+)
+
+//go:generate mockgen -source=wanip.go -destination=../../../../test/mocks/gomock/homeassistant/router/wanip/wanip.go
+
+const (
+	entityTypeName = "wanip"
+	icon           = "mdi:ip-network"
+)
+
+type (
+	discovery interface {
+		SendRouterDiscoverySensor(stateTopic, name string, router dto.Router, meta homeassistantdto.SensorMeta) error
+	}
+)
+
+// WanIP struct for handle home assistant router wan ip address entity.
+type WanIP struct {
+	basetopic       string
+	discoveryClient discovery
+}
+
+// NewWanIP creates new WanIP.
+func NewWanIP(
+	basetopic string,
+	discoveryClient discovery,
+) *WanIP {
+	return &WanIP{
+		basetopic:       basetopic,
+		discoveryClient: discoveryClient,
+	}
+}
+
+// SendDiscoveryMessage sends homeassistant discovery message.
+func (w *WanIP) SendDiscoveryMessage(router dto.Router) error {
+	meta := homeassistantdto.SensorMeta{
+		EntityMeta: homeassistantdto.EntityMeta{
+			Icon:           icon,
+			EntityCategory: homeassistantdto.EntityCategoryDiagnostic,
+		},
+	}
+	if err := w.discoveryClient.SendRouterDiscoverySensor(w.getStateTopic(), "router_"+entityTypeName, router, meta); err != nil {
+		return fmt.Errorf("WanIP SendDiscoveryMessage error: %w", err)
+	}
+
+	return nil
+}
+
+// GetStates returns entity state.
+func (w *WanIP) GetStates(router dto.Router) (map[string]string, error) {
+	return map[string]string{
+		w.getStateTopic(): router.WanIP,
+	}, nil
+}
+
+// GetCommandTopics returns command topics.
+func (w *WanIP) GetCommandTopics(_ dto.Router) []string {
+	return nil
+}
+
+// Consume consumes message.
+func (w *WanIP) Consume(_ dto.Router, _, _ string) error {
+	return nil
+}
+
+func (w *WanIP) getStateTopic() string {
+	return fmt.Sprintf("%s/router_%s/state", w.basetopic, entityTypeName)
+}
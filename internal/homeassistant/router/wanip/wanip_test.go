@@ -0,0 +1,103 @@
+package wanip
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	mock_wanip "keeneticToMqtt/test/mocks/gomock/homeassistant/router/wanip"
+)
+
+func TestWanIP_SendDiscoveryMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	meta := homeassistantdto.SensorMeta{
+		EntityMeta: homeassistantdto.EntityMeta{
+			Icon:           icon,
+			EntityCategory: homeassistantdto.EntityCategoryDiagnostic,
+		},
+	}
+
+	const (
+		basetopic = "basetopic"
+	)
+	someErr := errors.New("some error")
+
+	router := dto.Router{Model: "Giga"}
+
+	tests := []struct {
+		name        string
+		expectedErr error
+		discovery   func() discovery
+	}{
+		{
+			name: "success send discovery message",
+			discovery: func() discovery {
+				discovery := mock_wanip.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendRouterDiscoverySensor(
+						gomock.Eq("basetopic/router_wanip/state"),
+						gomock.Eq("router_wanip"),
+						gomock.Eq(router),
+						gomock.Eq(meta),
+					).
+					Return(nil)
+
+				return discovery
+			},
+		},
+		{
+			name: "error while send discovery message",
+			discovery: func() discovery {
+				discovery := mock_wanip.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendRouterDiscoverySensor(
+						gomock.Eq("basetopic/router_wanip/state"),
+						gomock.Eq("router_wanip"),
+						gomock.Eq(router),
+						gomock.Eq(meta),
+					).
+					Return(someErr)
+
+				return discovery
+			},
+			expectedErr: someErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wanip := NewWanIP(basetopic, tt.discovery())
+			err := wanip.SendDiscoveryMessage(router)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestWanIP_GetStates(t *testing.T) {
+	wanip := NewWanIP("basetopic", nil)
+
+	res, err := wanip.GetStates(dto.Router{WanIP: "10.0.0.2"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"basetopic/router_wanip/state": "10.0.0.2"}, res)
+}
+
+func TestWanIP_Consume(t *testing.T) {
+	wanip := WanIP{}
+
+	err := wanip.Consume(dto.Router{}, "", "")
+	assert.Nil(t, err)
+}
+
+func TestWanIP_GetCommandTopics(t *testing.T) {
+	wanip := WanIP{}
+	assert.Empty(t, wanip.GetCommandTopics(dto.Router{}))
+}
@@ -0,0 +1,92 @@
+package wanrxrate
+
+import (
+	"fmt"
+	"strconv"
+
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	"keeneticToMqtt/internal/homeassistant/topic"
+)
+
+//go:generate mockgen -source=wanrxrate.go -destination=../../../../test/mocks/gomock/homeassistant/router/wanrxrate/wanrxrate.go
+
+const (
+	entityTypeName = "rxrate"
+	unit           = "B/s"
+	deviceClass    = "data_rate"
+	stateClass     = "measurement"
+	icon           = "mdi:download"
+	precision      = 0
+)
+
+type (
+	discovery interface {
+		SendRouterDiscoverySensor(stateTopic, name string, router dto.Router, meta homeassistantdto.SensorMeta) error
+	}
+)
+
+// WanRxRate struct for handle home assistant router wan rx traffic rate entities, one for each wan interface.
+type WanRxRate struct {
+	basetopic       string
+	discoveryClient discovery
+}
+
+// NewWanRxRate creates new WanRxRate.
+func NewWanRxRate(
+	basetopic string,
+	discoveryClient discovery,
+) *WanRxRate {
+	return &WanRxRate{
+		basetopic:       basetopic,
+		discoveryClient: discoveryClient,
+	}
+}
+
+// SendDiscoveryMessage sends homeassistant discovery messages.
+func (w *WanRxRate) SendDiscoveryMessage(router dto.Router) error {
+	meta := homeassistantdto.SensorMeta{
+		Unit: unit,
+		EntityMeta: homeassistantdto.EntityMeta{
+			DeviceClass:               deviceClass,
+			StateClass:                stateClass,
+			Icon:                      icon,
+			SuggestedDisplayPrecision: homeassistantdto.Precision(precision),
+		},
+	}
+	for _, wan := range router.Wans {
+		if err := w.discoveryClient.SendRouterDiscoverySensor(w.getStateTopic(wan), w.getName(wan), router, meta); err != nil {
+			return fmt.Errorf("WanRxRate SendDiscoveryMessage error: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetStates returns entity states.
+func (w *WanRxRate) GetStates(router dto.Router) (map[string]string, error) {
+	states := make(map[string]string, len(router.Wans))
+	for _, wan := range router.Wans {
+		states[w.getStateTopic(wan)] = strconv.FormatInt(wan.RxRate, 10)
+	}
+
+	return states, nil
+}
+
+// GetCommandTopics returns command topics.
+func (w *WanRxRate) GetCommandTopics(_ dto.Router) []string {
+	return nil
+}
+
+// Consume consumes message.
+func (w *WanRxRate) Consume(_ dto.Router, _, _ string) error {
+	return nil
+}
+
+func (w *WanRxRate) getName(wan dto.Wan) string {
+	return "router_" + topic.Level(wan.ID) + "_" + entityTypeName
+}
+
+func (w *WanRxRate) getStateTopic(wan dto.Wan) string {
+	return fmt.Sprintf("%s/%s/state", w.basetopic, w.getName(wan))
+}
@@ -0,0 +1,119 @@
+package wanrxrate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	mock_wanrxrate "keeneticToMqtt/test/mocks/gomock/homeassistant/router/wanrxrate"
+)
+
+func TestWanRxRate_SendDiscoveryMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		basetopic = "basetopic"
+	)
+	someErr := errors.New("some error")
+
+	router := dto.Router{Wans: []dto.Wan{{ID: "PPPoE0"}, {ID: "GigabitEthernet0/Vlan2"}}}
+	meta := homeassistantdto.SensorMeta{
+		Unit: unit,
+		EntityMeta: homeassistantdto.EntityMeta{
+			DeviceClass:               deviceClass,
+			StateClass:                stateClass,
+			Icon:                      icon,
+			SuggestedDisplayPrecision: homeassistantdto.Precision(precision),
+		},
+	}
+
+	tests := []struct {
+		name        string
+		expectedErr error
+		discovery   func() discovery
+	}{
+		{
+			name: "success send discovery messages",
+			discovery: func() discovery {
+				discovery := mock_wanrxrate.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendRouterDiscoverySensor(
+						gomock.Eq("basetopic/router_PPPoE0_rxrate/state"),
+						gomock.Eq("router_PPPoE0_rxrate"),
+						gomock.Eq(router),
+						gomock.Eq(meta),
+					).
+					Return(nil)
+				discovery.EXPECT().
+					SendRouterDiscoverySensor(
+						gomock.Eq("basetopic/router_GigabitEthernet0_Vlan2_rxrate/state"),
+						gomock.Eq("router_GigabitEthernet0_Vlan2_rxrate"),
+						gomock.Eq(router),
+						gomock.Eq(meta),
+					).
+					Return(nil)
+
+				return discovery
+			},
+		},
+		{
+			name: "error while send discovery message",
+			discovery: func() discovery {
+				discovery := mock_wanrxrate.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendRouterDiscoverySensor(
+						gomock.Eq("basetopic/router_PPPoE0_rxrate/state"),
+						gomock.Eq("router_PPPoE0_rxrate"),
+						gomock.Eq(router),
+						gomock.Eq(meta),
+					).
+					Return(someErr)
+
+				return discovery
+			},
+			expectedErr: someErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wanRxRate := NewWanRxRate(basetopic, tt.discovery())
+			err := wanRxRate.SendDiscoveryMessage(router)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestWanRxRate_GetStates(t *testing.T) {
+	wanRxRate := NewWanRxRate("basetopic", nil)
+
+	res, err := wanRxRate.GetStates(dto.Router{Wans: []dto.Wan{
+		{ID: "PPPoE0", RxRate: 100, TxRate: 200},
+		{ID: "GigabitEthernet0/Vlan2"},
+	}})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		"basetopic/router_PPPoE0_rxrate/state":                 "100",
+		"basetopic/router_GigabitEthernet0_Vlan2_rxrate/state": "0",
+	}, res)
+}
+
+func TestWanRxRate_Consume(t *testing.T) {
+	wanRxRate := WanRxRate{}
+
+	err := wanRxRate.Consume(dto.Router{}, "", "")
+	assert.Nil(t, err)
+}
+
+func TestWanRxRate_GetCommandTopics(t *testing.T) {
+	wanRxRate := WanRxRate{}
+	assert.Empty(t, wanRxRate.GetCommandTopics(dto.Router{}))
+}
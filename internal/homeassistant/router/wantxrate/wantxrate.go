@@ -0,0 +1,92 @@
+package wantxrate
+
+import (
+	"fmt"
+	"strconv"
+
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	"keeneticToMqtt/internal/homeassistant/topic"
+)
+
+//go:generate mockgen -source=wantxrate.go -destination=../../../../test/mocks/gomock/homeassistant/router/wantxrate/wantxrate.go
+
+const (
+	entityTypeName = "txrate"
+	unit           = "B/s"
+	deviceClass    = "data_rate"
+	stateClass     = "measurement"
+	icon           = "mdi:upload"
+	precision      = 0
+)
+
+type (
+	discovery interface {
+		SendRouterDiscoverySensor(stateTopic, name string, router dto.Router, meta homeassistantdto.SensorMeta) error
+	}
+)
+
+// WanTxRate struct for handle home assistant router wan tx traffic rate entities, one for each wan interface.
+type WanTxRate struct {
+	basetopic       string
+	discoveryClient discovery
+}
+
+// NewWanTxRate creates new WanTxRate.
+func NewWanTxRate(
+	basetopic string,
+	discoveryClient discovery,
+) *WanTxRate {
+	return &WanTxRate{
+		basetopic:       basetopic,
+		discoveryClient: discoveryClient,
+	}
+}
+
+// SendDiscoveryMessage sends homeassistant discovery messages.
+func (w *WanTxRate) SendDiscoveryMessage(router dto.Router) error {
+	meta := homeassistantdto.SensorMeta{
+		Unit: unit,
+		EntityMeta: homeassistantdto.EntityMeta{
+			DeviceClass:               deviceClass,
+			StateClass:                stateClass,
+			Icon:                      icon,
+			SuggestedDisplayPrecision: homeassistantdto.Precision(precision),
+		},
+	}
+	for _, wan := range router.Wans {
+		if err := w.discoveryClient.SendRouterDiscoverySensor(w.getStateTopic(wan), w.getName(wan), router, meta); err != nil {
+			return fmt.Errorf("WanTxRate SendDiscoveryMessage error: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetStates returns entity states.
+func (w *WanTxRate) GetStates(router dto.Router) (map[string]string, error) {
+	states := make(map[string]string, len(router.Wans))
+	for _, wan := range router.Wans {
+		states[w.getStateTopic(wan)] = strconv.FormatInt(wan.TxRate, 10)
+	}
+
+	return states, nil
+}
+
+// GetCommandTopics returns command topics.
+func (w *WanTxRate) GetCommandTopics(_ dto.Router) []string {
+	return nil
+}
+
+// Consume consumes message.
+func (w *WanTxRate) Consume(_ dto.Router, _, _ string) error {
+	return nil
+}
+
+func (w *WanTxRate) getName(wan dto.Wan) string {
+	return "router_" + topic.Level(wan.ID) + "_" + entityTypeName
+}
+
+func (w *WanTxRate) getStateTopic(wan dto.Wan) string {
+	return fmt.Sprintf("%s/%s/state", w.basetopic, w.getName(wan))
+}
@@ -0,0 +1,119 @@
+package wantxrate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	mock_wantxrate "keeneticToMqtt/test/mocks/gomock/homeassistant/router/wantxrate"
+)
+
+func TestWanTxRate_SendDiscoveryMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		basetopic = "basetopic"
+	)
+	someErr := errors.New("some error")
+
+	router := dto.Router{Wans: []dto.Wan{{ID: "PPPoE0"}, {ID: "GigabitEthernet0/Vlan2"}}}
+	meta := homeassistantdto.SensorMeta{
+		Unit: unit,
+		EntityMeta: homeassistantdto.EntityMeta{
+			DeviceClass:               deviceClass,
+			StateClass:                stateClass,
+			Icon:                      icon,
+			SuggestedDisplayPrecision: homeassistantdto.Precision(precision),
+		},
+	}
+
+	tests := []struct {
+		name        string
+		expectedErr error
+		discovery   func() discovery
+	}{
+		{
+			name: "success send discovery messages",
+			discovery: func() discovery {
+				discovery := mock_wantxrate.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendRouterDiscoverySensor(
+						gomock.Eq("basetopic/router_PPPoE0_txrate/state"),
+						gomock.Eq("router_PPPoE0_txrate"),
+						gomock.Eq(router),
+						gomock.Eq(meta),
+					).
+					Return(nil)
+				discovery.EXPECT().
+					SendRouterDiscoverySensor(
+						gomock.Eq("basetopic/router_GigabitEthernet0_Vlan2_txrate/state"),
+						gomock.Eq("router_GigabitEthernet0_Vlan2_txrate"),
+						gomock.Eq(router),
+						gomock.Eq(meta),
+					).
+					Return(nil)
+
+				return discovery
+			},
+		},
+		{
+			name: "error while send discovery message",
+			discovery: func() discovery {
+				discovery := mock_wantxrate.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendRouterDiscoverySensor(
+						gomock.Eq("basetopic/router_PPPoE0_txrate/state"),
+						gomock.Eq("router_PPPoE0_txrate"),
+						gomock.Eq(router),
+						gomock.Eq(meta),
+					).
+					Return(someErr)
+
+				return discovery
+			},
+			expectedErr: someErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wanTxRate := NewWanTxRate(basetopic, tt.discovery())
+			err := wanTxRate.SendDiscoveryMessage(router)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestWanTxRate_GetStates(t *testing.T) {
+	wanTxRate := NewWanTxRate("basetopic", nil)
+
+	res, err := wanTxRate.GetStates(dto.Router{Wans: []dto.Wan{
+		{ID: "PPPoE0", RxRate: 100, TxRate: 200},
+		{ID: "GigabitEthernet0/Vlan2"},
+	}})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		"basetopic/router_PPPoE0_txrate/state":                 "200",
+		"basetopic/router_GigabitEthernet0_Vlan2_txrate/state": "0",
+	}, res)
+}
+
+func TestWanTxRate_Consume(t *testing.T) {
+	wanTxRate := WanTxRate{}
+
+	err := wanTxRate.Consume(dto.Router{}, "", "")
+	assert.Nil(t, err)
+}
+
+func TestWanTxRate_GetCommandTopics(t *testing.T) {
+	wanTxRate := WanTxRate{}
+	assert.Empty(t, wanTxRate.GetCommandTopics(dto.Router{}))
+}
@@ -0,0 +1,114 @@
+package wifi
+
+import (
+	"fmt"
+
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	"keeneticToMqtt/internal/homeassistant/topic"
+)
+
+//go:generate mockgen -source=wifi.go -destination=../../../../test/mocks/gomock/homeassistant/router/wifi/wifi.go
+
+const (
+	entityTypeName = "wifi"
+	offPayload     = "OFF"
+	onPayload      = "ON"
+	icon           = "mdi:wifi"
+)
+
+type (
+	discovery interface {
+		SendRouterDiscoverySwitch(commandTopic, stateTopic, name string, router dto.Router, meta homeassistantdto.EntityMeta) error
+	}
+	interfaceUpdate interface {
+		SetUp(name string, up bool) error
+	}
+)
+
+// Wifi struct for handle home assistant router wifi access point switches, one for each access point.
+// Guest network is keenetic access point too, usually WifiMaster0/AccessPoint1.
+type Wifi struct {
+	basetopic       string
+	discoveryClient discovery
+	interfaceUpdate interfaceUpdate
+}
+
+// NewWifi creates new Wifi.
+func NewWifi(
+	basetopic string,
+	discoveryClient discovery,
+	interfaceUpdate interfaceUpdate,
+) *Wifi {
+	return &Wifi{
+		basetopic:       basetopic,
+		discoveryClient: discoveryClient,
+		interfaceUpdate: interfaceUpdate,
+	}
+}
+
+// SendDiscoveryMessage sends homeassistant discovery messages.
+func (w *Wifi) SendDiscoveryMessage(router dto.Router) error {
+	meta := homeassistantdto.EntityMeta{
+		Icon: icon,
+	}
+	for _, ap := range router.AccessPoints {
+		if err := w.discoveryClient.SendRouterDiscoverySwitch(w.getCommandTopic(ap), w.getStateTopic(ap), w.getName(ap), router, meta); err != nil {
+			return fmt.Errorf("Wifi SendDiscoveryMessage error: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetStates returns entity states.
+func (w *Wifi) GetStates(router dto.Router) (map[string]string, error) {
+	states := make(map[string]string, len(router.AccessPoints))
+	for _, ap := range router.AccessPoints {
+		state := offPayload
+		if ap.Up {
+			state = onPayload
+		}
+		states[w.getStateTopic(ap)] = state
+	}
+
+	return states, nil
+}
+
+// GetCommandTopics returns command topics.
+func (w *Wifi) GetCommandTopics(router dto.Router) []string {
+	topics := make([]string, 0, len(router.AccessPoints))
+	for _, ap := range router.AccessPoints {
+		topics = append(topics, w.getCommandTopic(ap))
+	}
+
+	return topics
+}
+
+// Consume consumes message.
+func (w *Wifi) Consume(router dto.Router, commandTopic, message string) error {
+	for _, ap := range router.AccessPoints {
+		if w.getCommandTopic(ap) != commandTopic {
+			continue
+		}
+		if err := w.interfaceUpdate.SetUp(ap.ID, message != offPayload); err != nil {
+			return fmt.Errorf("client error while setting wifi access point state: %w", err)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("Wifi Consume error: unknown access point for topic %s", commandTopic)
+}
+
+func (w *Wifi) getName(ap dto.AccessPoint) string {
+	return "router_" + entityTypeName + "_" + topic.Level(ap.ID)
+}
+
+func (w *Wifi) getStateTopic(ap dto.AccessPoint) string {
+	return fmt.Sprintf("%s/%s/state", w.basetopic, w.getName(ap))
+}
+
+func (w *Wifi) getCommandTopic(ap dto.AccessPoint) string {
+	return fmt.Sprintf("%s/%s/command", w.basetopic, w.getName(ap))
+}
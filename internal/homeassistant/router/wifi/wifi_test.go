@@ -0,0 +1,201 @@
+package wifi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	mock_wifi "keeneticToMqtt/test/mocks/gomock/homeassistant/router/wifi"
+)
+
+func TestWifi_SendDiscoveryMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	meta := homeassistantdto.EntityMeta{
+		Icon: icon,
+	}
+
+	const (
+		basetopic = "basetopic"
+	)
+	someErr := errors.New("some error")
+
+	router := dto.Router{AccessPoints: []dto.AccessPoint{
+		{ID: "WifiMaster0/AccessPoint0"},
+		{ID: "WifiMaster0/AccessPoint1"},
+	}}
+
+	tests := []struct {
+		name        string
+		expectedErr error
+		discovery   func() discovery
+	}{
+		{
+			name: "success send discovery messages",
+			discovery: func() discovery {
+				discovery := mock_wifi.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendRouterDiscoverySwitch(
+						gomock.Eq("basetopic/router_wifi_WifiMaster0_AccessPoint0/command"),
+						gomock.Eq("basetopic/router_wifi_WifiMaster0_AccessPoint0/state"),
+						gomock.Eq("router_wifi_WifiMaster0_AccessPoint0"),
+						gomock.Eq(router),
+						gomock.Eq(meta),
+					).
+					Return(nil)
+				discovery.EXPECT().
+					SendRouterDiscoverySwitch(
+						gomock.Eq("basetopic/router_wifi_WifiMaster0_AccessPoint1/command"),
+						gomock.Eq("basetopic/router_wifi_WifiMaster0_AccessPoint1/state"),
+						gomock.Eq("router_wifi_WifiMaster0_AccessPoint1"),
+						gomock.Eq(router),
+						gomock.Eq(meta),
+					).
+					Return(nil)
+
+				return discovery
+			},
+		},
+		{
+			name: "error while send discovery message",
+			discovery: func() discovery {
+				discovery := mock_wifi.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendRouterDiscoverySwitch(
+						gomock.Eq("basetopic/router_wifi_WifiMaster0_AccessPoint0/command"),
+						gomock.Eq("basetopic/router_wifi_WifiMaster0_AccessPoint0/state"),
+						gomock.Eq("router_wifi_WifiMaster0_AccessPoint0"),
+						gomock.Eq(router),
+						gomock.Eq(meta),
+					).
+					Return(someErr)
+
+				return discovery
+			},
+			expectedErr: someErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wifi := NewWifi(basetopic, tt.discovery(), nil)
+			err := wifi.SendDiscoveryMessage(router)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestWifi_GetStates(t *testing.T) {
+	wifi := NewWifi("basetopic", nil, nil)
+
+	res, err := wifi.GetStates(dto.Router{AccessPoints: []dto.AccessPoint{
+		{ID: "WifiMaster0/AccessPoint0", Up: true},
+		{ID: "WifiMaster0/AccessPoint1"},
+	}})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		"basetopic/router_wifi_WifiMaster0_AccessPoint0/state": "ON",
+		"basetopic/router_wifi_WifiMaster0_AccessPoint1/state": "OFF",
+	}, res)
+}
+
+func TestWifi_GetCommandTopics(t *testing.T) {
+	wifi := NewWifi("basetopic", nil, nil)
+
+	res := wifi.GetCommandTopics(dto.Router{AccessPoints: []dto.AccessPoint{
+		{ID: "WifiMaster0/AccessPoint0"},
+		{ID: "WifiMaster1/AccessPoint0"},
+	}})
+	assert.Equal(t, []string{
+		"basetopic/router_wifi_WifiMaster0_AccessPoint0/command",
+		"basetopic/router_wifi_WifiMaster1_AccessPoint0/command",
+	}, res)
+}
+
+func TestWifi_Consume(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		basetopic    = "basetopic"
+		commandTopic = "basetopic/router_wifi_WifiMaster0_AccessPoint1/command"
+	)
+	someErr := errors.New("some error")
+
+	router := dto.Router{AccessPoints: []dto.AccessPoint{
+		{ID: "WifiMaster0/AccessPoint0"},
+		{ID: "WifiMaster0/AccessPoint1"},
+	}}
+
+	tests := []struct {
+		name            string
+		commandTopic    string
+		message         string
+		interfaceUpdate func() interfaceUpdate
+		expectedErr     error
+		expectedErrStr  string
+	}{
+		{
+			name:         "turn on guest network",
+			commandTopic: commandTopic,
+			message:      "ON",
+			interfaceUpdate: func() interfaceUpdate {
+				interfaceUpdate := mock_wifi.NewMockinterfaceUpdate(ctrl)
+				interfaceUpdate.EXPECT().SetUp("WifiMaster0/AccessPoint1", true).Return(nil)
+				return interfaceUpdate
+			},
+		},
+		{
+			name:         "turn off guest network",
+			commandTopic: commandTopic,
+			message:      "OFF",
+			interfaceUpdate: func() interfaceUpdate {
+				interfaceUpdate := mock_wifi.NewMockinterfaceUpdate(ctrl)
+				interfaceUpdate.EXPECT().SetUp("WifiMaster0/AccessPoint1", false).Return(nil)
+				return interfaceUpdate
+			},
+		},
+		{
+			name:         "error from client",
+			commandTopic: commandTopic,
+			message:      "ON",
+			interfaceUpdate: func() interfaceUpdate {
+				interfaceUpdate := mock_wifi.NewMockinterfaceUpdate(ctrl)
+				interfaceUpdate.EXPECT().SetUp("WifiMaster0/AccessPoint1", true).Return(someErr)
+				return interfaceUpdate
+			},
+			expectedErr: someErr,
+		},
+		{
+			name:         "unknown access point",
+			commandTopic: "basetopic/router_wifi_WifiMaster1_AccessPoint0/command",
+			message:      "ON",
+			interfaceUpdate: func() interfaceUpdate {
+				return mock_wifi.NewMockinterfaceUpdate(ctrl)
+			},
+			expectedErrStr: "Wifi Consume error: unknown access point",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wifi := NewWifi(basetopic, nil, tt.interfaceUpdate())
+			err := wifi.Consume(router, tt.commandTopic, tt.message)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else if tt.expectedErrStr != "" {
+				assert.Regexp(t, tt.expectedErrStr+".*", err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
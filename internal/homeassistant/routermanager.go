@@ -0,0 +1,191 @@
+package homeassistant
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"keeneticToMqtt/internal/dto"
+)
+
+//go:generate mockgen -source=routermanager.go -destination=../../test/mocks/gomock/homeassistant/routermanager.go
+
+// RouterEntity home assistant entity of keenetic router device.
+// One RouterEntity can handle several home assistant entities (for example one for each wan interface),
+// so it returns states and command topics for all of them.
+type RouterEntity interface {
+	SendDiscoveryMessage(router dto.Router) error
+	GetStates(router dto.Router) (map[string]string, error)
+	GetCommandTopics(router dto.Router) []string
+	Consume(router dto.Router, commandTopic, message string) error
+}
+
+type routerInfo interface {
+	GetRouterInfo() (dto.Router, error)
+}
+
+// RouterManager entity manager for keenetic router entities in home assistant.
+type RouterManager struct {
+	entities        []RouterEntity
+	routerInfo      routerInfo
+	mqtt            mqtt
+	pollingInterval time.Duration
+	logger          logger
+
+	router        dto.Router
+	discoveryKeys map[int]string
+	subscriptions map[string]bool
+	states        *stateCache[string]
+	mutex         sync.Mutex
+
+	trafficSamples map[string]trafficSample
+	now            func() time.Time
+}
+
+// NewRouterManager creates new RouterManager.
+func NewRouterManager(
+	entities []RouterEntity,
+	routerInfo routerInfo,
+	mqtt mqtt,
+	pollingInterval time.Duration,
+	logger logger,
+) *RouterManager {
+	return &RouterManager{
+		entities:        entities,
+		routerInfo:      routerInfo,
+		mqtt:            mqtt,
+		pollingInterval: pollingInterval,
+		logger:          logger,
+		discoveryKeys:   make(map[int]string),
+		subscriptions:   make(map[string]bool),
+		states:          newStateCache[string](),
+		trafficSamples:  make(map[string]trafficSample),
+		now:             time.Now,
+	}
+}
+
+// Run router entity updates and command consumer.
+func (m *RouterManager) Run() chan struct{} {
+	return runUpdates(m.pollingInterval, m.mqtt, m.update, func() {
+		m.logger.Info("shutdown routermanager")
+	})
+}
+
+func (m *RouterManager) update() {
+	router, err := m.routerInfo.GetRouterInfo()
+	if err != nil {
+		m.logger.Error("Router manager get router info error", "error", err)
+		return
+	}
+	m.logger.Info("Router manager update", "router", router)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	router = m.fillWanRates(router)
+	m.router = router
+	for i, entity := range m.entities {
+		states, err := entity.GetStates(router)
+		if err != nil {
+			m.logger.Error("Router manager get state error",
+				"entity", entity,
+				"error", err,
+			)
+			continue
+		}
+
+		m.sendDiscovery(i, entity, router, states)
+		m.subscribe(entity, router)
+		m.updateStates(states)
+	}
+}
+
+// Resync resends discovery messages and all states, for example after home assistant restart.
+func (m *RouterManager) Resync() {
+	m.mutex.Lock()
+	m.discoveryKeys = make(map[int]string)
+	m.mutex.Unlock()
+	m.states.reset()
+
+	m.update()
+}
+
+// sendDiscovery sends discovery messages on first update
+// and every time router info or set of entity state topics changes.
+func (m *RouterManager) sendDiscovery(i int, entity RouterEntity, router dto.Router, states map[string]string) {
+	topics := make([]string, 0, len(states))
+	for topic := range states {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	key := router.Manufacturer + router.Model + router.Firmware + strings.Join(topics, ",")
+
+	if m.discoveryKeys[i] == key {
+		return
+	}
+
+	if err := entity.SendDiscoveryMessage(router); err != nil {
+		m.logger.Error("Router manager update error while sending discovery message",
+			"error", err,
+			"entity", entity,
+		)
+		return
+	}
+	m.discoveryKeys[i] = key
+}
+
+func (m *RouterManager) subscribe(entity RouterEntity, router dto.Router) {
+	for _, commandTopic := range entity.GetCommandTopics(router) {
+		if m.subscriptions[commandTopic] {
+			continue
+		}
+		m.subscriptions[commandTopic] = true
+		ch := m.mqtt.Subscribe(commandTopic)
+		go m.runEntityConsumer(entity, commandTopic, ch)
+	}
+}
+
+// updateStates sends mqtt messages with updates to state topic only if state changes.
+func (m *RouterManager) updateStates(states map[string]string) {
+	for topic, state := range states {
+		if !m.states.changed(topic, state) {
+			continue
+		}
+		m.mqtt.SendMessage(topic, state, false)
+	}
+}
+
+// forgetStates forgets sent states of all home assistant entities of router entity.
+func (m *RouterManager) forgetStates(e RouterEntity, router dto.Router) {
+	states, err := e.GetStates(router)
+	if err != nil {
+		return
+	}
+
+	for topic := range states {
+		m.states.forget(topic)
+	}
+}
+
+func (m *RouterManager) runEntityConsumer(e RouterEntity, commandTopic string, ch chan string) {
+	for {
+		message := <-ch
+		m.mutex.Lock()
+		router := m.router
+		m.mutex.Unlock()
+
+		err := e.Consume(router, commandTopic, message)
+		if err != nil {
+			m.logger.Error("error while router entity consume",
+				"topic", commandTopic,
+				"message", message,
+				"entity", e,
+				"error", err,
+			)
+		}
+		finishCommand(m.mqtt, commandTopic, message, err, func() {
+			m.forgetStates(e, router)
+		}, m.update)
+	}
+}
@@ -0,0 +1,363 @@
+package homeassistant
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"keeneticToMqtt/internal/dto"
+	mock_homeassistant "keeneticToMqtt/test/mocks/gomock/homeassistant"
+)
+
+func TestRouterManager_update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		stateTopic   = "stateTopic"
+		commandTopic = "commandTopic"
+		state        = "state"
+		storageState = "storageState"
+	)
+
+	router := dto.Router{Model: "Giga", Firmware: "4.1.7"}
+	discoveryKey := "Giga4.1.7stateTopic"
+	someErr := errors.New("some error")
+
+	tests := []struct {
+		name                  string
+		entities              func() []RouterEntity
+		routerInfo            func() routerInfo
+		mqtt                  func() mqtt
+		logger                func() logger
+		discoveryKeys         map[int]string
+		subscriptions         map[string]bool
+		states                map[string]string
+		expectedDiscoveryKeys map[int]string
+		expectedStates        map[string]string
+	}{
+		{
+			name: "first update",
+			entities: func() []RouterEntity {
+				entity := mock_homeassistant.NewMockRouterEntity(ctrl)
+				entity.EXPECT().GetStates(router).Return(map[string]string{stateTopic: state}, nil)
+				entity.EXPECT().SendDiscoveryMessage(router).Return(nil)
+				entity.EXPECT().GetCommandTopics(router).Return(nil)
+				return []RouterEntity{entity}
+			},
+			routerInfo: func() routerInfo {
+				routerInfo := mock_homeassistant.NewMockrouterInfo(ctrl)
+				routerInfo.EXPECT().GetRouterInfo().Return(router, nil)
+				return routerInfo
+			},
+			mqtt: func() mqtt {
+				mqtt := mock_homeassistant.NewMockmqtt(ctrl)
+				mqtt.EXPECT().SendMessage(stateTopic, state, false)
+				return mqtt
+			},
+			logger: func() logger {
+				logger := mock_homeassistant.NewMocklogger(ctrl)
+				logger.EXPECT().Info("Router manager update", "router", router)
+				return logger
+			},
+			discoveryKeys:         map[int]string{},
+			subscriptions:         map[string]bool{},
+			states:                map[string]string{},
+			expectedDiscoveryKeys: map[int]string{0: discoveryKey},
+			expectedStates:        map[string]string{stateTopic: state},
+		},
+		{
+			name: "discovery already sent and state is same as in storage",
+			entities: func() []RouterEntity {
+				entity := mock_homeassistant.NewMockRouterEntity(ctrl)
+				entity.EXPECT().GetStates(router).Return(map[string]string{stateTopic: storageState}, nil)
+				entity.EXPECT().GetCommandTopics(router).Return([]string{commandTopic})
+				return []RouterEntity{entity}
+			},
+			routerInfo: func() routerInfo {
+				routerInfo := mock_homeassistant.NewMockrouterInfo(ctrl)
+				routerInfo.EXPECT().GetRouterInfo().Return(router, nil)
+				return routerInfo
+			},
+			mqtt: func() mqtt {
+				return mock_homeassistant.NewMockmqtt(ctrl)
+			},
+			logger: func() logger {
+				logger := mock_homeassistant.NewMocklogger(ctrl)
+				logger.EXPECT().Info("Router manager update", "router", router)
+				return logger
+			},
+			discoveryKeys:         map[int]string{0: discoveryKey},
+			subscriptions:         map[string]bool{commandTopic: true},
+			states:                map[string]string{stateTopic: storageState},
+			expectedDiscoveryKeys: map[int]string{0: discoveryKey},
+			expectedStates:        map[string]string{stateTopic: storageState},
+		},
+		{
+			name: "subscribe to new command topic",
+			entities: func() []RouterEntity {
+				entity := mock_homeassistant.NewMockRouterEntity(ctrl)
+				entity.EXPECT().GetStates(router).Return(map[string]string{stateTopic: state}, nil)
+				entity.EXPECT().GetCommandTopics(router).Return([]string{commandTopic})
+				return []RouterEntity{entity}
+			},
+			routerInfo: func() routerInfo {
+				routerInfo := mock_homeassistant.NewMockrouterInfo(ctrl)
+				routerInfo.EXPECT().GetRouterInfo().Return(router, nil)
+				return routerInfo
+			},
+			mqtt: func() mqtt {
+				mqtt := mock_homeassistant.NewMockmqtt(ctrl)
+				mqtt.EXPECT().Subscribe(commandTopic).Return(make(chan string))
+				mqtt.EXPECT().SendMessage(stateTopic, state, false)
+				return mqtt
+			},
+			logger: func() logger {
+				logger := mock_homeassistant.NewMocklogger(ctrl)
+				logger.EXPECT().Info("Router manager update", "router", router)
+				return logger
+			},
+			discoveryKeys:         map[int]string{0: discoveryKey},
+			subscriptions:         map[string]bool{},
+			states:                map[string]string{stateTopic: storageState},
+			expectedDiscoveryKeys: map[int]string{0: discoveryKey},
+			expectedStates:        map[string]string{stateTopic: state},
+		},
+		{
+			name: "error while sending discovery",
+			entities: func() []RouterEntity {
+				entity := mock_homeassistant.NewMockRouterEntity(ctrl)
+				entity.EXPECT().GetStates(router).Return(map[string]string{stateTopic: state}, nil)
+				entity.EXPECT().SendDiscoveryMessage(router).Return(someErr)
+				entity.EXPECT().GetCommandTopics(router).Return(nil)
+				return []RouterEntity{entity}
+			},
+			routerInfo: func() routerInfo {
+				routerInfo := mock_homeassistant.NewMockrouterInfo(ctrl)
+				routerInfo.EXPECT().GetRouterInfo().Return(router, nil)
+				return routerInfo
+			},
+			mqtt: func() mqtt {
+				mqtt := mock_homeassistant.NewMockmqtt(ctrl)
+				mqtt.EXPECT().SendMessage(stateTopic, state, false)
+				return mqtt
+			},
+			logger: func() logger {
+				logger := mock_homeassistant.NewMocklogger(ctrl)
+				logger.EXPECT().Info("Router manager update", "router", router)
+				logger.EXPECT().Error("Router manager update error while sending discovery message", "error", someErr, "entity", gomock.Any())
+				return logger
+			},
+			discoveryKeys:         map[int]string{},
+			subscriptions:         map[string]bool{},
+			states:                map[string]string{},
+			expectedDiscoveryKeys: map[int]string{},
+			expectedStates:        map[string]string{stateTopic: state},
+		},
+		{
+			name: "error while getting states",
+			entities: func() []RouterEntity {
+				entity := mock_homeassistant.NewMockRouterEntity(ctrl)
+				entity.EXPECT().GetStates(router).Return(nil, someErr)
+				return []RouterEntity{entity}
+			},
+			routerInfo: func() routerInfo {
+				routerInfo := mock_homeassistant.NewMockrouterInfo(ctrl)
+				routerInfo.EXPECT().GetRouterInfo().Return(router, nil)
+				return routerInfo
+			},
+			mqtt: func() mqtt {
+				return mock_homeassistant.NewMockmqtt(ctrl)
+			},
+			logger: func() logger {
+				logger := mock_homeassistant.NewMocklogger(ctrl)
+				logger.EXPECT().Info("Router manager update", "router", router)
+				logger.EXPECT().Error("Router manager get state error", "entity", gomock.Any(), "error", someErr)
+				return logger
+			},
+			discoveryKeys:         map[int]string{},
+			subscriptions:         map[string]bool{},
+			states:                map[string]string{},
+			expectedDiscoveryKeys: map[int]string{},
+			expectedStates:        map[string]string{},
+		},
+		{
+			name: "error while getting router info",
+			entities: func() []RouterEntity {
+				return []RouterEntity{mock_homeassistant.NewMockRouterEntity(ctrl)}
+			},
+			routerInfo: func() routerInfo {
+				routerInfo := mock_homeassistant.NewMockrouterInfo(ctrl)
+				routerInfo.EXPECT().GetRouterInfo().Return(dto.Router{}, someErr)
+				return routerInfo
+			},
+			mqtt: func() mqtt {
+				return mock_homeassistant.NewMockmqtt(ctrl)
+			},
+			logger: func() logger {
+				logger := mock_homeassistant.NewMocklogger(ctrl)
+				logger.EXPECT().Error("Router manager get router info error", "error", someErr)
+				return logger
+			},
+			discoveryKeys:         map[int]string{},
+			subscriptions:         map[string]bool{},
+			states:                map[string]string{},
+			expectedDiscoveryKeys: map[int]string{},
+			expectedStates:        map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := NewRouterManager(
+				tt.entities(),
+				tt.routerInfo(),
+				tt.mqtt(),
+				time.Second,
+				tt.logger(),
+			)
+			manager.discoveryKeys = tt.discoveryKeys
+			manager.subscriptions = tt.subscriptions
+			manager.states.states = tt.states
+
+			manager.update()
+
+			assert.Equal(t, tt.expectedDiscoveryKeys, manager.discoveryKeys)
+			assert.Equal(t, tt.expectedStates, manager.states.states)
+		})
+	}
+}
+
+func TestRouterManager_Resync(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		stateTopic = "stateTopic"
+		state      = "state"
+	)
+
+	router := dto.Router{Model: "Giga", Firmware: "4.1.7"}
+	discoveryKey := "Giga4.1.7stateTopic"
+
+	entity := mock_homeassistant.NewMockRouterEntity(ctrl)
+	entity.EXPECT().GetStates(router).Return(map[string]string{stateTopic: state}, nil)
+	entity.EXPECT().SendDiscoveryMessage(router).Return(nil)
+	entity.EXPECT().GetCommandTopics(router).Return(nil)
+
+	routerInfo := mock_homeassistant.NewMockrouterInfo(ctrl)
+	routerInfo.EXPECT().GetRouterInfo().Return(router, nil)
+
+	mqtt := mock_homeassistant.NewMockmqtt(ctrl)
+	mqtt.EXPECT().SendMessage(stateTopic, state, false)
+
+	logger := mock_homeassistant.NewMocklogger(ctrl)
+	logger.EXPECT().Info("Router manager update", "router", router)
+
+	manager := NewRouterManager([]RouterEntity{entity}, routerInfo, mqtt, time.Second, logger)
+	manager.discoveryKeys = map[int]string{0: discoveryKey}
+	manager.states.states = map[string]string{stateTopic: state}
+
+	manager.Resync()
+
+	assert.Equal(t, map[int]string{0: discoveryKey}, manager.discoveryKeys)
+	assert.Equal(t, map[string]string{stateTopic: state}, manager.states.states)
+}
+
+func TestRouterManager_Consume(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		commandTopic = "commandTopic"
+		command      = "command"
+		stateTopic   = "stateTopic"
+		state        = "ON"
+	)
+
+	router := dto.Router{Model: "Giga"}
+	someErr := errors.New("some error")
+
+	ch := make(chan string)
+	consumed := make(chan struct{})
+
+	entity := mock_homeassistant.NewMockRouterEntity(ctrl)
+	entity.EXPECT().Consume(router, commandTopic, command).Return(someErr)
+	entity.EXPECT().GetStates(router).Return(map[string]string{stateTopic: state}, nil)
+
+	mqtt := mock_homeassistant.NewMockmqtt(ctrl)
+	mqtt.EXPECT().SendMessage(ResultTopic(commandTopic), `{"success":false,"command":"command","code":"error","error":"some error"}`, false)
+
+	routerInfo := mock_homeassistant.NewMockrouterInfo(ctrl)
+	routerInfo.EXPECT().GetRouterInfo().Return(dto.Router{}, someErr)
+
+	logger := mock_homeassistant.NewMocklogger(ctrl)
+	logger.EXPECT().Error("error while router entity consume", "topic", commandTopic, "message", command, "entity", gomock.Any(), "error", someErr)
+	logger.EXPECT().Error("Router manager get router info error", "error", someErr).Do(func(_ string, _ ...any) {
+		close(consumed)
+	})
+
+	manager := NewRouterManager(nil, routerInfo, mqtt, time.Second, logger)
+	manager.router = router
+	manager.states.states = map[string]string{stateTopic: state}
+
+	go manager.runEntityConsumer(entity, commandTopic, ch)
+	ch <- command
+	<-consumed
+
+	// rejected command state is forgotten to resend actual state
+	manager.mutex.Lock()
+	assert.Empty(t, manager.states.states)
+	manager.mutex.Unlock()
+}
+
+func TestRouterManager_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	routerInfo := mock_homeassistant.NewMockrouterInfo(ctrl)
+	routerInfo.EXPECT().GetRouterInfo().Return(dto.Router{}, nil).MinTimes(1)
+
+	stopped := make(chan struct{})
+	logger := mock_homeassistant.NewMocklogger(ctrl)
+	logger.EXPECT().Info("Router manager update", "router", dto.Router{}).MinTimes(1)
+	logger.EXPECT().Info("shutdown routermanager").Do(func(_ string, _ ...any) {
+		close(stopped)
+	})
+
+	mqtt := mock_homeassistant.NewMockmqtt(ctrl)
+	mqtt.EXPECT().IsConnected().Return(true).MinTimes(1)
+
+	manager := NewRouterManager(nil, routerInfo, mqtt, 10*time.Millisecond, logger)
+	done := manager.Run()
+
+	ticker := time.NewTicker(15 * time.Millisecond)
+	<-ticker.C
+	done <- struct{}{}
+	<-stopped
+}
+
+func TestRouterManager_Run_disconnected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stopped := make(chan struct{})
+	logger := mock_homeassistant.NewMocklogger(ctrl)
+	logger.EXPECT().Info("shutdown routermanager").Do(func(_ string, _ ...any) {
+		close(stopped)
+	})
+
+	mqtt := mock_homeassistant.NewMockmqtt(ctrl)
+	mqtt.EXPECT().IsConnected().Return(false).MinTimes(1)
+
+	// router info isn't requested without mqtt connection
+	manager := NewRouterManager(nil, mock_homeassistant.NewMockrouterInfo(ctrl), mqtt, 10*time.Millisecond, logger)
+	done := manager.Run()
+
+	ticker := time.NewTicker(15 * time.Millisecond)
+	<-ticker.C
+	done <- struct{}{}
+	<-stopped
+}
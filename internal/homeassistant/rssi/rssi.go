@@ -0,0 +1,85 @@
+package rssi
+
+import (
+	"fmt"
+	"strconv"
+
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	"keeneticToMqtt/internal/homeassistant/topic"
+)
+
+//go:generate mockgen -source=rssi.go -destination=../../../test/mocks/gomock/homeassistant/rssi/rssi.go
+
+const (
+	entityTypeName = "rssi"
+	unit           = "dBm"
+	deviceClass    = "signal_strength"
+	stateClass     = "measurement"
+	precision      = 0
+)
+
+type (
+	discovery interface {
+		SendDiscoverySensor(stateTopic string, client dto.Client, entityType string, meta homeassistantdto.SensorMeta) error
+	}
+)
+
+// RSSI struct for handle home assistant client wifi signal strength entities.
+type RSSI struct {
+	topics          *topic.Template
+	discoveryClient discovery
+}
+
+// NewRSSI creates new RSSI.
+func NewRSSI(
+	topics *topic.Template,
+	discoveryClient discovery,
+) *RSSI {
+	return &RSSI{
+		topics:          topics,
+		discoveryClient: discoveryClient,
+	}
+}
+
+// SendDiscoveryMessage sends homeassistant discovery message.
+func (r *RSSI) SendDiscoveryMessage(client dto.Client) error {
+	stateTopic := r.GetStateTopic(client)
+	meta := homeassistantdto.SensorMeta{
+		Unit: unit,
+		EntityMeta: homeassistantdto.EntityMeta{
+			DeviceClass:               deviceClass,
+			StateClass:                stateClass,
+			SuggestedDisplayPrecision: homeassistantdto.Precision(precision),
+			EntityCategory:            homeassistantdto.EntityCategoryDiagnostic,
+		},
+	}
+	if err := r.discoveryClient.SendDiscoverySensor(stateTopic, client, entityTypeName, meta); err != nil {
+		return fmt.Errorf("RSSI SendDiscoveryMessage error: %w", err)
+	}
+
+	return nil
+}
+
+// GetState returns entity state. Wired clients have no state.
+func (r *RSSI) GetState(client dto.Client) (string, error) {
+	if client.AP == "" {
+		return homeassistantdto.PayloadNone, nil
+	}
+	return strconv.Itoa(client.RSSI), nil
+}
+
+// Consume consumes message.
+func (r *RSSI) Consume(_ dto.Client, _ string) error {
+	return nil
+}
+
+// GetStateTopic returns state topic.
+func (r *RSSI) GetStateTopic(client dto.Client) string {
+	return r.topics.StateTopic(client, entityTypeName)
+}
+
+// GetCommandTopic returns command topic.
+func (r *RSSI) GetCommandTopic(_ dto.Client) string {
+	return ""
+}
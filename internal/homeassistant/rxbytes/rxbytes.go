@@ -3,37 +3,42 @@ package rxbytes
 import (
 	"fmt"
 	"strconv"
-	"strings"
 
 	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	"keeneticToMqtt/internal/homeassistant/topic"
 )
 
 //go:generate mockgen -source=rxbytes.go -destination=../../../test/mocks/gomock/homeassistant/rxbytes/rxbytes.go
 
 const (
 	entityTypeName = "rxbytes"
-	unit           = "bytes"
+	unit           = "B"
+	stateClass     = "total_increasing"
+	deviceClass    = "data_size"
+	icon           = "mdi:download"
+	precision      = 0
 )
 
 type (
 	discovery interface {
-		SendDiscoverySensor(stateTopic, deviceName, name, unit string) error
+		SendDiscoverySensor(stateTopic string, client dto.Client, entityType string, meta homeassistantdto.SensorMeta) error
 	}
 )
 
 // RxBytes struct for handle home assistant client rxbytes entities.
 type RxBytes struct {
-	basetopic       string
+	topics          *topic.Template
 	discoveryClient discovery
 }
 
 // NewRxBytes creates new RxBytes.
 func NewRxBytes(
-	basetopic string,
+	topics *topic.Template,
 	discoveryClient discovery,
 ) *RxBytes {
 	return &RxBytes{
-		basetopic:       basetopic,
+		topics:          topics,
 		discoveryClient: discoveryClient,
 	}
 }
@@ -41,7 +46,16 @@ func NewRxBytes(
 // SendDiscoveryMessage sends homeassistant discovery message.
 func (b *RxBytes) SendDiscoveryMessage(client dto.Client) error {
 	stateTopic := b.GetStateTopic(client)
-	if err := b.discoveryClient.SendDiscoverySensor(stateTopic, client.Name, client.Name+"_"+entityTypeName, unit); err != nil {
+	meta := homeassistantdto.SensorMeta{
+		Unit: unit,
+		EntityMeta: homeassistantdto.EntityMeta{
+			DeviceClass:               deviceClass,
+			StateClass:                stateClass,
+			Icon:                      icon,
+			SuggestedDisplayPrecision: homeassistantdto.Precision(precision),
+		},
+	}
+	if err := b.discoveryClient.SendDiscoverySensor(stateTopic, client, entityTypeName, meta); err != nil {
 		return fmt.Errorf("RxBytes SendDiscoveryMessage error: %w", err)
 	}
 
@@ -60,8 +74,7 @@ func (b *RxBytes) Consume(_ dto.Client, _ string) error {
 
 // GetStateTopic returns state topic.
 func (b *RxBytes) GetStateTopic(client dto.Client) string {
-	mac := strings.Replace(client.Mac, ":", "_", -1)
-	return fmt.Sprintf("%s/%s_%s/state", b.basetopic, mac, entityTypeName)
+	return b.topics.StateTopic(client, entityTypeName)
 }
 
 // GetCommandTopic returns command topic.
@@ -8,6 +8,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	"keeneticToMqtt/internal/homeassistant/topic"
 	mock_rxbytes "keeneticToMqtt/test/mocks/gomock/homeassistant/rxbytes"
 )
 
@@ -15,6 +17,16 @@ func TestRxBytes_SendDiscoveryMessage(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	meta := homeassistantdto.SensorMeta{
+		Unit: unit,
+		EntityMeta: homeassistantdto.EntityMeta{
+			DeviceClass:               deviceClass,
+			StateClass:                stateClass,
+			Icon:                      icon,
+			SuggestedDisplayPrecision: homeassistantdto.Precision(precision),
+		},
+	}
+
 	const (
 		mac       = "mac"
 		name      = "name"
@@ -36,9 +48,9 @@ func TestRxBytes_SendDiscoveryMessage(t *testing.T) {
 				discovery.EXPECT().
 					SendDiscoverySensor(
 						gomock.Eq("basetopic/mac_rxbytes/state"),
-						gomock.Eq(name),
-						gomock.Eq("name_rxbytes"),
-						gomock.Eq(unit),
+						gomock.Eq(client),
+						gomock.Eq("rxbytes"),
+						gomock.Eq(meta),
 					).
 					Return(nil)
 
@@ -52,9 +64,9 @@ func TestRxBytes_SendDiscoveryMessage(t *testing.T) {
 				discovery.EXPECT().
 					SendDiscoverySensor(
 						gomock.Eq("basetopic/mac_rxbytes/state"),
-						gomock.Eq(name),
-						gomock.Eq("name_rxbytes"),
-						gomock.Eq(unit),
+						gomock.Eq(client),
+						gomock.Eq("rxbytes"),
+						gomock.Eq(meta),
 					).
 					Return(someErr)
 
@@ -66,7 +78,7 @@ func TestRxBytes_SendDiscoveryMessage(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			rxBytes := NewRxBytes(basetopic, tt.discovery())
+			rxBytes := NewRxBytes(topic.NewTemplate(basetopic, ""), tt.discovery())
 			err := rxBytes.SendDiscoveryMessage(client)
 			if tt.expectedErr != nil {
 				assert.ErrorIs(t, err, tt.expectedErr)
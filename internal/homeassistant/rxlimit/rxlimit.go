@@ -0,0 +1,103 @@
+package rxlimit
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	"keeneticToMqtt/internal/homeassistant/topic"
+)
+
+//go:generate mockgen -source=rxlimit.go -destination=../../../test/mocks/gomock/homeassistant/rxlimit/rxlimit.go
+
+const (
+	entityTypeName = "rxlimit"
+	unit           = "kbit/s"
+	maxLimit       = 1000000
+	mode           = "box"
+	deviceClass    = "data_rate"
+	icon           = "mdi:download-lock"
+)
+
+type (
+	discovery interface {
+		SendDiscoveryNumber(commandTopic, stateTopic string, client dto.Client, entityType string, meta homeassistantdto.NumberMeta) error
+	}
+	accessUpdate interface {
+		SetTrafficShape(mac string, rx, tx int64) error
+	}
+)
+
+// RxLimit struct for handle home assistant client rx traffic shaping limit entities.
+type RxLimit struct {
+	topics          *topic.Template
+	discoveryClient discovery
+	accessUpdate    accessUpdate
+}
+
+// NewRxLimit creates new RxLimit.
+func NewRxLimit(
+	topics *topic.Template,
+	discoveryClient discovery,
+	accessUpdate accessUpdate,
+) *RxLimit {
+	return &RxLimit{
+		topics:          topics,
+		discoveryClient: discoveryClient,
+		accessUpdate:    accessUpdate,
+	}
+}
+
+// SendDiscoveryMessage sends homeassistant discovery message.
+func (r *RxLimit) SendDiscoveryMessage(client dto.Client) error {
+	commandTopic := r.GetCommandTopic(client)
+	stateTopic := r.GetStateTopic(client)
+	meta := homeassistantdto.NumberMeta{
+		Unit: unit,
+		Max:  maxLimit,
+		Step: 1,
+		Mode: mode,
+		EntityMeta: homeassistantdto.EntityMeta{
+			DeviceClass:    deviceClass,
+			Icon:           icon,
+			EntityCategory: homeassistantdto.EntityCategoryConfig,
+		},
+	}
+
+	if err := r.discoveryClient.SendDiscoveryNumber(commandTopic, stateTopic, client, entityTypeName, meta); err != nil {
+		return fmt.Errorf("RxLimit SendDiscoveryMessage error: %w", err)
+	}
+
+	return nil
+}
+
+// GetState returns entity state.
+func (r *RxLimit) GetState(client dto.Client) (string, error) {
+	return strconv.FormatInt(client.RxLimit, 10), nil
+}
+
+// Consume consumes message with new rx limit in kbit/s from 0 to maxLimit, 0 means unlimited.
+func (r *RxLimit) Consume(client dto.Client, message string) error {
+	limit, err := strconv.ParseFloat(message, 64)
+	if err != nil || math.IsNaN(limit) || math.IsInf(limit, 0) || limit < 0 || limit > maxLimit {
+		return fmt.Errorf("RxLimit Consume error: invalid limit %q", message)
+	}
+
+	if err := r.accessUpdate.SetTrafficShape(client.Mac, int64(math.Round(limit)), client.TxLimit); err != nil {
+		return fmt.Errorf("client error while setting rx limit: %w", err)
+	}
+
+	return nil
+}
+
+// GetStateTopic returns state topic.
+func (r *RxLimit) GetStateTopic(client dto.Client) string {
+	return r.topics.StateTopic(client, entityTypeName)
+}
+
+// GetCommandTopic returns command topic.
+func (r *RxLimit) GetCommandTopic(client dto.Client) string {
+	return r.topics.CommandTopic(client, entityTypeName)
+}
@@ -0,0 +1,83 @@
+package rxrate
+
+import (
+	"fmt"
+	"strconv"
+
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	"keeneticToMqtt/internal/homeassistant/topic"
+)
+
+//go:generate mockgen -source=rxrate.go -destination=../../../test/mocks/gomock/homeassistant/rxrate/rxrate.go
+
+const (
+	entityTypeName = "rxrate"
+	unit           = "B/s"
+	deviceClass    = "data_rate"
+	stateClass     = "measurement"
+	icon           = "mdi:download"
+	precision      = 0
+)
+
+type (
+	discovery interface {
+		SendDiscoverySensor(stateTopic string, client dto.Client, entityType string, meta homeassistantdto.SensorMeta) error
+	}
+)
+
+// RxRate struct for handle home assistant client rx traffic rate entities.
+type RxRate struct {
+	topics          *topic.Template
+	discoveryClient discovery
+}
+
+// NewRxRate creates new RxRate.
+func NewRxRate(
+	topics *topic.Template,
+	discoveryClient discovery,
+) *RxRate {
+	return &RxRate{
+		topics:          topics,
+		discoveryClient: discoveryClient,
+	}
+}
+
+// SendDiscoveryMessage sends homeassistant discovery message.
+func (b *RxRate) SendDiscoveryMessage(client dto.Client) error {
+	stateTopic := b.GetStateTopic(client)
+	meta := homeassistantdto.SensorMeta{
+		Unit: unit,
+		EntityMeta: homeassistantdto.EntityMeta{
+			DeviceClass:               deviceClass,
+			StateClass:                stateClass,
+			Icon:                      icon,
+			SuggestedDisplayPrecision: homeassistantdto.Precision(precision),
+		},
+	}
+	if err := b.discoveryClient.SendDiscoverySensor(stateTopic, client, entityTypeName, meta); err != nil {
+		return fmt.Errorf("RxRate SendDiscoveryMessage error: %w", err)
+	}
+
+	return nil
+}
+
+// GetState returns entity state.
+func (b *RxRate) GetState(client dto.Client) (string, error) {
+	return strconv.Itoa(int(client.RxRate)), nil
+}
+
+// Consume consumes message.
+func (b *RxRate) Consume(_ dto.Client, _ string) error {
+	return nil
+}
+
+// GetStateTopic returns state topic.
+func (b *RxRate) GetStateTopic(client dto.Client) string {
+	return b.topics.StateTopic(client, entityTypeName)
+}
+
+// GetCommandTopic returns command topic.
+func (b *RxRate) GetCommandTopic(_ dto.Client) string {
+	return ""
+}
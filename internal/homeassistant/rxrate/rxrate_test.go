@@ -0,0 +1,138 @@
+package rxrate
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	"keeneticToMqtt/internal/homeassistant/topic"
+	mock_rxrate "keeneticToMqtt/test/mocks/gomock/homeassistant/rxrate"
+)
+
+func TestRxRate_SendDiscoveryMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	meta := homeassistantdto.SensorMeta{
+		Unit: unit,
+		EntityMeta: homeassistantdto.EntityMeta{
+			DeviceClass:               deviceClass,
+			StateClass:                stateClass,
+			Icon:                      icon,
+			SuggestedDisplayPrecision: homeassistantdto.Precision(precision),
+		},
+	}
+
+	const (
+		mac       = "mac"
+		name      = "name"
+		basetopic = "basetopic"
+	)
+	someErr := errors.New("some error")
+
+	client := dto.Client{Mac: mac, Name: name}
+
+	tests := []struct {
+		name        string
+		expectedErr error
+		discovery   func() discovery
+	}{
+		{
+			name: "success send discovery message",
+			discovery: func() discovery {
+				discovery := mock_rxrate.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendDiscoverySensor(
+						gomock.Eq("basetopic/mac_rxrate/state"),
+						gomock.Eq(client),
+						gomock.Eq("rxrate"),
+						gomock.Eq(meta),
+					).
+					Return(nil)
+
+				return discovery
+			},
+		},
+		{
+			name: "error while send discovery message",
+			discovery: func() discovery {
+				discovery := mock_rxrate.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendDiscoverySensor(
+						gomock.Eq("basetopic/mac_rxrate/state"),
+						gomock.Eq(client),
+						gomock.Eq("rxrate"),
+						gomock.Eq(meta),
+					).
+					Return(someErr)
+
+				return discovery
+			},
+			expectedErr: someErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rxRate := NewRxRate(topic.NewTemplate(basetopic, ""), tt.discovery())
+			err := rxRate.SendDiscoveryMessage(client)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestRxRate_GetState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		mac    = "mac"
+		rxRate = 123
+		name   = "name"
+	)
+
+	tests := []struct {
+		name     string
+		expected string
+		client   dto.Client
+	}{
+		{
+			name: "success rxrate get",
+			client: dto.Client{
+				Mac:    mac,
+				RxRate: rxRate,
+				Name:   name,
+			},
+			expected: strconv.Itoa(rxRate),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rxRate := RxRate{}
+			res, err := rxRate.GetState(tt.client)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, res)
+		})
+	}
+}
+
+func TestRxRate_Consume(t *testing.T) {
+	rxRate := RxRate{}
+
+	err := rxRate.Consume(dto.Client{}, "")
+	assert.Nil(t, err)
+}
+
+func TestRxRate_GetCommandTopic(t *testing.T) {
+	rxRate := RxRate{}
+	assert.Empty(t, rxRate.GetCommandTopic(dto.Client{}))
+}
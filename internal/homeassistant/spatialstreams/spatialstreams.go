@@ -0,0 +1,83 @@
+package spatialstreams
+
+import (
+	"fmt"
+	"strconv"
+
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	"keeneticToMqtt/internal/homeassistant/topic"
+)
+
+//go:generate mockgen -source=spatialstreams.go -destination=../../../test/mocks/gomock/homeassistant/spatialstreams/spatialstreams.go
+
+const (
+	entityTypeName = "spatialstreams"
+	stateClass     = "measurement"
+	icon           = "mdi:antenna"
+	precision      = 0
+)
+
+type (
+	discovery interface {
+		SendDiscoverySensor(stateTopic string, client dto.Client, entityType string, meta homeassistantdto.SensorMeta) error
+	}
+)
+
+// SpatialStreams struct for handle home assistant client wifi spatial streams count entities.
+type SpatialStreams struct {
+	topics          *topic.Template
+	discoveryClient discovery
+}
+
+// NewSpatialStreams creates new SpatialStreams.
+func NewSpatialStreams(
+	topics *topic.Template,
+	discoveryClient discovery,
+) *SpatialStreams {
+	return &SpatialStreams{
+		topics:          topics,
+		discoveryClient: discoveryClient,
+	}
+}
+
+// SendDiscoveryMessage sends homeassistant discovery message.
+func (s *SpatialStreams) SendDiscoveryMessage(client dto.Client) error {
+	stateTopic := s.GetStateTopic(client)
+	meta := homeassistantdto.SensorMeta{
+		EntityMeta: homeassistantdto.EntityMeta{
+			StateClass:                stateClass,
+			Icon:                      icon,
+			SuggestedDisplayPrecision: homeassistantdto.Precision(precision),
+			EntityCategory:            homeassistantdto.EntityCategoryDiagnostic,
+		},
+	}
+	if err := s.discoveryClient.SendDiscoverySensor(stateTopic, client, entityTypeName, meta); err != nil {
+		return fmt.Errorf("SpatialStreams SendDiscoveryMessage error: %w", err)
+	}
+
+	return nil
+}
+
+// GetState returns entity state. Wired clients have no state.
+func (s *SpatialStreams) GetState(client dto.Client) (string, error) {
+	if client.AP == "" {
+		return homeassistantdto.PayloadNone, nil
+	}
+	return strconv.Itoa(client.SpatialStreams), nil
+}
+
+// Consume consumes message.
+func (s *SpatialStreams) Consume(_ dto.Client, _ string) error {
+	return nil
+}
+
+// GetStateTopic returns state topic.
+func (s *SpatialStreams) GetStateTopic(client dto.Client) string {
+	return s.topics.StateTopic(client, entityTypeName)
+}
+
+// GetCommandTopic returns command topic.
+func (s *SpatialStreams) GetCommandTopic(_ dto.Client) string {
+	return ""
+}
@@ -0,0 +1,131 @@
+package spatialstreams
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	"keeneticToMqtt/internal/homeassistant/topic"
+	mock_spatialstreams "keeneticToMqtt/test/mocks/gomock/homeassistant/spatialstreams"
+)
+
+func TestSpatialStreams_SendDiscoveryMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	meta := homeassistantdto.SensorMeta{
+		EntityMeta: homeassistantdto.EntityMeta{
+			StateClass:                stateClass,
+			Icon:                      icon,
+			SuggestedDisplayPrecision: homeassistantdto.Precision(precision),
+			EntityCategory:            homeassistantdto.EntityCategoryDiagnostic,
+		},
+	}
+
+	const (
+		mac       = "mac"
+		name      = "name"
+		basetopic = "basetopic"
+	)
+	someErr := errors.New("some error")
+
+	client := dto.Client{Mac: mac, Name: name}
+
+	tests := []struct {
+		name        string
+		expectedErr error
+		discovery   func() discovery
+	}{
+		{
+			name: "success send discovery message",
+			discovery: func() discovery {
+				discovery := mock_spatialstreams.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendDiscoverySensor(
+						gomock.Eq("basetopic/mac_spatialstreams/state"),
+						gomock.Eq(client),
+						gomock.Eq("spatialstreams"),
+						gomock.Eq(meta),
+					).
+					Return(nil)
+
+				return discovery
+			},
+		},
+		{
+			name: "error while send discovery message",
+			discovery: func() discovery {
+				discovery := mock_spatialstreams.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendDiscoverySensor(
+						gomock.Eq("basetopic/mac_spatialstreams/state"),
+						gomock.Eq(client),
+						gomock.Eq("spatialstreams"),
+						gomock.Eq(meta),
+					).
+					Return(someErr)
+
+				return discovery
+			},
+			expectedErr: someErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spatialstreams := NewSpatialStreams(topic.NewTemplate(basetopic, ""), tt.discovery())
+			err := spatialstreams.SendDiscoveryMessage(client)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestSpatialStreams_GetState(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		client   dto.Client
+	}{
+		{
+			name: "wifi client",
+			client: dto.Client{
+				AP:             "WifiMaster0/AccessPoint0",
+				SpatialStreams: 2,
+			},
+			expected: "2",
+		},
+		{
+			name:     "wired client",
+			client:   dto.Client{},
+			expected: homeassistantdto.PayloadNone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spatialstreams := SpatialStreams{}
+			res, err := spatialstreams.GetState(tt.client)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, res)
+		})
+	}
+}
+
+func TestSpatialStreams_Consume(t *testing.T) {
+	spatialstreams := SpatialStreams{}
+
+	err := spatialstreams.Consume(dto.Client{}, "")
+	assert.Nil(t, err)
+}
+
+func TestSpatialStreams_GetCommandTopic(t *testing.T) {
+	spatialstreams := SpatialStreams{}
+	assert.Empty(t, spatialstreams.GetCommandTopic(dto.Client{}))
+}
@@ -0,0 +1,78 @@
+package ssid
+
+import (
+	"fmt"
+
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	"keeneticToMqtt/internal/homeassistant/topic"
+)
+
+//go:generate mockgen -source=ssid.go -destination=../../../test/mocks/gomock/homeassistant/ssid/ssid.go
+
+const (
+	entityTypeName = "ssid"
+	icon           = "mdi:wifi"
+)
+
+type (
+	discovery interface {
+		SendDiscoverySensor(stateTopic string, client dto.Client, entityType string, meta homeassistantdto.SensorMeta) error
+	}
+)
+
+// SSID struct for handle home assistant client wifi network name entities.
+type SSID struct {
+	topics          *topic.Template
+	discoveryClient discovery
+}
+
+// NewSSID creates new SSID.
+func NewSSID(
+	topics *topic.Template,
+	discoveryClient discovery,
+) *SSID {
+	return &SSID{
+		topics:          topics,
+		discoveryClient: discoveryClient,
+	}
+}
+
+// SendDiscoveryMessage sends homeassistant discovery message.
+func (s *SSID) SendDiscoveryMessage(client dto.Client) error {
+	stateTopic := s.GetStateTopic(client)
+	meta := homeassistantdto.SensorMeta{
+		EntityMeta: homeassistantdto.EntityMeta{
+			Icon:           icon,
+			EntityCategory: homeassistantdto.EntityCategoryDiagnostic,
+		},
+	}
+	if err := s.discoveryClient.SendDiscoverySensor(stateTopic, client, entityTypeName, meta); err != nil {
+		return fmt.Errorf("SSID SendDiscoveryMessage error: %w", err)
+	}
+
+	return nil
+}
+
+// GetState returns entity state. Wired clients have no state.
+func (s *SSID) GetState(client dto.Client) (string, error) {
+	if client.AP == "" {
+		return homeassistantdto.PayloadNone, nil
+	}
+	return client.SSID, nil
+}
+
+// Consume consumes message.
+func (s *SSID) Consume(_ dto.Client, _ string) error {
+	return nil
+}
+
+// GetStateTopic returns state topic.
+func (s *SSID) GetStateTopic(client dto.Client) string {
+	return s.topics.StateTopic(client, entityTypeName)
+}
+
+// GetCommandTopic returns command topic.
+func (s *SSID) GetCommandTopic(_ dto.Client) string {
+	return ""
+}
@@ -0,0 +1,81 @@
+package homeassistant
+
+import (
+	"sync"
+	"time"
+)
+
+// stateCache remembers states sent to mqtt, so unchanged states are not resent on every update.
+// Key identifies state, for example state topic of router entity or state topic and mac of client entity.
+type stateCache[K comparable] struct {
+	states map[K]string
+	mutex  sync.Mutex
+}
+
+func newStateCache[K comparable]() *stateCache[K] {
+	return &stateCache[K]{
+		states: make(map[K]string),
+	}
+}
+
+// changed remembers state and reports if it differs from the sent one.
+func (c *stateCache[K]) changed(key K, state string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if sent, ok := c.states[key]; ok && sent == state {
+		return false
+	}
+	c.states[key] = state
+
+	return true
+}
+
+// forget forgets sent state, so next update resends actual state,
+// for example home assistant reverts optimistic state of rejected command.
+func (c *stateCache[K]) forget(key K) {
+	c.mutex.Lock()
+	delete(c.states, key)
+	c.mutex.Unlock()
+}
+
+// reset forgets all sent states.
+func (c *stateCache[K]) reset() {
+	c.mutex.Lock()
+	c.states = make(map[K]string)
+	c.mutex.Unlock()
+}
+
+// runUpdates calls update on every tick until done receives, then calls shutdown.
+func runUpdates(interval time.Duration, mqtt mqtt, update func(), shutdown func()) chan struct{} {
+	done := make(chan struct{})
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		for {
+			select {
+			case <-done:
+				shutdown()
+				return
+			case _ = <-ticker.C:
+				// states sent without connection are lost, but cached as sent, so wait for reconnect
+				if !mqtt.IsConnected() {
+					continue
+				}
+				update()
+			}
+		}
+	}()
+
+	return done
+}
+
+// finishCommand publishes result of consumed command and runs update, which sends actual states.
+// rollback is called for rejected command to forget its states, so update resends them.
+func finishCommand(mqtt mqtt, commandTopic, message string, err error, rollback func(), update func()) {
+	if err != nil {
+		rollback()
+	}
+	mqtt.SendMessage(ResultTopic(commandTopic), resultMessage(message, err), false)
+	update()
+}
@@ -0,0 +1,71 @@
+package homeassistant
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	mock_homeassistant "keeneticToMqtt/test/mocks/gomock/homeassistant"
+)
+
+func TestStateCache(t *testing.T) {
+	cache := newStateCache[string]()
+
+	assert.True(t, cache.changed("topic", "on"))
+	assert.False(t, cache.changed("topic", "on"))
+	assert.True(t, cache.changed("topic", "off"))
+
+	cache.forget("topic")
+	assert.True(t, cache.changed("topic", "off"))
+
+	cache.reset()
+	assert.Empty(t, cache.states)
+	assert.True(t, cache.changed("topic", "off"))
+}
+
+func TestFinishCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		commandTopic = "commandTopic"
+		message      = "ON"
+	)
+	someErr := errors.New("some err")
+
+	tests := []struct {
+		name             string
+		err              error
+		expectedResult   string
+		expectedRollback bool
+	}{
+		{
+			name:           "success command",
+			expectedResult: `{"success":true,"command":"ON"}`,
+		},
+		{
+			name:             "rejected command rolls back states",
+			err:              someErr,
+			expectedResult:   `{"success":false,"command":"ON","code":"error","error":"some err"}`,
+			expectedRollback: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rolledBack, updated bool
+
+			mqtt := mock_homeassistant.NewMockmqtt(ctrl)
+			mqtt.EXPECT().SendMessage("commandTopic/result", tt.expectedResult, false).Do(func(string, string, bool) {
+				// result is published after rollback and before update
+				assert.Equal(t, tt.expectedRollback, rolledBack)
+				assert.False(t, updated)
+			})
+
+			finishCommand(mqtt, commandTopic, message, tt.err, func() { rolledBack = true }, func() { updated = true })
+			assert.Equal(t, tt.expectedRollback, rolledBack)
+			assert.True(t, updated)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package topic
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+
+	"keeneticToMqtt/internal/dto"
+)
+
+const (
+	// DefaultTemplate is client entity topic template, which is used when template is not configured.
+	DefaultTemplate = "{base}/{mac}_{entity}"
+
+	basePlaceholder       = "{base}"
+	macPlaceholder        = "{mac}"
+	clientNamePlaceholder = "{client_name}"
+	entityPlaceholder     = "{entity}"
+
+	stateSuffix   = "/state"
+	commandSuffix = "/command"
+
+	// attributesEntity is entity part of client attributes topic.
+	attributesEntity = "attributes"
+)
+
+// Template builds topics of client entities.
+// Template placeholders are {base} for mqtt base topic, {mac} for client mac, {client_name} for client name
+// and {entity} for entity type. State and command topics are template with /state and /command suffix.
+type Template struct {
+	base     string
+	template string
+}
+
+// NewTemplate creates new Template. DefaultTemplate is used for empty template.
+func NewTemplate(base, template string) *Template {
+	if template == "" {
+		template = DefaultTemplate
+	}
+
+	return &Template{
+		base:     base,
+		template: template,
+	}
+}
+
+// Validate checks that template builds unique topics for every client entity.
+// Client names are not unique, for example several unregistered clients with the same hostname, so {mac} is required.
+func Validate(template string) error {
+	if template == "" {
+		return nil
+	}
+	if !strings.Contains(template, entityPlaceholder) {
+		return errors.New("topic template must contain {entity}")
+	}
+	if !strings.Contains(template, macPlaceholder) {
+		return errors.New("topic template must contain {mac}")
+	}
+	if strings.ContainsAny(template, "+#") {
+		return errors.New("topic template must not contain mqtt wildcards")
+	}
+
+	return nil
+}
+
+// StateTopic returns state topic of client entity.
+func (t *Template) StateTopic(client dto.Client, entity string) string {
+	return t.build(client, entity) + stateSuffix
+}
+
+// CommandTopic returns command topic of client entity.
+func (t *Template) CommandTopic(client dto.Client, entity string) string {
+	return t.build(client, entity) + commandSuffix
+}
+
+// AttributesTopic returns topic of client json attributes, which are shared by all client entities.
+func (t *Template) AttributesTopic(client dto.Client) string {
+	return t.build(client, attributesEntity)
+}
+
+func (t *Template) build(client dto.Client, entity string) string {
+	return strings.NewReplacer(
+		basePlaceholder, t.base,
+		macPlaceholder, strings.ReplaceAll(client.Mac, ":", "_"),
+		clientNamePlaceholder, Level(client.Name),
+		entityPlaceholder, entity,
+	).Replace(t.template)
+}
+
+// Level replaces characters, which are not letters, digits, - or _, with _,
+// so value can be used as single mqtt topic level and in home assistant object id.
+func Level(value string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, value)
+}
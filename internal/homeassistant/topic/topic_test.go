@@ -0,0 +1,97 @@
+package topic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"keeneticToMqtt/internal/dto"
+)
+
+func TestTemplate(t *testing.T) {
+	client := dto.Client{Mac: "aa:bb:cc:dd:ee:ff", Name: "Phone / Иван #1"}
+
+	tests := []struct {
+		name               string
+		template           string
+		expectedState      string
+		expectedCommand    string
+		expectedAttributes string
+	}{
+		{
+			name:               "default template",
+			expectedState:      "base/aa_bb_cc_dd_ee_ff_policy/state",
+			expectedCommand:    "base/aa_bb_cc_dd_ee_ff_policy/command",
+			expectedAttributes: "base/aa_bb_cc_dd_ee_ff_attributes",
+		},
+		{
+			name:               "client name template",
+			template:           "{base}/{client_name}_{mac}/{entity}",
+			expectedState:      "base/Phone___Иван__1_aa_bb_cc_dd_ee_ff/policy/state",
+			expectedCommand:    "base/Phone___Иван__1_aa_bb_cc_dd_ee_ff/policy/command",
+			expectedAttributes: "base/Phone___Иван__1_aa_bb_cc_dd_ee_ff/attributes",
+		},
+		{
+			name:               "custom prefix",
+			template:           "home/keenetic/{mac}/{entity}",
+			expectedState:      "home/keenetic/aa_bb_cc_dd_ee_ff/policy/state",
+			expectedCommand:    "home/keenetic/aa_bb_cc_dd_ee_ff/policy/command",
+			expectedAttributes: "home/keenetic/aa_bb_cc_dd_ee_ff/attributes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := NewTemplate("base", tt.template)
+			assert.Equal(t, tt.expectedState, template.StateTopic(client, "policy"))
+			assert.Equal(t, tt.expectedCommand, template.CommandTopic(client, "policy"))
+			assert.Equal(t, tt.expectedAttributes, template.AttributesTopic(client))
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name           string
+		template       string
+		expectedErrStr string
+	}{
+		{
+			name: "empty template",
+		},
+		{
+			name:     "valid template",
+			template: "{base}/{client_name}_{mac}/{entity}",
+		},
+		{
+			name:           "no entity",
+			template:       "{base}/{mac}",
+			expectedErrStr: "topic template must contain {entity}",
+		},
+		{
+			name:           "no client",
+			template:       "{base}/{entity}",
+			expectedErrStr: "topic template must contain {mac}",
+		},
+		{
+			name:           "client name without mac",
+			template:       "{base}/{client_name}/{entity}",
+			expectedErrStr: "topic template must contain {mac}",
+		},
+		{
+			name:           "wildcard",
+			template:       "{base}/+/{mac}_{entity}",
+			expectedErrStr: "topic template must not contain mqtt wildcards",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.template)
+			if tt.expectedErrStr != "" {
+				assert.EqualError(t, err, tt.expectedErrStr)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package homeassistant
+
+import (
+	"time"
+
+	"keeneticToMqtt/internal/dto"
+)
+
+// minRateInterval minimal interval between traffic samples to calculate rate.
+const minRateInterval = time.Second
+
+type trafficSample struct {
+	rxBytes, txBytes int64
+	rxRate, txRate   int64
+	time             time.Time
+}
+
+// calculateRate returns bytes per second between two counter values.
+// Counter is considered reset (for example after client reconnect) if current value is less than previous.
+func calculateRate(previous, current int64, interval time.Duration) int64 {
+	delta := current - previous
+	if delta < 0 {
+		delta = current
+	}
+
+	return int64(float64(delta) / interval.Seconds())
+}
+
+// nextTrafficSample stores new traffic sample for key and returns it with calculated rates.
+// Rates of previous sample are reused if it is too fresh to calculate rate.
+func nextTrafficSample(samples map[string]trafficSample, key string, rxBytes, txBytes int64, now time.Time) trafficSample {
+	current := trafficSample{
+		rxBytes: rxBytes,
+		txBytes: txBytes,
+		time:    now,
+	}
+
+	if previous, ok := samples[key]; ok {
+		interval := now.Sub(previous.time)
+		if interval < minRateInterval {
+			return previous
+		}
+		current.rxRate = calculateRate(previous.rxBytes, rxBytes, interval)
+		current.txRate = calculateRate(previous.txBytes, txBytes, interval)
+	}
+	samples[key] = current
+
+	return current
+}
+
+// fillTrafficRates fills client rx and tx rates using previous traffic sample.
+func (m *EntityManager) fillTrafficRates(client dto.Client) dto.Client {
+	m.trafficSamplesMutex.Lock()
+	defer m.trafficSamplesMutex.Unlock()
+
+	sample := nextTrafficSample(m.trafficSamples, client.Mac, client.RxBytes, client.TxBytes, m.now())
+	client.RxRate = sample.rxRate
+	client.TxRate = sample.txRate
+
+	return client
+}
+
+// fillWanRates fills rx and tx rates of router wan interfaces using previous traffic samples.
+func (m *RouterManager) fillWanRates(router dto.Router) dto.Router {
+	if len(router.Wans) == 0 {
+		return router
+	}
+
+	now := m.now()
+	wans := make([]dto.Wan, 0, len(router.Wans))
+	for _, wan := range router.Wans {
+		sample := nextTrafficSample(m.trafficSamples, wan.ID, wan.RxBytes, wan.TxBytes, now)
+		wan.RxRate = sample.rxRate
+		wan.TxRate = sample.txRate
+		wans = append(wans, wan)
+	}
+	router.Wans = wans
+
+	return router
+}
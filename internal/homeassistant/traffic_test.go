@@ -0,0 +1,135 @@
+package homeassistant
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"keeneticToMqtt/internal/dto"
+)
+
+func TestCalculateRate(t *testing.T) {
+	tests := []struct {
+		name              string
+		previous, current int64
+		interval          time.Duration
+		expected          int64
+	}{
+		{
+			name:     "counter grows",
+			previous: 1000,
+			current:  3000,
+			interval: 2 * time.Second,
+			expected: 1000,
+		},
+		{
+			name:     "counter is not changed",
+			previous: 1000,
+			current:  1000,
+			interval: 10 * time.Second,
+			expected: 0,
+		},
+		{
+			name:     "counter reset",
+			previous: 1000,
+			current:  500,
+			interval: 10 * time.Second,
+			expected: 50,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, calculateRate(tt.previous, tt.current, tt.interval))
+		})
+	}
+}
+
+func TestEntityManager_fillTrafficRates(t *testing.T) {
+	const mac = "mac"
+
+	now := time.Now()
+
+	tests := []struct {
+		name           string
+		client         dto.Client
+		trafficSamples map[string]trafficSample
+		expected       dto.Client
+		expectedSample trafficSample
+	}{
+		{
+			name:           "first sample",
+			client:         dto.Client{Mac: mac, RxBytes: 100, TxBytes: 200},
+			trafficSamples: map[string]trafficSample{},
+			expected:       dto.Client{Mac: mac, RxBytes: 100, TxBytes: 200},
+			expectedSample: trafficSample{rxBytes: 100, txBytes: 200, time: now},
+		},
+		{
+			name:   "rates calculated from previous sample",
+			client: dto.Client{Mac: mac, RxBytes: 2100, TxBytes: 4200},
+			trafficSamples: map[string]trafficSample{
+				mac: {rxBytes: 100, txBytes: 200, time: now.Add(-10 * time.Second)},
+			},
+			expected:       dto.Client{Mac: mac, RxBytes: 2100, TxBytes: 4200, RxRate: 200, TxRate: 400},
+			expectedSample: trafficSample{rxBytes: 2100, txBytes: 4200, rxRate: 200, txRate: 400, time: now},
+		},
+		{
+			name:   "counters reset",
+			client: dto.Client{Mac: mac, RxBytes: 100, TxBytes: 200},
+			trafficSamples: map[string]trafficSample{
+				mac: {rxBytes: 1000, txBytes: 2000, time: now.Add(-10 * time.Second)},
+			},
+			expected:       dto.Client{Mac: mac, RxBytes: 100, TxBytes: 200, RxRate: 10, TxRate: 20},
+			expectedSample: trafficSample{rxBytes: 100, txBytes: 200, rxRate: 10, txRate: 20, time: now},
+		},
+		{
+			name:   "previous sample is too recent",
+			client: dto.Client{Mac: mac, RxBytes: 2100, TxBytes: 4200},
+			trafficSamples: map[string]trafficSample{
+				mac: {rxBytes: 100, txBytes: 200, rxRate: 5, txRate: 6, time: now.Add(-time.Millisecond)},
+			},
+			expected:       dto.Client{Mac: mac, RxBytes: 2100, TxBytes: 4200, RxRate: 5, TxRate: 6},
+			expectedSample: trafficSample{rxBytes: 100, txBytes: 200, rxRate: 5, txRate: 6, time: now.Add(-time.Millisecond)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := NewEntityManager(nil, nil, nil, nil, nil, time.Second, nil)
+			manager.trafficSamples = tt.trafficSamples
+			manager.now = func() time.Time {
+				return now
+			}
+
+			assert.Equal(t, tt.expected, manager.fillTrafficRates(tt.client))
+			assert.Equal(t, tt.expectedSample, manager.trafficSamples[mac])
+		})
+	}
+}
+
+func TestRouterManager_fillWanRates(t *testing.T) {
+	now := time.Now()
+
+	manager := NewRouterManager(nil, nil, nil, time.Second, nil)
+	manager.trafficSamples = map[string]trafficSample{
+		"PPPoE0": {rxBytes: 100, txBytes: 200, time: now.Add(-10 * time.Second)},
+	}
+	manager.now = func() time.Time {
+		return now
+	}
+
+	router := dto.Router{Wans: []dto.Wan{
+		{ID: "PPPoE0", RxBytes: 2100, TxBytes: 4200},
+		{ID: "UsbLte0", RxBytes: 100, TxBytes: 200},
+	}}
+	expected := dto.Router{Wans: []dto.Wan{
+		{ID: "PPPoE0", RxBytes: 2100, TxBytes: 4200, RxRate: 200, TxRate: 400},
+		{ID: "UsbLte0", RxBytes: 100, TxBytes: 200},
+	}}
+
+	assert.Equal(t, expected, manager.fillWanRates(router))
+	assert.Equal(t, map[string]trafficSample{
+		"PPPoE0":  {rxBytes: 2100, txBytes: 4200, rxRate: 200, txRate: 400, time: now},
+		"UsbLte0": {rxBytes: 100, txBytes: 200, time: now},
+	}, manager.trafficSamples)
+}
@@ -3,37 +3,42 @@ package txbytes
 import (
 	"fmt"
 	"strconv"
-	"strings"
 
 	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	"keeneticToMqtt/internal/homeassistant/topic"
 )
 
 //go:generate mockgen -source=txbytes.go -destination=../../../test/mocks/gomock/homeassistant/txbytes/txbytes.go
 
 const (
 	entityTypeName = "txbytes"
-	unit           = "bytes"
+	unit           = "B"
+	stateClass     = "total_increasing"
+	deviceClass    = "data_size"
+	icon           = "mdi:upload"
+	precision      = 0
 )
 
 type (
 	discovery interface {
-		SendDiscoverySensor(stateTopic, deviceName, name, unit string) error
+		SendDiscoverySensor(stateTopic string, client dto.Client, entityType string, meta homeassistantdto.SensorMeta) error
 	}
 )
 
 // TxBytes struct for handle home assistant client txbytes entities.
 type TxBytes struct {
-	basetopic       string
+	topics          *topic.Template
 	discoveryClient discovery
 }
 
 // NewTxBytes creates new TxBytes.
 func NewTxBytes(
-	basetopic string,
+	topics *topic.Template,
 	discoveryClient discovery,
 ) *TxBytes {
 	return &TxBytes{
-		basetopic:       basetopic,
+		topics:          topics,
 		discoveryClient: discoveryClient,
 	}
 }
@@ -41,7 +46,16 @@ func NewTxBytes(
 // SendDiscoveryMessage sends homeassistant discovery message.
 func (p *TxBytes) SendDiscoveryMessage(client dto.Client) error {
 	stateTopic := p.GetStateTopic(client)
-	if err := p.discoveryClient.SendDiscoverySensor(stateTopic, client.Name, client.Name+"_"+entityTypeName, unit); err != nil {
+	meta := homeassistantdto.SensorMeta{
+		Unit: unit,
+		EntityMeta: homeassistantdto.EntityMeta{
+			DeviceClass:               deviceClass,
+			StateClass:                stateClass,
+			Icon:                      icon,
+			SuggestedDisplayPrecision: homeassistantdto.Precision(precision),
+		},
+	}
+	if err := p.discoveryClient.SendDiscoverySensor(stateTopic, client, entityTypeName, meta); err != nil {
 		return fmt.Errorf("TxBytes SendDiscoveryMessage error: %w", err)
 	}
 
@@ -60,8 +74,7 @@ func (p *TxBytes) Consume(_ dto.Client, _ string) error {
 
 // GetStateTopic returns state topic.
 func (p *TxBytes) GetStateTopic(client dto.Client) string {
-	mac := strings.Replace(client.Mac, ":", "_", -1)
-	return fmt.Sprintf("%s/%s_%s/state", p.basetopic, mac, entityTypeName)
+	return p.topics.StateTopic(client, entityTypeName)
 }
 
 // GetCommandTopic returns command topic.
@@ -10,12 +10,24 @@ import (
 	mock_txbytes "keeneticToMqtt/test/mocks/gomock/homeassistant/txbytes"
 
 	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	"keeneticToMqtt/internal/homeassistant/topic"
 )
 
 func TestTxBytes_SendDiscoveryMessage(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	meta := homeassistantdto.SensorMeta{
+		Unit: unit,
+		EntityMeta: homeassistantdto.EntityMeta{
+			DeviceClass:               deviceClass,
+			StateClass:                stateClass,
+			Icon:                      icon,
+			SuggestedDisplayPrecision: homeassistantdto.Precision(precision),
+		},
+	}
+
 	const (
 		mac       = "mac"
 		name      = "name"
@@ -37,9 +49,9 @@ func TestTxBytes_SendDiscoveryMessage(t *testing.T) {
 				discovery.EXPECT().
 					SendDiscoverySensor(
 						gomock.Eq("basetopic/mac_txbytes/state"),
-						gomock.Eq(name),
-						gomock.Eq("name_txbytes"),
-						gomock.Eq(unit),
+						gomock.Eq(client),
+						gomock.Eq("txbytes"),
+						gomock.Eq(meta),
 					).
 					Return(nil)
 
@@ -53,9 +65,9 @@ func TestTxBytes_SendDiscoveryMessage(t *testing.T) {
 				discovery.EXPECT().
 					SendDiscoverySensor(
 						gomock.Eq("basetopic/mac_txbytes/state"),
-						gomock.Eq(name),
-						gomock.Eq("name_txbytes"),
-						gomock.Eq(unit),
+						gomock.Eq(client),
+						gomock.Eq("txbytes"),
+						gomock.Eq(meta),
 					).
 					Return(someErr)
 
@@ -67,7 +79,7 @@ func TestTxBytes_SendDiscoveryMessage(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			txBytes := NewTxBytes(basetopic, tt.discovery())
+			txBytes := NewTxBytes(topic.NewTemplate(basetopic, ""), tt.discovery())
 			err := txBytes.SendDiscoveryMessage(client)
 			if tt.expectedErr != nil {
 				assert.ErrorIs(t, err, tt.expectedErr)
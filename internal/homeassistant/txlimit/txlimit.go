@@ -0,0 +1,103 @@
+package txlimit
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	"keeneticToMqtt/internal/homeassistant/topic"
+)
+
+//go:generate mockgen -source=txlimit.go -destination=../../../test/mocks/gomock/homeassistant/txlimit/txlimit.go
+
+const (
+	entityTypeName = "txlimit"
+	unit           = "kbit/s"
+	maxLimit       = 1000000
+	mode           = "box"
+	deviceClass    = "data_rate"
+	icon           = "mdi:upload-lock"
+)
+
+type (
+	discovery interface {
+		SendDiscoveryNumber(commandTopic, stateTopic string, client dto.Client, entityType string, meta homeassistantdto.NumberMeta) error
+	}
+	accessUpdate interface {
+		SetTrafficShape(mac string, rx, tx int64) error
+	}
+)
+
+// TxLimit struct for handle home assistant client tx traffic shaping limit entities.
+type TxLimit struct {
+	topics          *topic.Template
+	discoveryClient discovery
+	accessUpdate    accessUpdate
+}
+
+// NewTxLimit creates new TxLimit.
+func NewTxLimit(
+	topics *topic.Template,
+	discoveryClient discovery,
+	accessUpdate accessUpdate,
+) *TxLimit {
+	return &TxLimit{
+		topics:          topics,
+		discoveryClient: discoveryClient,
+		accessUpdate:    accessUpdate,
+	}
+}
+
+// SendDiscoveryMessage sends homeassistant discovery message.
+func (t *TxLimit) SendDiscoveryMessage(client dto.Client) error {
+	commandTopic := t.GetCommandTopic(client)
+	stateTopic := t.GetStateTopic(client)
+	meta := homeassistantdto.NumberMeta{
+		Unit: unit,
+		Max:  maxLimit,
+		Step: 1,
+		Mode: mode,
+		EntityMeta: homeassistantdto.EntityMeta{
+			DeviceClass:    deviceClass,
+			Icon:           icon,
+			EntityCategory: homeassistantdto.EntityCategoryConfig,
+		},
+	}
+
+	if err := t.discoveryClient.SendDiscoveryNumber(commandTopic, stateTopic, client, entityTypeName, meta); err != nil {
+		return fmt.Errorf("TxLimit SendDiscoveryMessage error: %w", err)
+	}
+
+	return nil
+}
+
+// GetState returns entity state.
+func (t *TxLimit) GetState(client dto.Client) (string, error) {
+	return strconv.FormatInt(client.TxLimit, 10), nil
+}
+
+// Consume consumes message with new tx limit in kbit/s from 0 to maxLimit, 0 means unlimited.
+func (t *TxLimit) Consume(client dto.Client, message string) error {
+	limit, err := strconv.ParseFloat(message, 64)
+	if err != nil || math.IsNaN(limit) || math.IsInf(limit, 0) || limit < 0 || limit > maxLimit {
+		return fmt.Errorf("TxLimit Consume error: invalid limit %q", message)
+	}
+
+	if err := t.accessUpdate.SetTrafficShape(client.Mac, client.RxLimit, int64(math.Round(limit))); err != nil {
+		return fmt.Errorf("client error while setting tx limit: %w", err)
+	}
+
+	return nil
+}
+
+// GetStateTopic returns state topic.
+func (t *TxLimit) GetStateTopic(client dto.Client) string {
+	return t.topics.StateTopic(client, entityTypeName)
+}
+
+// GetCommandTopic returns command topic.
+func (t *TxLimit) GetCommandTopic(client dto.Client) string {
+	return t.topics.CommandTopic(client, entityTypeName)
+}
@@ -0,0 +1,211 @@
+package txlimit
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	"keeneticToMqtt/internal/homeassistant/topic"
+	mock_txlimit "keeneticToMqtt/test/mocks/gomock/homeassistant/txlimit"
+)
+
+func TestTxLimit_SendDiscoveryMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		mac       = "mac"
+		name      = "name"
+		basetopic = "basetopic"
+	)
+	someErr := errors.New("some error")
+
+	client := dto.Client{Mac: mac, Name: name}
+	meta := homeassistantdto.NumberMeta{
+		Unit: unit,
+		Max:  maxLimit,
+		Step: 1,
+		Mode: mode,
+		EntityMeta: homeassistantdto.EntityMeta{
+			DeviceClass:    deviceClass,
+			Icon:           icon,
+			EntityCategory: homeassistantdto.EntityCategoryConfig,
+		},
+	}
+
+	tests := []struct {
+		name        string
+		expectedErr error
+		discovery   func() discovery
+	}{
+		{
+			name: "success send discovery message",
+			discovery: func() discovery {
+				discovery := mock_txlimit.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendDiscoveryNumber(
+						gomock.Eq("basetopic/mac_txlimit/command"),
+						gomock.Eq("basetopic/mac_txlimit/state"),
+						gomock.Eq(client),
+						gomock.Eq("txlimit"),
+						gomock.Eq(meta),
+					).
+					Return(nil)
+
+				return discovery
+			},
+		},
+		{
+			name: "error while send discovery message",
+			discovery: func() discovery {
+				discovery := mock_txlimit.NewMockdiscovery(ctrl)
+				discovery.EXPECT().
+					SendDiscoveryNumber(
+						gomock.Eq("basetopic/mac_txlimit/command"),
+						gomock.Eq("basetopic/mac_txlimit/state"),
+						gomock.Eq(client),
+						gomock.Eq("txlimit"),
+						gomock.Eq(meta),
+					).
+					Return(someErr)
+
+				return discovery
+			},
+			expectedErr: someErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txLimit := NewTxLimit(topic.NewTemplate(basetopic, ""), tt.discovery(), nil)
+			err := txLimit.SendDiscoveryMessage(client)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestTxLimit_GetState(t *testing.T) {
+	txLimit := NewTxLimit(topic.NewTemplate("basetopic", ""), nil, nil)
+
+	state, err := txLimit.GetState(dto.Client{RxLimit: 512, TxLimit: 1024})
+	assert.Nil(t, err)
+	assert.Equal(t, "1024", state)
+}
+
+func TestTxLimit_Consume(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const mac = "mac"
+	someErr := errors.New("some error")
+
+	client := dto.Client{Mac: mac, RxLimit: 512, TxLimit: 1024}
+
+	tests := []struct {
+		name           string
+		message        string
+		accessUpdate   func() accessUpdate
+		expectedErr    error
+		expectedErrStr string
+	}{
+		{
+			name:    "set new limit",
+			message: "2048",
+			accessUpdate: func() accessUpdate {
+				accessUpdate := mock_txlimit.NewMockaccessUpdate(ctrl)
+				accessUpdate.EXPECT().SetTrafficShape(mac, int64(512), int64(2048)).Return(nil)
+				return accessUpdate
+			},
+		},
+		{
+			name:    "set unlimited with float payload",
+			message: "0.0",
+			accessUpdate: func() accessUpdate {
+				accessUpdate := mock_txlimit.NewMockaccessUpdate(ctrl)
+				accessUpdate.EXPECT().SetTrafficShape(mac, int64(512), int64(0)).Return(nil)
+				return accessUpdate
+			},
+		},
+		{
+			name:    "invalid payload",
+			message: "abc",
+			accessUpdate: func() accessUpdate {
+				return mock_txlimit.NewMockaccessUpdate(ctrl)
+			},
+			expectedErrStr: "TxLimit Consume error: invalid limit",
+		},
+		{
+			name:    "negative limit",
+			message: "-1",
+			accessUpdate: func() accessUpdate {
+				return mock_txlimit.NewMockaccessUpdate(ctrl)
+			},
+			expectedErrStr: "TxLimit Consume error: invalid limit",
+		},
+		{
+			name:    "NaN limit",
+			message: "NaN",
+			accessUpdate: func() accessUpdate {
+				return mock_txlimit.NewMockaccessUpdate(ctrl)
+			},
+			expectedErrStr: "TxLimit Consume error: invalid limit",
+		},
+		{
+			name:    "infinite limit",
+			message: "+Inf",
+			accessUpdate: func() accessUpdate {
+				return mock_txlimit.NewMockaccessUpdate(ctrl)
+			},
+			expectedErrStr: "TxLimit Consume error: invalid limit",
+		},
+		{
+			name:    "limit above max",
+			message: "1000001",
+			accessUpdate: func() accessUpdate {
+				return mock_txlimit.NewMockaccessUpdate(ctrl)
+			},
+			expectedErrStr: "TxLimit Consume error: invalid limit",
+		},
+		{
+			name:    "error from client",
+			message: "2048",
+			accessUpdate: func() accessUpdate {
+				accessUpdate := mock_txlimit.NewMockaccessUpdate(ctrl)
+				accessUpdate.EXPECT().SetTrafficShape(mac, int64(512), int64(2048)).Return(someErr)
+				return accessUpdate
+			},
+			expectedErr: someErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txLimit := NewTxLimit(topic.NewTemplate("basetopic", ""), nil, tt.accessUpdate())
+			err := txLimit.Consume(client, tt.message)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else if tt.expectedErrStr != "" {
+				assert.Regexp(t, tt.expectedErrStr+".*", err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestTxLimit_GetStateTopic(t *testing.T) {
+	txLimit := NewTxLimit(topic.NewTemplate("basetopic", ""), nil, nil)
+	assert.Equal(t, "basetopic/aa_bb_txlimit/state", txLimit.GetStateTopic(dto.Client{Mac: "aa:bb"}))
+}
+
+func TestTxLimit_GetCommandTopic(t *testing.T) {
+	txLimit := NewTxLimit(topic.NewTemplate("basetopic", ""), nil, nil)
+	assert.Equal(t, "basetopic/aa_bb_txlimit/command", txLimit.GetCommandTopic(dto.Client{Mac: "aa:bb"}))
+}
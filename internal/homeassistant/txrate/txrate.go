@@ -0,0 +1,83 @@
+package txrate
+
+import (
+	"fmt"
+	"strconv"
+
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	"keeneticToMqtt/internal/homeassistant/topic"
+)
+
+//go:generate mockgen -source=txrate.go -destination=../../../test/mocks/gomock/homeassistant/txrate/txrate.go
+
+const (
+	entityTypeName = "txrate"
+	unit           = "B/s"
+	deviceClass    = "data_rate"
+	stateClass     = "measurement"
+	icon           = "mdi:upload"
+	precision      = 0
+)
+
+type (
+	discovery interface {
+		SendDiscoverySensor(stateTopic string, client dto.Client, entityType string, meta homeassistantdto.SensorMeta) error
+	}
+)
+
+// TxRate struct for handle home assistant client tx traffic rate entities.
+type TxRate struct {
+	topics          *topic.Template
+	discoveryClient discovery
+}
+
+// NewTxRate creates new TxRate.
+func NewTxRate(
+	topics *topic.Template,
+	discoveryClient discovery,
+) *TxRate {
+	return &TxRate{
+		topics:          topics,
+		discoveryClient: discoveryClient,
+	}
+}
+
+// SendDiscoveryMessage sends homeassistant discovery message.
+func (p *TxRate) SendDiscoveryMessage(client dto.Client) error {
+	stateTopic := p.GetStateTopic(client)
+	meta := homeassistantdto.SensorMeta{
+		Unit: unit,
+		EntityMeta: homeassistantdto.EntityMeta{
+			DeviceClass:               deviceClass,
+			StateClass:                stateClass,
+			Icon:                      icon,
+			SuggestedDisplayPrecision: homeassistantdto.Precision(precision),
+		},
+	}
+	if err := p.discoveryClient.SendDiscoverySensor(stateTopic, client, entityTypeName, meta); err != nil {
+		return fmt.Errorf("TxRate SendDiscoveryMessage error: %w", err)
+	}
+
+	return nil
+}
+
+// GetState returns entity state.
+func (p *TxRate) GetState(client dto.Client) (string, error) {
+	return strconv.Itoa(int(client.TxRate)), nil
+}
+
+// Consume consumes message.
+func (p *TxRate) Consume(_ dto.Client, _ string) error {
+	return nil
+}
+
+// GetStateTopic returns state topic.
+func (p *TxRate) GetStateTopic(client dto.Client) string {
+	return p.topics.StateTopic(client, entityTypeName)
+}
+
+// GetCommandTopic returns command topic.
+func (p *TxRate) GetCommandTopic(_ dto.Client) string {
+	return ""
+}
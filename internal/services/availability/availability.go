@@ -0,0 +1,78 @@
+package availability
+
+import (
+	"sync"
+)
+
+//go:generate mockgen -source=availability.go -destination=../../../test/mocks/gomock/services/availability/availability.go
+
+const (
+	// Online availability payload.
+	Online = "online"
+	// Offline availability payload.
+	Offline = "offline"
+)
+
+type mqttClient interface {
+	SendMessage(topic, message string, retained bool)
+}
+
+// BridgeTopic returns topic with keeneticToMqtt availability. Mqtt last will sets it offline.
+func BridgeTopic(baseTopic string) string {
+	return baseTopic + "/bridge/state"
+}
+
+// RouterTopic returns topic with keenetic router availability.
+func RouterTopic(baseTopic string) string {
+	return baseTopic + "/bridge/router"
+}
+
+// Availability publishes keenetic router availability.
+type Availability struct {
+	routerTopic string
+	mqtt        mqttClient
+
+	// routerAvailable last published router availability, nil if nothing published yet.
+	routerAvailable *bool
+	mutex           sync.Mutex
+}
+
+// NewAvailability creates new Availability.
+func NewAvailability(baseTopic string, mqtt mqttClient) *Availability {
+	return &Availability{
+		routerTopic: RouterTopic(baseTopic),
+		mqtt:        mqtt,
+	}
+}
+
+// SetRouterAvailable publishes router availability if it changes.
+func (a *Availability) SetRouterAvailable(available bool) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.routerAvailable != nil && *a.routerAvailable == available {
+		return
+	}
+	a.routerAvailable = &available
+	a.publish(available)
+}
+
+// Republish publishes last router availability again, if it is known.
+// Publishing fails while mqtt is disconnected, so it must be called after every mqtt connection.
+func (a *Availability) Republish() {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.routerAvailable == nil {
+		return
+	}
+	a.publish(*a.routerAvailable)
+}
+
+func (a *Availability) publish(available bool) {
+	payload := Offline
+	if available {
+		payload = Online
+	}
+	a.mqtt.SendMessage(a.routerTopic, payload, true)
+}
@@ -0,0 +1,84 @@
+package availability
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	mock_availability "keeneticToMqtt/test/mocks/gomock/services/availability"
+)
+
+func TestTopics(t *testing.T) {
+	assert.Equal(t, "base/bridge/state", BridgeTopic("base"))
+	assert.Equal(t, "base/bridge/router", RouterTopic("base"))
+}
+
+func TestAvailability_SetRouterAvailable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name      string
+		available []bool
+		mqtt      func() mqttClient
+	}{
+		{
+			name:      "first call publishes availability",
+			available: []bool{true},
+			mqtt: func() mqttClient {
+				mqtt := mock_availability.NewMockmqttClient(ctrl)
+				mqtt.EXPECT().SendMessage("base/bridge/router", Online, true)
+				return mqtt
+			},
+		},
+		{
+			name:      "same availability is published once",
+			available: []bool{false, false},
+			mqtt: func() mqttClient {
+				mqtt := mock_availability.NewMockmqttClient(ctrl)
+				mqtt.EXPECT().SendMessage("base/bridge/router", Offline, true)
+				return mqtt
+			},
+		},
+		{
+			name:      "changed availability is published",
+			available: []bool{true, false, true},
+			mqtt: func() mqttClient {
+				mqtt := mock_availability.NewMockmqttClient(ctrl)
+				gomock.InOrder(
+					mqtt.EXPECT().SendMessage("base/bridge/router", Online, true),
+					mqtt.EXPECT().SendMessage("base/bridge/router", Offline, true),
+					mqtt.EXPECT().SendMessage("base/bridge/router", Online, true),
+				)
+				return mqtt
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			availability := NewAvailability("base", tt.mqtt())
+			for _, available := range tt.available {
+				availability.SetRouterAvailable(available)
+			}
+		})
+	}
+}
+
+func TestAvailability_Republish(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mqtt := mock_availability.NewMockmqttClient(ctrl)
+	availability := NewAvailability("base", mqtt)
+
+	// nothing to republish before first availability
+	availability.Republish()
+
+	gomock.InOrder(
+		mqtt.EXPECT().SendMessage("base/bridge/router", Offline, true),
+		mqtt.EXPECT().SendMessage("base/bridge/router", Offline, true),
+	)
+	availability.SetRouterAvailable(false)
+	availability.Republish()
+}
@@ -2,6 +2,8 @@ package clientlist
 
 import (
 	"fmt"
+	"sync"
+	"time"
 
 	"keeneticToMqtt/internal/dto"
 	"keeneticToMqtt/internal/dto/homeassistantdto"
@@ -10,38 +12,40 @@ import (
 
 //go:generate mockgen -source=clientlist.go -destination=../../../test/mocks/gomock/services/clientlist/clientlist.go
 
+const linkUp = "up"
+
 type listClient interface {
-	GetDeviceList() ([]keeneticdto.DeviceInfoResponse, error)
-	GetClientPolicyList() ([]keeneticdto.DevicePolicy, error)
+	GetClientLists() ([]keeneticdto.DeviceInfoResponse, []keeneticdto.DevicePolicy, error)
 }
 
 // ClientList struct for building keenetic client list.
 type ClientList struct {
-	listClient   listClient
-	macWhiteList map[string]bool
+	listClient      listClient
+	filter          clientFilter
+	awayTimeout     time.Duration
+	lastActive      map[string]time.Time
+	lastActiveMutex sync.Mutex
+	now             func() time.Time
 }
 
 // NewClientList creates new ClientList.
-func NewClientList(listClient listClient, macWhiteList []string) *ClientList {
-	macMap := make(map[string]bool, len(macWhiteList))
-	for _, mac := range macWhiteList {
-		macMap[mac] = true
-	}
+// mode is one of dto.ClientMode* constants, empty mode means dto.ClientModeWhitelist.
+// awayTimeout is a grace period after which not active client is considered offline.
+func NewClientList(listClient listClient, mode string, whiteList, blackList []string, awayTimeout time.Duration) *ClientList {
 	return &ClientList{
-		listClient:   listClient,
-		macWhiteList: macMap,
+		listClient:  listClient,
+		filter:      newClientFilter(mode, whiteList, blackList),
+		awayTimeout: awayTimeout,
+		lastActive:  make(map[string]time.Time),
+		now:         time.Now,
 	}
 }
 
 // GetClientList returns list of dto.Client.
 func (l *ClientList) GetClientList() ([]dto.Client, error) {
-	deviceList, err := l.listClient.GetDeviceList()
-	if err != nil {
-		return nil, fmt.Errorf("ClientList client error while getting device list: %w", err)
-	}
-	policyList, err := l.listClient.GetClientPolicyList()
+	deviceList, policyList, err := l.listClient.GetClientLists()
 	if err != nil {
-		return nil, fmt.Errorf("ClientList client error while getting policy list: %w", err)
+		return nil, fmt.Errorf("ClientList client error while getting client lists: %w", err)
 	}
 
 	policyMap := make(map[string]keeneticdto.DevicePolicy, len(policyList))
@@ -51,14 +55,37 @@ func (l *ClientList) GetClientList() ([]dto.Client, error) {
 
 	clientList := make([]dto.Client, 0)
 	for _, device := range deviceList {
-		if !l.macWhiteList[device.Mac] {
+		if !l.filter.match(device) {
 			continue
 		}
 		client := dto.Client{
-			Mac:     device.Mac,
-			Name:    device.Name,
-			TxBytes: device.TxBytes,
-			RxBytes: device.RxBytes,
+			Mac:      device.Mac,
+			Name:     clientName(device),
+			TxBytes:  device.TxBytes,
+			RxBytes:  device.RxBytes,
+			Active:   device.Active,
+			Link:     device.Link,
+			LastSeen: device.LastSeen,
+			Uptime:   device.Uptime,
+			Online:   l.isOnline(device),
+			RxLimit:  device.TrafficShape.RX,
+			TxLimit:  device.TrafficShape.TX,
+
+			SSID:           device.SSID,
+			AP:             device.AP,
+			RSSI:           device.RSSI,
+			LinkRate:       device.TxRate,
+			MCS:            device.MCS,
+			SpatialStreams: device.TxSS,
+
+			IP:         device.IP,
+			Hostname:   device.Hostname,
+			Interface:  device.Interface.Name,
+			Registered: device.Registered,
+			Access:     device.Access,
+			Priority:   device.Priority,
+			Security:   device.Security,
+			FirstSeen:  device.FirstSeen,
 		}
 
 		policy := policyMap[device.Mac]
@@ -70,8 +97,69 @@ func (l *ClientList) GetClientList() ([]dto.Client, error) {
 
 		client.Permit = policy.Permit
 
+		if device.Schedule != "" {
+			client.Schedule = device.Schedule
+		} else {
+			client.Schedule = homeassistantdto.NoneSchedule
+		}
+
 		clientList = append(clientList, client)
 	}
+	l.pruneLastActive(clientList)
 
 	return clientList, nil
 }
+
+// clientName returns keenetic client name. Unregistered hosts may have no name, then hostname or mac is used,
+// so entity names and client name topics are never empty.
+func clientName(device keeneticdto.DeviceInfoResponse) string {
+	if device.Name != "" {
+		return device.Name
+	}
+	if device.Hostname != "" {
+		return device.Hostname
+	}
+
+	return device.Mac
+}
+
+// isOnline checks if device is connected now or was connected not earlier than awayTimeout ago.
+// Keenetic last seen seconds keep the grace period after restart, when there is no remembered activity.
+func (l *ClientList) isOnline(device keeneticdto.DeviceInfoResponse) bool {
+	l.lastActiveMutex.Lock()
+	defer l.lastActiveMutex.Unlock()
+
+	now := l.now()
+	if device.Active && (device.Link == "" || device.Link == linkUp) {
+		l.lastActive[device.Mac] = now
+		return true
+	}
+
+	if device.LastSeen > 0 && time.Duration(device.LastSeen)*time.Second < l.awayTimeout {
+		return true
+	}
+
+	lastActive, ok := l.lastActive[device.Mac]
+	if !ok {
+		return false
+	}
+
+	return now.Sub(lastActive) < l.awayTimeout
+}
+
+// pruneLastActive forgets activity of clients, which are not in client list anymore.
+func (l *ClientList) pruneLastActive(clients []dto.Client) {
+	macs := make(map[string]bool, len(clients))
+	for _, client := range clients {
+		macs[client.Mac] = true
+	}
+
+	l.lastActiveMutex.Lock()
+	defer l.lastActiveMutex.Unlock()
+
+	for mac := range l.lastActive {
+		if !macs[mac] {
+			delete(l.lastActive, mac)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package clientlist
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"keeneticToMqtt/internal/dto"
@@ -31,6 +32,7 @@ func TestClientList_GetClientList(t *testing.T) {
 	tests := []struct {
 		name        string
 		listClient  func() listClient
+		mode        string
 		whitelist   []string
 		expected    []dto.Client
 		expectedErr error
@@ -39,14 +41,13 @@ func TestClientList_GetClientList(t *testing.T) {
 			name: "success list building",
 			listClient: func() listClient {
 				listClient := mock_clientlist.NewMocklistClient(ctrl)
-				listClient.EXPECT().GetDeviceList().Return([]keeneticdto.DeviceInfoResponse{
+				listClient.EXPECT().GetClientLists().Return([]keeneticdto.DeviceInfoResponse{
 					{
-						Mac:  mac1,
-						Name: name1,
+						Mac:      mac1,
+						Name:     name1,
+						Schedule: "schedule",
 					},
-				}, nil)
-
-				listClient.EXPECT().GetClientPolicyList().Return([]keeneticdto.DevicePolicy{
+				}, []keeneticdto.DevicePolicy{
 					{
 						Mac:    mac1,
 						Policy: &policy,
@@ -59,10 +60,79 @@ func TestClientList_GetClientList(t *testing.T) {
 			whitelist: []string{mac1},
 			expected: []dto.Client{
 				{
-					Mac:    mac1,
-					Policy: policy,
-					Name:   name1,
-					Permit: true,
+					Mac:      mac1,
+					Policy:   policy,
+					Schedule: "schedule",
+					Name:     name1,
+					Permit:   true,
+				},
+			},
+		},
+		{
+			name: "active client",
+			listClient: func() listClient {
+				listClient := mock_clientlist.NewMocklistClient(ctrl)
+				listClient.EXPECT().GetClientLists().Return([]keeneticdto.DeviceInfoResponse{
+					{
+						Mac:      mac1,
+						Name:     name1,
+						Active:   true,
+						Link:     "up",
+						LastSeen: 1,
+						Uptime:   100,
+						SSID:     "ssid",
+						AP:       "WifiMaster0/AccessPoint0",
+						RSSI:     -50,
+						TxRate:   433,
+						MCS:      9,
+						TxSS:     2,
+						TrafficShape: keeneticdto.DeviceInfoTrafficShape{
+							RX: 1024,
+							TX: 512,
+						},
+						IP:         "192.168.1.10",
+						Hostname:   "hostname",
+						Interface:  keeneticdto.DeviceInfoInterface{ID: "Bridge0", Name: "Home"},
+						Registered: true,
+						Access:     "permit",
+						Priority:   6,
+						Security:   "wpa2-psk",
+						FirstSeen:  1000,
+					},
+				}, []keeneticdto.DevicePolicy{}, nil)
+
+				return listClient
+			},
+			whitelist: []string{mac1},
+			expected: []dto.Client{
+				{
+					Mac:      mac1,
+					Policy:   homeassistantdto.NonePolicy,
+					Schedule: homeassistantdto.NoneSchedule,
+					Name:     name1,
+					Active:   true,
+					Link:     "up",
+					LastSeen: 1,
+					Uptime:   100,
+					Online:   true,
+					RxLimit:  1024,
+					TxLimit:  512,
+
+					SSID:           "ssid",
+					AP:             "WifiMaster0/AccessPoint0",
+					RSSI:           -50,
+					LinkRate:       433,
+					MCS:            9,
+					SpatialStreams: 2,
+
+					IP:         "192.168.1.10",
+					Hostname:   "hostname",
+					Interface:  "Home",
+					Registered: true,
+					Access:     "permit",
+					Priority:   6,
+					Security:   "wpa2-psk",
+					FirstSeen:  1000,
 				},
 			},
 		},
@@ -70,14 +140,12 @@ func TestClientList_GetClientList(t *testing.T) {
 			name: "mac is not in white list",
 			listClient: func() listClient {
 				listClient := mock_clientlist.NewMocklistClient(ctrl)
-				listClient.EXPECT().GetDeviceList().Return([]keeneticdto.DeviceInfoResponse{
+				listClient.EXPECT().GetClientLists().Return([]keeneticdto.DeviceInfoResponse{
 					{
 						Mac:  mac1,
 						Name: name1,
 					},
-				}, nil)
-
-				listClient.EXPECT().GetClientPolicyList().Return([]keeneticdto.DevicePolicy{
+				}, []keeneticdto.DevicePolicy{
 					{
 						Mac:    mac1,
 						Policy: &policy,
@@ -91,27 +159,39 @@ func TestClientList_GetClientList(t *testing.T) {
 			expected:  []dto.Client{},
 		},
 		{
-			name: "GetDeviceList error",
+			name: "registered mode",
 			listClient: func() listClient {
 				listClient := mock_clientlist.NewMocklistClient(ctrl)
-				listClient.EXPECT().GetDeviceList().Return(nil, someErr)
+				listClient.EXPECT().GetClientLists().Return([]keeneticdto.DeviceInfoResponse{
+					{
+						Mac:        mac1,
+						Name:       name1,
+						Registered: true,
+					},
+					{
+						Mac:  "mac2",
+						Name: "name2",
+					},
+				}, []keeneticdto.DevicePolicy{}, nil)
 
 				return listClient
 			},
-			expectedErr: someErr,
+			mode: dto.ClientModeRegistered,
+			expected: []dto.Client{
+				{
+					Mac:        mac1,
+					Policy:     homeassistantdto.NonePolicy,
+					Schedule:   homeassistantdto.NoneSchedule,
+					Name:       name1,
+					Registered: true,
+				},
+			},
 		},
 		{
-			name: "GetClientPolicyList error",
+			name: "GetClientLists error",
 			listClient: func() listClient {
 				listClient := mock_clientlist.NewMocklistClient(ctrl)
-				listClient.EXPECT().GetDeviceList().Return([]keeneticdto.DeviceInfoResponse{
-					{
-						Mac:  mac1,
-						Name: name1,
-					},
-				}, nil)
-
-				listClient.EXPECT().GetClientPolicyList().Return(nil, someErr)
+				listClient.EXPECT().GetClientLists().Return(nil, nil, someErr)
 
 				return listClient
 			},
@@ -121,14 +201,12 @@ func TestClientList_GetClientList(t *testing.T) {
 			name: "empty policy",
 			listClient: func() listClient {
 				listClient := mock_clientlist.NewMocklistClient(ctrl)
-				listClient.EXPECT().GetDeviceList().Return([]keeneticdto.DeviceInfoResponse{
+				listClient.EXPECT().GetClientLists().Return([]keeneticdto.DeviceInfoResponse{
 					{
 						Mac:  mac1,
 						Name: name1,
 					},
-				}, nil)
-
-				listClient.EXPECT().GetClientPolicyList().Return([]keeneticdto.DevicePolicy{
+				}, []keeneticdto.DevicePolicy{
 					{
 						Mac:    mac1,
 						Policy: &emptyPolicy,
@@ -141,10 +219,11 @@ func TestClientList_GetClientList(t *testing.T) {
 			whitelist: []string{mac1},
 			expected: []dto.Client{
 				{
-					Mac:    mac1,
-					Policy: homeassistantdto.NonePolicy,
-					Name:   name1,
-					Permit: true,
+					Mac:      mac1,
+					Policy:   homeassistantdto.NonePolicy,
+					Schedule: homeassistantdto.NoneSchedule,
+					Name:     name1,
+					Permit:   true,
 				},
 			},
 		},
@@ -152,14 +231,12 @@ func TestClientList_GetClientList(t *testing.T) {
 			name: "nil policy",
 			listClient: func() listClient {
 				listClient := mock_clientlist.NewMocklistClient(ctrl)
-				listClient.EXPECT().GetDeviceList().Return([]keeneticdto.DeviceInfoResponse{
+				listClient.EXPECT().GetClientLists().Return([]keeneticdto.DeviceInfoResponse{
 					{
 						Mac:  mac1,
 						Name: name1,
 					},
-				}, nil)
-
-				listClient.EXPECT().GetClientPolicyList().Return([]keeneticdto.DevicePolicy{
+				}, []keeneticdto.DevicePolicy{
 					{
 						Mac:    mac1,
 						Policy: nil,
@@ -172,10 +249,11 @@ func TestClientList_GetClientList(t *testing.T) {
 			whitelist: []string{mac1},
 			expected: []dto.Client{
 				{
-					Mac:    mac1,
-					Policy: homeassistantdto.NonePolicy,
-					Name:   name1,
-					Permit: true,
+					Mac:      mac1,
+					Policy:   homeassistantdto.NonePolicy,
+					Schedule: homeassistantdto.NoneSchedule,
+					Name:     name1,
+					Permit:   true,
 				},
 			},
 		},
@@ -183,23 +261,68 @@ func TestClientList_GetClientList(t *testing.T) {
 			name: "no policy in map",
 			listClient: func() listClient {
 				listClient := mock_clientlist.NewMocklistClient(ctrl)
-				listClient.EXPECT().GetDeviceList().Return([]keeneticdto.DeviceInfoResponse{
+				listClient.EXPECT().GetClientLists().Return([]keeneticdto.DeviceInfoResponse{
 					{
 						Mac:  mac1,
 						Name: name1,
 					},
-				}, nil)
+				}, []keeneticdto.DevicePolicy{}, nil)
+
+				return listClient
+			},
+			whitelist: []string{mac1},
+			expected: []dto.Client{
+				{
+					Mac:      mac1,
+					Policy:   homeassistantdto.NonePolicy,
+					Schedule: homeassistantdto.NoneSchedule,
+					Name:     name1,
+				},
+			},
+		},
+		{
+			name: "unregistered client without name is named by hostname",
+			listClient: func() listClient {
+				listClient := mock_clientlist.NewMocklistClient(ctrl)
+				listClient.EXPECT().GetClientLists().Return([]keeneticdto.DeviceInfoResponse{
+					{
+						Mac:      mac1,
+						Hostname: "hostname",
+					},
+				}, []keeneticdto.DevicePolicy{}, nil)
 
-				listClient.EXPECT().GetClientPolicyList().Return([]keeneticdto.DevicePolicy{}, nil)
+				return listClient
+			},
+			whitelist: []string{mac1},
+			expected: []dto.Client{
+				{
+					Mac:      mac1,
+					Policy:   homeassistantdto.NonePolicy,
+					Schedule: homeassistantdto.NoneSchedule,
+					Name:     "hostname",
+					Hostname: "hostname",
+				},
+			},
+		},
+		{
+			name: "unregistered client without name and hostname is named by mac",
+			listClient: func() listClient {
+				listClient := mock_clientlist.NewMocklistClient(ctrl)
+				listClient.EXPECT().GetClientLists().Return([]keeneticdto.DeviceInfoResponse{
+					{
+						Mac: mac1,
+					},
+				}, []keeneticdto.DevicePolicy{}, nil)
 
 				return listClient
 			},
 			whitelist: []string{mac1},
 			expected: []dto.Client{
 				{
-					Mac:    mac1,
-					Policy: homeassistantdto.NonePolicy,
-					Name:   name1,
+					Mac:      mac1,
+					Policy:   homeassistantdto.NonePolicy,
+					Schedule: homeassistantdto.NoneSchedule,
+					Name:     mac1,
 				},
 			},
 		},
@@ -209,7 +332,10 @@ func TestClientList_GetClientList(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			clientList := NewClientList(
 				tt.listClient(),
+				tt.mode,
 				tt.whitelist,
+				nil,
+				time.Minute,
 			)
 			res, err := clientList.GetClientList()
 			if tt.expectedErr != nil {
@@ -222,3 +348,116 @@ func TestClientList_GetClientList(t *testing.T) {
 		})
 	}
 }
+
+func TestClientList_isOnline(t *testing.T) {
+	const (
+		mac         = "mac"
+		awayTimeout = time.Minute
+	)
+
+	now := time.Now()
+
+	tests := []struct {
+		name               string
+		device             keeneticdto.DeviceInfoResponse
+		lastActive         map[string]time.Time
+		expected           bool
+		expectedLastActive map[string]time.Time
+	}{
+		{
+			name:               "active device with link up",
+			device:             keeneticdto.DeviceInfoResponse{Mac: mac, Active: true, Link: "up"},
+			lastActive:         map[string]time.Time{},
+			expected:           true,
+			expectedLastActive: map[string]time.Time{mac: now},
+		},
+		{
+			name:               "active wired device without link",
+			device:             keeneticdto.DeviceInfoResponse{Mac: mac, Active: true},
+			lastActive:         map[string]time.Time{},
+			expected:           true,
+			expectedLastActive: map[string]time.Time{mac: now},
+		},
+		{
+			name:               "active device with link down",
+			device:             keeneticdto.DeviceInfoResponse{Mac: mac, Active: true, Link: "down"},
+			lastActive:         map[string]time.Time{},
+			expected:           false,
+			expectedLastActive: map[string]time.Time{},
+		},
+		{
+			name:               "not active device never seen",
+			device:             keeneticdto.DeviceInfoResponse{Mac: mac},
+			lastActive:         map[string]time.Time{},
+			expected:           false,
+			expectedLastActive: map[string]time.Time{},
+		},
+		{
+			name:               "not active device inside away timeout",
+			device:             keeneticdto.DeviceInfoResponse{Mac: mac},
+			lastActive:         map[string]time.Time{mac: now.Add(-awayTimeout / 2)},
+			expected:           true,
+			expectedLastActive: map[string]time.Time{mac: now.Add(-awayTimeout / 2)},
+		},
+		{
+			name:               "not active device seen by keenetic inside away timeout",
+			device:             keeneticdto.DeviceInfoResponse{Mac: mac, LastSeen: 30},
+			lastActive:         map[string]time.Time{},
+			expected:           true,
+			expectedLastActive: map[string]time.Time{},
+		},
+		{
+			name:               "not active device seen by keenetic after away timeout",
+			device:             keeneticdto.DeviceInfoResponse{Mac: mac, LastSeen: 60},
+			lastActive:         map[string]time.Time{},
+			expected:           false,
+			expectedLastActive: map[string]time.Time{},
+		},
+		{
+			name:               "not active device after away timeout",
+			device:             keeneticdto.DeviceInfoResponse{Mac: mac},
+			lastActive:         map[string]time.Time{mac: now.Add(-awayTimeout)},
+			expected:           false,
+			expectedLastActive: map[string]time.Time{mac: now.Add(-awayTimeout)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientList := NewClientList(nil, "", nil, nil, awayTimeout)
+			clientList.lastActive = tt.lastActive
+			clientList.now = func() time.Time {
+				return now
+			}
+
+			assert.Equal(t, tt.expected, clientList.isOnline(tt.device))
+			assert.Equal(t, tt.expectedLastActive, clientList.lastActive)
+		})
+	}
+}
+
+func TestClientList_pruneLastActive(t *testing.T) {
+	const (
+		mac1 = "mac1"
+		mac2 = "mac2"
+	)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	listClient := mock_clientlist.NewMocklistClient(ctrl)
+	listClient.EXPECT().GetClientLists().Return([]keeneticdto.DeviceInfoResponse{
+		{Mac: mac1, Active: true},
+	}, []keeneticdto.DevicePolicy{}, nil)
+
+	now := time.Now()
+	clientList := NewClientList(listClient, "", []string{mac1, mac2}, nil, time.Minute)
+	clientList.now = func() time.Time {
+		return now
+	}
+	clientList.lastActive = map[string]time.Time{mac1: now, mac2: now}
+
+	_, err := clientList.GetClientList()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]time.Time{mac1: now}, clientList.lastActive)
+}
@@ -0,0 +1,86 @@
+package clientlist
+
+import (
+	"path"
+	"strings"
+
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/keeneticdto"
+)
+
+// ouiLength length of mac address OUI prefix, for example "aa:bb:cc".
+const ouiLength = 8
+
+// clientFilter decides which keenetic clients are handled according to discovery mode.
+// Whitelist and blacklist items can be mac addresses, OUI prefixes ("aa:bb:cc")
+// or glob patterns ("aa:bb:cc:*"). Blacklist has priority over any mode.
+type clientFilter struct {
+	mode      string
+	whiteList []string
+	blackList []string
+}
+
+func newClientFilter(mode string, whiteList, blackList []string) clientFilter {
+	if mode == "" {
+		mode = dto.ClientModeWhitelist
+	}
+
+	return clientFilter{
+		mode:      mode,
+		whiteList: normalizePatterns(whiteList),
+		blackList: normalizePatterns(blackList),
+	}
+}
+
+func (f clientFilter) match(device keeneticdto.DeviceInfoResponse) bool {
+	mac := strings.ToLower(device.Mac)
+	if matchAny(mac, f.blackList) {
+		return false
+	}
+	if matchAny(mac, f.whiteList) {
+		return true
+	}
+
+	switch f.mode {
+	case dto.ClientModeAll:
+		return true
+	case dto.ClientModeRegistered:
+		return device.Registered
+	default:
+		return false
+	}
+}
+
+func normalizePatterns(patterns []string) []string {
+	res := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		res = append(res, strings.ToLower(strings.TrimSpace(pattern)))
+	}
+
+	return res
+}
+
+func matchAny(mac string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matchPattern(mac, pattern) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchPattern(mac, pattern string) bool {
+	if pattern == mac {
+		return true
+	}
+	if len(pattern) == ouiLength && strings.HasPrefix(mac, pattern+":") {
+		return true
+	}
+	if strings.ContainsAny(pattern, "*?[") {
+		ok, err := path.Match(pattern, mac)
+		return err == nil && ok
+	}
+
+	return false
+}
@@ -0,0 +1,107 @@
+package clientlist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/keeneticdto"
+)
+
+func TestClientFilter_match(t *testing.T) {
+	const (
+		mac        = "AA:BB:CC:00:00:01"
+		anotherMac = "dd:ee:ff:00:00:02"
+	)
+
+	tests := []struct {
+		name      string
+		mode      string
+		whiteList []string
+		blackList []string
+		device    keeneticdto.DeviceInfoResponse
+		expected  bool
+	}{
+		{
+			name:      "default mode, mac in whitelist",
+			whiteList: []string{"aa:bb:cc:00:00:01"},
+			device:    keeneticdto.DeviceInfoResponse{Mac: mac},
+			expected:  true,
+		},
+		{
+			name:      "whitelist mode, mac is not in whitelist",
+			mode:      dto.ClientModeWhitelist,
+			whiteList: []string{mac},
+			device:    keeneticdto.DeviceInfoResponse{Mac: anotherMac, Registered: true},
+			expected:  false,
+		},
+		{
+			name:      "whitelist mode, OUI prefix",
+			mode:      dto.ClientModeWhitelist,
+			whiteList: []string{"aa:bb:cc"},
+			device:    keeneticdto.DeviceInfoResponse{Mac: mac},
+			expected:  true,
+		},
+		{
+			name:      "whitelist mode, glob pattern",
+			mode:      dto.ClientModeWhitelist,
+			whiteList: []string{"aa:bb:*"},
+			device:    keeneticdto.DeviceInfoResponse{Mac: mac},
+			expected:  true,
+		},
+		{
+			name:      "whitelist mode, invalid glob pattern",
+			mode:      dto.ClientModeWhitelist,
+			whiteList: []string{"aa:bb:[*"},
+			device:    keeneticdto.DeviceInfoResponse{Mac: mac},
+			expected:  false,
+		},
+		{
+			name:     "registered mode, registered client",
+			mode:     dto.ClientModeRegistered,
+			device:   keeneticdto.DeviceInfoResponse{Mac: mac, Registered: true},
+			expected: true,
+		},
+		{
+			name:     "registered mode, not registered client",
+			mode:     dto.ClientModeRegistered,
+			device:   keeneticdto.DeviceInfoResponse{Mac: mac},
+			expected: false,
+		},
+		{
+			name:      "registered mode, not registered client from whitelist",
+			mode:      dto.ClientModeRegistered,
+			whiteList: []string{mac},
+			device:    keeneticdto.DeviceInfoResponse{Mac: mac},
+			expected:  true,
+		},
+		{
+			name:     "all mode",
+			mode:     dto.ClientModeAll,
+			device:   keeneticdto.DeviceInfoResponse{Mac: anotherMac},
+			expected: true,
+		},
+		{
+			name:      "all mode, mac in blacklist",
+			mode:      dto.ClientModeAll,
+			blackList: []string{anotherMac},
+			device:    keeneticdto.DeviceInfoResponse{Mac: anotherMac},
+			expected:  false,
+		},
+		{
+			name:      "blacklist has priority over whitelist",
+			mode:      dto.ClientModeWhitelist,
+			whiteList: []string{mac},
+			blackList: []string{"aa:bb:cc:*"},
+			device:    keeneticdto.DeviceInfoResponse{Mac: mac},
+			expected:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := newClientFilter(tt.mode, tt.whiteList, tt.blackList)
+			assert.Equal(t, tt.expected, filter.match(tt.device))
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package clientstate
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"keeneticToMqtt/internal/dto"
+)
+
+//go:generate mockgen -source=clientstate.go -destination=../../../test/mocks/gomock/services/clientstate/clientstate.go
+
+type mqttClient interface {
+	SendMessage(topic, message string, retained bool)
+}
+
+// host is item of bridge client list.
+type host struct {
+	Mac      string `json:"mac"`
+	Name     string `json:"name"`
+	IP       string `json:"ip"`
+	Hostname string `json:"hostname"`
+	Online   bool   `json:"online"`
+	Topic    string `json:"topic"`
+}
+
+// ClientTopic returns topic with aggregated json state of client.
+func ClientTopic(baseTopic string, client dto.Client) string {
+	return baseTopic + "/" + strings.ReplaceAll(client.Mac, ":", "_")
+}
+
+// ClientsTopic returns topic with list of all known clients.
+func ClientsTopic(baseTopic string) string {
+	return baseTopic + "/bridge/clients"
+}
+
+// Publisher publishes aggregated client states for mqtt consumers other than home assistant,
+// so they don't need to subscribe to every entity state topic.
+type Publisher struct {
+	baseTopic string
+	mqtt      mqttClient
+
+	// states last published client states by topic.
+	states map[string]string
+	// clients last published client list, empty if nothing published yet.
+	clients string
+	mutex   sync.Mutex
+}
+
+// NewPublisher creates new Publisher.
+func NewPublisher(baseTopic string, mqtt mqttClient) *Publisher {
+	return &Publisher{
+		baseTopic: baseTopic,
+		mqtt:      mqtt,
+		states:    make(map[string]string),
+	}
+}
+
+// Publish publishes changed client states and client list as retained messages.
+// States of clients, which are not in clients anymore, are removed.
+func (p *Publisher) Publish(clients []dto.Client) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	sorted := make([]dto.Client, len(clients))
+	copy(sorted, clients)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Mac < sorted[j].Mac
+	})
+
+	actual := make(map[string]bool, len(sorted))
+	hosts := make([]host, 0, len(sorted))
+	for _, client := range sorted {
+		topic := ClientTopic(p.baseTopic, client)
+		actual[topic] = true
+		hosts = append(hosts, host{
+			Mac:      client.Mac,
+			Name:     client.Name,
+			IP:       client.IP,
+			Hostname: client.Hostname,
+			Online:   client.Online,
+			Topic:    topic,
+		})
+
+		state, err := json.Marshal(client)
+		if err != nil {
+			return fmt.Errorf("Publisher marshal client state error: %w", err)
+		}
+		if p.states[topic] != string(state) {
+			p.states[topic] = string(state)
+			p.mqtt.SendMessage(topic, string(state), true)
+		}
+	}
+
+	for topic := range p.states {
+		if !actual[topic] {
+			delete(p.states, topic)
+			p.mqtt.SendMessage(topic, "", true)
+		}
+	}
+
+	list, err := json.Marshal(hosts)
+	if err != nil {
+		return fmt.Errorf("Publisher marshal client list error: %w", err)
+	}
+	if p.clients != string(list) {
+		p.clients = string(list)
+		p.mqtt.SendMessage(ClientsTopic(p.baseTopic), string(list), true)
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package clientstate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"keeneticToMqtt/internal/dto"
+	mock_clientstate "keeneticToMqtt/test/mocks/gomock/services/clientstate"
+)
+
+func TestTopics(t *testing.T) {
+	assert.Equal(t, "base/aa_bb_cc_dd_ee_ff", ClientTopic("base", dto.Client{Mac: "aa:bb:cc:dd:ee:ff"}))
+	assert.Equal(t, "base/bridge/clients", ClientsTopic("base"))
+}
+
+func TestPublisher_Publish(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		phoneTopic   = "base/aa_aa"
+		laptopTopic  = "base/bb_bb"
+		clientsTopic = "base/bridge/clients"
+	)
+
+	phone := dto.Client{Mac: "aa:aa", Name: "phone", IP: "192.168.1.10", Hostname: "phone-host", Online: true, RxBytes: 100}
+	phoneChanged := phone
+	phoneChanged.RxBytes = 200
+	laptop := dto.Client{Mac: "bb:bb", Name: "laptop"}
+
+	phoneState := `{"mac":"aa:aa","policy":"","schedule":"","name":"phone","permit":false,"rxbytes":100,"txbytes":0,"rxrate":0,"txrate":0,"active":false,"link":"","lastSeen":0,"uptime":0,"online":true,"rxLimit":0,"txLimit":0,"ssid":"","ap":"","rssi":0,"linkRate":0,"mcs":0,"spatialStreams":0,"ip":"192.168.1.10","hostname":"phone-host","interface":"","registered":false,"access":"","priority":0,"security":"","firstSeen":0}`
+	phoneChangedState := `{"mac":"aa:aa","policy":"","schedule":"","name":"phone","permit":false,"rxbytes":200,"txbytes":0,"rxrate":0,"txrate":0,"active":false,"link":"","lastSeen":0,"uptime":0,"online":true,"rxLimit":0,"txLimit":0,"ssid":"","ap":"","rssi":0,"linkRate":0,"mcs":0,"spatialStreams":0,"ip":"192.168.1.10","hostname":"phone-host","interface":"","registered":false,"access":"","priority":0,"security":"","firstSeen":0}`
+	laptopState := `{"mac":"bb:bb","policy":"","schedule":"","name":"laptop","permit":false,"rxbytes":0,"txbytes":0,"rxrate":0,"txrate":0,"active":false,"link":"","lastSeen":0,"uptime":0,"online":false,"rxLimit":0,"txLimit":0,"ssid":"","ap":"","rssi":0,"linkRate":0,"mcs":0,"spatialStreams":0,"ip":"","hostname":"","interface":"","registered":false,"access":"","priority":0,"security":"","firstSeen":0}`
+	phoneHost := `{"mac":"aa:aa","name":"phone","ip":"192.168.1.10","hostname":"phone-host","online":true,"topic":"base/aa_aa"}`
+	laptopHost := `{"mac":"bb:bb","name":"laptop","ip":"","hostname":"","online":false,"topic":"base/bb_bb"}`
+
+	tests := []struct {
+		name    string
+		updates [][]dto.Client
+		mqtt    func() mqttClient
+	}{
+		{
+			name:    "client states and sorted client list are published",
+			updates: [][]dto.Client{{laptop, phone}},
+			mqtt: func() mqttClient {
+				mqtt := mock_clientstate.NewMockmqttClient(ctrl)
+				gomock.InOrder(
+					mqtt.EXPECT().SendMessage(phoneTopic, phoneState, true),
+					mqtt.EXPECT().SendMessage(laptopTopic, laptopState, true),
+					mqtt.EXPECT().SendMessage(clientsTopic, "["+phoneHost+","+laptopHost+"]", true),
+				)
+				return mqtt
+			},
+		},
+		{
+			name:    "same states are published once",
+			updates: [][]dto.Client{{phone}, {phone}},
+			mqtt: func() mqttClient {
+				mqtt := mock_clientstate.NewMockmqttClient(ctrl)
+				mqtt.EXPECT().SendMessage(phoneTopic, phoneState, true)
+				mqtt.EXPECT().SendMessage(clientsTopic, "["+phoneHost+"]", true)
+				return mqtt
+			},
+		},
+		{
+			name:    "only changed client state is published",
+			updates: [][]dto.Client{{phone, laptop}, {phoneChanged, laptop}},
+			mqtt: func() mqttClient {
+				mqtt := mock_clientstate.NewMockmqttClient(ctrl)
+				mqtt.EXPECT().SendMessage(phoneTopic, phoneState, true)
+				mqtt.EXPECT().SendMessage(laptopTopic, laptopState, true)
+				mqtt.EXPECT().SendMessage(clientsTopic, "["+phoneHost+","+laptopHost+"]", true)
+				mqtt.EXPECT().SendMessage(phoneTopic, phoneChangedState, true)
+				return mqtt
+			},
+		},
+		{
+			name:    "state of removed client is cleared",
+			updates: [][]dto.Client{{phone, laptop}, {phone}},
+			mqtt: func() mqttClient {
+				mqtt := mock_clientstate.NewMockmqttClient(ctrl)
+				mqtt.EXPECT().SendMessage(phoneTopic, phoneState, true)
+				mqtt.EXPECT().SendMessage(laptopTopic, laptopState, true)
+				mqtt.EXPECT().SendMessage(clientsTopic, "["+phoneHost+","+laptopHost+"]", true)
+				mqtt.EXPECT().SendMessage(laptopTopic, "", true)
+				mqtt.EXPECT().SendMessage(clientsTopic, "["+phoneHost+"]", true)
+				return mqtt
+			},
+		},
+		{
+			name:    "empty client list",
+			updates: [][]dto.Client{{}},
+			mqtt: func() mqttClient {
+				mqtt := mock_clientstate.NewMockmqttClient(ctrl)
+				mqtt.EXPECT().SendMessage(clientsTopic, "[]", true)
+				return mqtt
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publisher := NewPublisher("base", tt.mqtt())
+			for _, clients := range tt.updates {
+				assert.Nil(t, publisher.Publish(clients))
+			}
+		})
+	}
+}
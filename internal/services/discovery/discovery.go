@@ -3,6 +3,12 @@ package discovery
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
 )
 
 //go:generate mockgen -source=discovery.go -destination=../../../test/mocks/gomock/services/discovery/discovery.go
@@ -10,27 +16,88 @@ import (
 const (
 	defaultDiscoveryPrefix = "homeassistant"
 	manufacturer           = "BlenderistDev keeneticToMqtt"
+	routerManufacturer     = "Keenetic"
+	trackerSourceType      = "router"
+	availabilityModeAll    = "all"
+	originName             = "keeneticToMqtt"
+	connectionMac          = "mac"
+	// retainedWait is time to receive retained discovery configs of previous runs.
+	retainedWait = 2 * time.Second
+	// clientIDLen is length of client mac in client entity object id.
+	clientIDLen = 12
 )
 
 type (
 	mqttClient interface {
 		SendMessage(topic, message string, retained bool)
+		ReadRetained(topic string, wait time.Duration) map[string]string
+	}
+	clientTopics interface {
+		AttributesTopic(client dto.Client) string
+	}
+	availability struct {
+		Topic string `json:"topic"`
+	}
+	// availabilityConfig is embedded into every discovery config.
+	availabilityConfig struct {
+		Availability     []availability `json:"availability,omitempty"`
+		AvailabilityMode string         `json:"availability_mode,omitempty"`
+	}
+	origin struct {
+		Name string `json:"name"`
+	}
+	// entityConfig is common part of every discovery config.
+	entityConfig struct {
+		Name     string `json:"name"`
+		UniqueID string `json:"unique_id"`
+		ObjectID string `json:"object_id"`
+		Device   device `json:"device"`
+		Origin   origin `json:"origin"`
+		// JSONAttributesTopic is set only for client entities.
+		JSONAttributesTopic string `json:"json_attributes_topic,omitempty"`
+		metaConfig
+		availabilityConfig
+	}
+	// metaConfig is optional part of discovery config, which is declared by entity.
+	metaConfig struct {
+		DeviceClass               string `json:"device_class,omitempty"`
+		StateClass                string `json:"state_class,omitempty"`
+		EntityCategory            string `json:"entity_category,omitempty"`
+		Icon                      string `json:"icon,omitempty"`
+		SuggestedDisplayPrecision *int   `json:"suggested_display_precision,omitempty"`
 	}
 	device struct {
-		Manufacturer string `json:"manufacturer"`
-		Name         string `json:"name"`
+		Identifiers  []string   `json:"identifiers,omitempty"`
+		Connections  [][]string `json:"connections,omitempty"`
+		Manufacturer string     `json:"manufacturer"`
+		Model        string     `json:"model,omitempty"`
+		Name         string     `json:"name"`
+		SwVersion    string     `json:"sw_version,omitempty"`
+		ViaDevice    string     `json:"via_device,omitempty"`
 	}
 )
 
 // Discovery struct to send home assistant discovery messages.
 type Discovery struct {
 	discoveryPrefix, deviceID string
+	availabilityTopics        []string
+	attributesTopics          clientTopics
 	mqtt                      mqttClient
+
+	// clientTopics discovery topics sent by this Discovery, keyed by client device id.
+	clientTopics map[string]map[string]bool
+	// retainedTopics discovery and state topics of client entities of previous runs, keyed by client device id.
+	retainedTopics    map[string]map[string]bool
+	clientTopicsMutex sync.Mutex
 }
 
 // NewDiscovery creates new Discovery struct.
+// Entities are available only when all availabilityTopics are online.
+// Client entities get json attributes from attributesTopics, nil attributesTopics means client entities without attributes.
 func NewDiscovery(
 	discoveryPrefix, deviceID string,
+	availabilityTopics []string,
+	attributesTopics clientTopics,
 	mqtt mqttClient,
 ) *Discovery {
 	if discoveryPrefix == "" {
@@ -38,100 +105,394 @@ func NewDiscovery(
 	}
 
 	return &Discovery{
-		discoveryPrefix: discoveryPrefix,
-		deviceID:        deviceID,
-		mqtt:            mqtt,
+		discoveryPrefix:    discoveryPrefix,
+		deviceID:           deviceID,
+		availabilityTopics: availabilityTopics,
+		attributesTopics:   attributesTopics,
+		mqtt:               mqtt,
+		clientTopics:       make(map[string]map[string]bool),
 	}
 }
 
 // SendDiscoverySelect sends home assistant discovery message for switch.
-func (d *Discovery) SendDiscoverySelect(commandTopic, stateTopic, deviceName, name string, options []string) error {
+func (d *Discovery) SendDiscoverySelect(commandTopic, stateTopic string, client dto.Client, entityType string, options []string, meta homeassistantdto.EntityMeta) error {
+	entity := d.clientEntityConfig(client, entityType, meta)
 	config := struct {
 		CommandTopic string   `json:"command_topic"`
 		StateTopic   string   `json:"state_topic"`
-		Name         string   `json:"name"`
 		Options      []string `json:"options"`
-		Device       device
+		entityConfig
 	}{
 		CommandTopic: commandTopic,
 		StateTopic:   stateTopic,
-		Name:         name,
 		Options:      options,
-		Device: device{
-			Manufacturer: manufacturer,
-			Name:         deviceName,
-		},
+		entityConfig: entity,
 	}
 
-	configStr, err := json.Marshal(config)
-	if err != nil {
-		return fmt.Errorf("error while marshal select discovery config: %w", err)
-	}
-	d.sendDiscovery("select", d.deviceID+name, string(configStr))
-
-	return nil
+	return d.sendClientDiscovery(client, "select", entityType, entity.UniqueID, config)
 }
 
 // SendDiscoverySwitch sends home assistant discovery message for switch.
-func (d *Discovery) SendDiscoverySwitch(commandTopic, stateTopic, deviceName, name string) error {
+func (d *Discovery) SendDiscoverySwitch(commandTopic, stateTopic string, client dto.Client, entityType string, meta homeassistantdto.EntityMeta) error {
+	entity := d.clientEntityConfig(client, entityType, meta)
 	config := struct {
 		CommandTopic string `json:"command_topic"`
 		StateTopic   string `json:"state_topic"`
-		Name         string `json:"name"`
-		Device       device
+		entityConfig
 	}{
 		CommandTopic: commandTopic,
 		StateTopic:   stateTopic,
-		Name:         name,
-		Device: device{
-			Manufacturer: manufacturer,
-			Name:         deviceName,
-		},
+		entityConfig: entity,
 	}
 
-	configStr, err := json.Marshal(config)
-	if err != nil {
-		return fmt.Errorf("error while marshal select discovery config: %w", err)
+	return d.sendClientDiscovery(client, "switch", entityType, entity.UniqueID, config)
+}
+
+// SendDiscoveryNumber sends home assistant discovery message for number.
+func (d *Discovery) SendDiscoveryNumber(commandTopic, stateTopic string, client dto.Client, entityType string, meta homeassistantdto.NumberMeta) error {
+	entity := d.clientEntityConfig(client, entityType, meta.EntityMeta)
+	config := struct {
+		CommandTopic      string `json:"command_topic"`
+		StateTopic        string `json:"state_topic"`
+		UnitOfMeasurement string `json:"unit_of_measurement,omitempty"`
+		Min               int64  `json:"min"`
+		Max               int64  `json:"max"`
+		Step              int64  `json:"step,omitempty"`
+		Mode              string `json:"mode,omitempty"`
+		entityConfig
+	}{
+		CommandTopic:      commandTopic,
+		StateTopic:        stateTopic,
+		UnitOfMeasurement: meta.Unit,
+		Min:               meta.Min,
+		Max:               meta.Max,
+		Step:              meta.Step,
+		Mode:              meta.Mode,
+		entityConfig:      entity,
 	}
-	d.sendDiscovery("switch", d.deviceID+name, string(configStr))
 
-	return nil
+	return d.sendClientDiscovery(client, "number", entityType, entity.UniqueID, config)
 }
 
 // SendDiscoverySensor sends home assistant discovery message for sensor.
-func (d *Discovery) SendDiscoverySensor(stateTopic, deviceName, name, unit string) error {
+func (d *Discovery) SendDiscoverySensor(stateTopic string, client dto.Client, entityType string, meta homeassistantdto.SensorMeta) error {
+	entity := d.clientEntityConfig(client, entityType, meta.EntityMeta)
+	config := struct {
+		StateTopic        string `json:"state_topic"`
+		UnitOfMeasurement string `json:"unit_of_measurement,omitempty"`
+		entityConfig
+	}{
+		StateTopic:        stateTopic,
+		UnitOfMeasurement: meta.Unit,
+		entityConfig:      entity,
+	}
+
+	return d.sendClientDiscovery(client, "sensor", entityType, entity.UniqueID, config)
+}
+
+// SendDiscoveryBinarySensor sends home assistant discovery message for binary sensor.
+func (d *Discovery) SendDiscoveryBinarySensor(stateTopic string, client dto.Client, entityType string, meta homeassistantdto.EntityMeta) error {
+	entity := d.clientEntityConfig(client, entityType, meta)
+	config := struct {
+		StateTopic string `json:"state_topic"`
+		entityConfig
+	}{
+		StateTopic:   stateTopic,
+		entityConfig: entity,
+	}
+
+	return d.sendClientDiscovery(client, "binary_sensor", entityType, entity.UniqueID, config)
+}
+
+// SendDiscoveryDeviceTracker sends home assistant discovery message for device tracker.
+func (d *Discovery) SendDiscoveryDeviceTracker(stateTopic string, client dto.Client, entityType string, meta homeassistantdto.EntityMeta) error {
+	entity := d.clientEntityConfig(client, entityType, meta)
+	config := struct {
+		StateTopic     string `json:"state_topic"`
+		PayloadHome    string `json:"payload_home"`
+		PayloadNotHome string `json:"payload_not_home"`
+		SourceType     string `json:"source_type"`
+		entityConfig
+	}{
+		StateTopic:     stateTopic,
+		PayloadHome:    homeassistantdto.PayloadHome,
+		PayloadNotHome: homeassistantdto.PayloadNotHome,
+		SourceType:     trackerSourceType,
+		entityConfig:   entity,
+	}
+
+	return d.sendClientDiscovery(client, "device_tracker", entityType, entity.UniqueID, config)
+}
+
+// SendRouterDiscoverySensor sends home assistant discovery message for router sensor.
+func (d *Discovery) SendRouterDiscoverySensor(stateTopic, name string, router dto.Router, meta homeassistantdto.SensorMeta) error {
+	entity := d.routerEntityConfig(router, name, meta.EntityMeta)
 	config := struct {
 		StateTopic        string `json:"state_topic"`
-		Name              string `json:"name"`
-		Device            device
-		UnitOfMeasurement string `json:"unit_of_measurement"`
+		UnitOfMeasurement string `json:"unit_of_measurement,omitempty"`
+		entityConfig
 	}{
 		StateTopic:        stateTopic,
-		Name:              name,
-		UnitOfMeasurement: unit,
-		Device: device{
-			Manufacturer: manufacturer,
-			Name:         deviceName,
-		},
+		UnitOfMeasurement: meta.Unit,
+		entityConfig:      entity,
+	}
+
+	return d.sendDiscovery("sensor", entity.UniqueID, config)
+}
+
+// SendRouterDiscoveryBinarySensor sends home assistant discovery message for router binary sensor.
+func (d *Discovery) SendRouterDiscoveryBinarySensor(stateTopic, name string, router dto.Router, meta homeassistantdto.EntityMeta) error {
+	entity := d.routerEntityConfig(router, name, meta)
+	config := struct {
+		StateTopic string `json:"state_topic"`
+		entityConfig
+	}{
+		StateTopic:   stateTopic,
+		entityConfig: entity,
+	}
+
+	return d.sendDiscovery("binary_sensor", entity.UniqueID, config)
+}
+
+// SendRouterDiscoverySwitch sends home assistant discovery message for router switch.
+func (d *Discovery) SendRouterDiscoverySwitch(commandTopic, stateTopic, name string, router dto.Router, meta homeassistantdto.EntityMeta) error {
+	entity := d.routerEntityConfig(router, name, meta)
+	config := struct {
+		CommandTopic string `json:"command_topic"`
+		StateTopic   string `json:"state_topic"`
+		entityConfig
+	}{
+		CommandTopic: commandTopic,
+		StateTopic:   stateTopic,
+		entityConfig: entity,
+	}
+
+	return d.sendDiscovery("switch", entity.UniqueID, config)
+}
+
+// clientEntityConfig returns common part of discovery config of keenetic client entity.
+// Unique id depends only on client mac and entity type, so entity survives client rename.
+func (d *Discovery) clientEntityConfig(client dto.Client, entityType string, meta homeassistantdto.EntityMeta) entityConfig {
+	conf := entityConfig{
+		Name:               client.Name + "_" + entityType,
+		UniqueID:           d.clientDeviceID(client) + "_" + entityType,
+		ObjectID:           client.Name + "_" + entityType,
+		Device:             d.clientDevice(client),
+		Origin:             origin{Name: originName},
+		metaConfig:         newMetaConfig(meta),
+		availabilityConfig: d.availabilityConfig(),
+	}
+	if d.attributesTopics != nil {
+		conf.JSONAttributesTopic = d.attributesTopics.AttributesTopic(client)
+	}
+
+	return conf
+}
+
+// routerEntityConfig returns common part of discovery config of keenetic router entity.
+func (d *Discovery) routerEntityConfig(router dto.Router, name string, meta homeassistantdto.EntityMeta) entityConfig {
+	return entityConfig{
+		Name:               name,
+		UniqueID:           d.deviceID + "_" + name,
+		ObjectID:           name,
+		Device:             d.routerDevice(router),
+		Origin:             origin{Name: originName},
+		metaConfig:         newMetaConfig(meta),
+		availabilityConfig: d.availabilityConfig(),
+	}
+}
+
+// newMetaConfig returns entity metadata part of discovery config.
+func newMetaConfig(meta homeassistantdto.EntityMeta) metaConfig {
+	return metaConfig{
+		DeviceClass:               meta.DeviceClass,
+		StateClass:                meta.StateClass,
+		EntityCategory:            meta.EntityCategory,
+		Icon:                      meta.Icon,
+		SuggestedDisplayPrecision: meta.SuggestedDisplayPrecision,
+	}
+}
+
+// availabilityConfig returns availability part of discovery config.
+func (d *Discovery) availabilityConfig() availabilityConfig {
+	if len(d.availabilityTopics) == 0 {
+		return availabilityConfig{}
+	}
+
+	conf := availabilityConfig{AvailabilityMode: availabilityModeAll}
+	for _, topic := range d.availabilityTopics {
+		conf.Availability = append(conf.Availability, availability{Topic: topic})
+	}
+
+	return conf
+}
+
+// clientDevice returns home assistant device of keenetic client, which is connected via router device.
+func (d *Discovery) clientDevice(client dto.Client) device {
+	return device{
+		Identifiers:  []string{d.clientDeviceID(client)},
+		Connections:  [][]string{{connectionMac, client.Mac}},
+		Manufacturer: manufacturer,
+		Name:         client.Name,
+		ViaDevice:    d.deviceID,
+	}
+}
+
+// clientDeviceID returns stable id of keenetic client device, which is built from client mac.
+func (d *Discovery) clientDeviceID(client dto.Client) string {
+	return d.deviceID + "_" + strings.ToLower(strings.ReplaceAll(client.Mac, ":", ""))
+}
+
+// routerDevice returns home assistant device of keenetic router.
+func (d *Discovery) routerDevice(router dto.Router) device {
+	dev := device{
+		Identifiers:  []string{d.deviceID},
+		Manufacturer: router.Manufacturer,
+		Model:        router.Model,
+		Name:         router.Model,
+		SwVersion:    router.Firmware,
+	}
+	if dev.Manufacturer == "" {
+		dev.Manufacturer = routerManufacturer
+	}
+	if dev.Name == "" {
+		dev.Name = d.deviceID
+	}
+
+	return dev
+}
+
+// RemoveClientDiscovery removes all home assistant entities of client device with mac,
+// which were sent by this Discovery, by sending empty retained discovery messages.
+func (d *Discovery) RemoveClientDiscovery(mac string) {
+	id := d.clientDeviceID(dto.Client{Mac: mac})
+	d.clientTopicsMutex.Lock()
+	topics := d.clientTopics[id]
+	delete(d.clientTopics, id)
+	d.clientTopicsMutex.Unlock()
+
+	for topic := range topics {
+		d.mqtt.SendMessage(topic, "", true)
 	}
+}
+
+// LoadRetainedTopics reads retained discovery configs of client entities, which were sent by previous runs,
+// and remembers their discovery and state topics, so RemoveStaleClients can remove clients removed while bridge was down,
+// for example after whitelist change.
+func (d *Discovery) LoadRetainedTopics() {
+	configs := d.mqtt.ReadRetained(d.discoveryPrefix+"/+/+/config", retainedWait)
+
+	retainedTopics := make(map[string]map[string]bool)
+	for topic, payload := range configs {
+		id, ok := d.retainedClientDeviceID(topic)
+		if !ok || payload == "" {
+			continue
+		}
+		if retainedTopics[id] == nil {
+			retainedTopics[id] = make(map[string]bool)
+		}
+		retainedTopics[id][topic] = true
+
+		var config struct {
+			StateTopic          string `json:"state_topic"`
+			JSONAttributesTopic string `json:"json_attributes_topic"`
+		}
+		if err := json.Unmarshal([]byte(payload), &config); err != nil {
+			continue
+		}
+		for _, stateTopic := range []string{config.StateTopic, config.JSONAttributesTopic} {
+			if stateTopic != "" {
+				retainedTopics[id][stateTopic] = true
+			}
+		}
+	}
+
+	d.clientTopicsMutex.Lock()
+	d.retainedTopics = retainedTopics
+	d.clientTopicsMutex.Unlock()
+}
+
+// RemoveStaleClients removes home assistant entities and states of clients of previous runs, which are not in clients.
+// Retained topics are forgotten after that, clients removed later are removed by RemoveClientDiscovery.
+func (d *Discovery) RemoveStaleClients(clients []dto.Client) {
+	actual := make(map[string]bool, len(clients))
+	for _, client := range clients {
+		actual[d.clientDeviceID(client)] = true
+	}
+
+	d.clientTopicsMutex.Lock()
+	retainedTopics := d.retainedTopics
+	d.retainedTopics = nil
+	d.clientTopicsMutex.Unlock()
+
+	for id, topics := range retainedTopics {
+		if actual[id] {
+			continue
+		}
+		for topic := range topics {
+			d.mqtt.SendMessage(topic, "", true)
+		}
+	}
+}
 
+// retainedClientDeviceID returns client device id of client entity discovery topic.
+// Client entity object id is client device id and entity type, router entities are skipped.
+func (d *Discovery) retainedClientDeviceID(topic string) (string, bool) {
+	parts := strings.Split(strings.TrimPrefix(topic, d.discoveryPrefix+"/"), "/")
+	if len(parts) != 3 {
+		return "", false
+	}
+	rest, ok := strings.CutPrefix(parts[1], d.deviceID+"_")
+	if !ok || len(rest) <= clientIDLen || rest[clientIDLen] != '_' {
+		return "", false
+	}
+	for _, r := range rest[:clientIDLen] {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return "", false
+		}
+	}
+
+	return d.deviceID + "_" + rest[:clientIDLen], true
+}
+
+// sendClientDiscovery sends discovery config of client entity and remembers its topic to remove it later.
+// On first discovery of entity its legacy topic is cleared, see legacyObjectID.
+func (d *Discovery) sendClientDiscovery(client dto.Client, component, entityType, objectID string, config any) error {
+	topic := d.buildDiscoveryTopic(component, objectID)
+
+	id := d.clientDeviceID(client)
+	d.clientTopicsMutex.Lock()
+	if d.clientTopics[id] == nil {
+		d.clientTopics[id] = make(map[string]bool)
+	}
+	first := !d.clientTopics[id][topic]
+	d.clientTopics[id][topic] = true
+	d.clientTopicsMutex.Unlock()
+
+	if first {
+		d.mqtt.SendMessage(d.buildDiscoveryTopic(component, d.legacyObjectID(client, entityType)), "", true)
+	}
+
+	return d.sendDiscovery(component, objectID, config)
+}
+
+// legacyObjectID returns object id of client entity discovery topic used before object ids were built from mac.
+// Retained configs left in legacy topics duplicate entities in home assistant, so they are cleared.
+func (d *Discovery) legacyObjectID(client dto.Client, entityType string) string {
+	return d.deviceID + client.Name + "_" + entityType
+}
+
+func (d *Discovery) sendDiscovery(component, objectID string, config any) error {
 	configStr, err := json.Marshal(config)
 	if err != nil {
-		return fmt.Errorf("error while marshal select discovery config: %w", err)
+		return fmt.Errorf("error while marshal %s discovery config: %w", component, err)
 	}
-	d.sendDiscovery("sensor", d.deviceID+name, string(configStr))
 
-	return nil
-}
+	d.mqtt.SendMessage(d.buildDiscoveryTopic(component, objectID), string(configStr), true)
 
-func (d *Discovery) sendDiscovery(component, deviceID, config string) {
-	d.mqtt.SendMessage(
-		d.buildDiscoveryTopic(component, deviceID),
-		config,
-		true,
-	)
+	return nil
 }
 
-func (d Discovery) buildDiscoveryTopic(component, deviceID string) string {
-	return d.discoveryPrefix + "/" + component + "/" + deviceID + "/" + "config"
+func (d *Discovery) buildDiscoveryTopic(component, objectID string) string {
+	return d.discoveryPrefix + "/" + component + "/" + objectID + "/" + "config"
 }
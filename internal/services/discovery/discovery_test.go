@@ -5,9 +5,13 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/homeassistantdto"
 	mock_discovery "keeneticToMqtt/test/mocks/gomock/services/discovery"
 )
 
+const mac = "AA:BB:CC:DD:EE:FF"
+
 func TestDiscovery_SendDiscoverySelect(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -34,9 +38,10 @@ func TestDiscovery_SendDiscoverySelect(t *testing.T) {
 			name: "success sending select discovery message",
 			mqttClient: func() mqttClient {
 				client := mock_discovery.NewMockmqttClient(ctrl)
+				client.EXPECT().SendMessage(gomock.Eq("discoveryPrefix/select/deviceIDdeviceName_entityName/config"), gomock.Eq(""), gomock.Eq(true))
 				client.EXPECT().SendMessage(
-					gomock.Eq("discoveryPrefix/select/deviceIDentityName/config"),
-					gomock.Eq("{\"command_topic\":\"commandTopic\",\"state_topic\":\"stateTopic\",\"name\":\"entityName\",\"options\":[\"option1\",\"option2\"],\"Device\":{\"manufacturer\":\"BlenderistDev keeneticToMqtt\",\"name\":\"deviceName\"}}"),
+					gomock.Eq("discoveryPrefix/select/deviceID_aabbccddeeff_entityName/config"),
+					gomock.Eq("{\"command_topic\":\"commandTopic\",\"state_topic\":\"stateTopic\",\"options\":[\"option1\",\"option2\"],\"name\":\"deviceName_entityName\",\"unique_id\":\"deviceID_aabbccddeeff_entityName\",\"object_id\":\"deviceName_entityName\",\"device\":{\"identifiers\":[\"deviceID_aabbccddeeff\"],\"connections\":[[\"mac\",\"AA:BB:CC:DD:EE:FF\"]],\"manufacturer\":\"BlenderistDev keeneticToMqtt\",\"name\":\"deviceName\",\"via_device\":\"deviceID\"},\"origin\":{\"name\":\"keeneticToMqtt\"}}"),
 					gomock.Eq(true),
 				)
 
@@ -54,8 +59,8 @@ func TestDiscovery_SendDiscoverySelect(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			discovery := NewDiscovery(tt.discoveryPrefix, tt.deviceID, tt.mqttClient())
-			err := discovery.SendDiscoverySelect(tt.commandTopic, tt.stateTopic, tt.deviceName, tt.entityName, tt.options)
+			discovery := NewDiscovery(tt.discoveryPrefix, tt.deviceID, nil, nil, tt.mqttClient())
+			err := discovery.SendDiscoverySelect(tt.commandTopic, tt.stateTopic, dto.Client{Mac: mac, Name: tt.deviceName}, tt.entityName, tt.options, homeassistantdto.EntityMeta{})
 			if tt.expectedErr != nil {
 				assert.ErrorIs(t, err, tt.expectedErr)
 			} else {
@@ -84,6 +89,7 @@ func TestDiscovery_SendDiscoverySwitch(t *testing.T) {
 		commandTopic, stateTopic, deviceName, entityName string
 		discoveryPrefix, deviceID                        string
 		options                                          []string
+		meta                                             homeassistantdto.EntityMeta
 		mqttClient                                       func() mqttClient
 		expectedErr                                      error
 	}{
@@ -91,9 +97,10 @@ func TestDiscovery_SendDiscoverySwitch(t *testing.T) {
 			name: "success sending switch discovery message",
 			mqttClient: func() mqttClient {
 				client := mock_discovery.NewMockmqttClient(ctrl)
+				client.EXPECT().SendMessage(gomock.Eq("discoveryPrefix/switch/deviceIDdeviceName_entityName/config"), gomock.Eq(""), gomock.Eq(true))
 				client.EXPECT().SendMessage(
-					gomock.Eq("discoveryPrefix/switch/deviceIDentityName/config"),
-					gomock.Eq("{\"command_topic\":\"commandTopic\",\"state_topic\":\"stateTopic\",\"name\":\"entityName\",\"Device\":{\"manufacturer\":\"BlenderistDev keeneticToMqtt\",\"name\":\"deviceName\"}}"),
+					gomock.Eq("discoveryPrefix/switch/deviceID_aabbccddeeff_entityName/config"),
+					gomock.Eq("{\"command_topic\":\"commandTopic\",\"state_topic\":\"stateTopic\",\"name\":\"deviceName_entityName\",\"unique_id\":\"deviceID_aabbccddeeff_entityName\",\"object_id\":\"deviceName_entityName\",\"device\":{\"identifiers\":[\"deviceID_aabbccddeeff\"],\"connections\":[[\"mac\",\"AA:BB:CC:DD:EE:FF\"]],\"manufacturer\":\"BlenderistDev keeneticToMqtt\",\"name\":\"deviceName\",\"via_device\":\"deviceID\"},\"origin\":{\"name\":\"keeneticToMqtt\"}}"),
 					gomock.Eq(true),
 				)
 
@@ -107,12 +114,33 @@ func TestDiscovery_SendDiscoverySwitch(t *testing.T) {
 			deviceID:        deviceID,
 			discoveryPrefix: discoveryPrefix,
 		},
+		{
+			name: "success sending switch discovery message with entity metadata",
+			mqttClient: func() mqttClient {
+				client := mock_discovery.NewMockmqttClient(ctrl)
+				client.EXPECT().SendMessage(gomock.Eq("discoveryPrefix/switch/deviceIDdeviceName_entityName/config"), gomock.Eq(""), gomock.Eq(true))
+				client.EXPECT().SendMessage(
+					gomock.Eq("discoveryPrefix/switch/deviceID_aabbccddeeff_entityName/config"),
+					gomock.Eq("{\"command_topic\":\"commandTopic\",\"state_topic\":\"stateTopic\",\"name\":\"deviceName_entityName\",\"unique_id\":\"deviceID_aabbccddeeff_entityName\",\"object_id\":\"deviceName_entityName\",\"device\":{\"identifiers\":[\"deviceID_aabbccddeeff\"],\"connections\":[[\"mac\",\"AA:BB:CC:DD:EE:FF\"]],\"manufacturer\":\"BlenderistDev keeneticToMqtt\",\"name\":\"deviceName\",\"via_device\":\"deviceID\"},\"origin\":{\"name\":\"keeneticToMqtt\"},\"entity_category\":\"config\",\"icon\":\"mdi:web\"}"),
+					gomock.Eq(true),
+				)
+
+				return client
+			},
+			commandTopic:    commandTopic,
+			stateTopic:      stateTopic,
+			deviceName:      deviceName,
+			entityName:      entityName,
+			deviceID:        deviceID,
+			discoveryPrefix: discoveryPrefix,
+			meta:            homeassistantdto.EntityMeta{EntityCategory: homeassistantdto.EntityCategoryConfig, Icon: "mdi:web"},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			discovery := NewDiscovery(tt.discoveryPrefix, tt.deviceID, tt.mqttClient())
-			err := discovery.SendDiscoverySwitch(tt.commandTopic, tt.stateTopic, tt.deviceName, tt.entityName)
+			discovery := NewDiscovery(tt.discoveryPrefix, tt.deviceID, nil, nil, tt.mqttClient())
+			err := discovery.SendDiscoverySwitch(tt.commandTopic, tt.stateTopic, dto.Client{Mac: mac, Name: tt.deviceName}, tt.entityName, tt.meta)
 			if tt.expectedErr != nil {
 				assert.ErrorIs(t, err, tt.expectedErr)
 			} else {
@@ -122,6 +150,37 @@ func TestDiscovery_SendDiscoverySwitch(t *testing.T) {
 	}
 }
 
+func TestDiscovery_SendDiscoveryNumber(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		commandTopic    = "commandTopic"
+		stateTopic      = "stateTopic"
+		deviceName      = "deviceName"
+		entityName      = "entityName"
+		discoveryPrefix = "discoveryPrefix"
+		deviceID        = "deviceID"
+	)
+
+	client := mock_discovery.NewMockmqttClient(ctrl)
+	client.EXPECT().SendMessage(gomock.Eq("discoveryPrefix/number/deviceIDdeviceName_entityName/config"), gomock.Eq(""), gomock.Eq(true))
+	client.EXPECT().SendMessage(
+		gomock.Eq("discoveryPrefix/number/deviceID_aabbccddeeff_entityName/config"),
+		gomock.Eq("{\"command_topic\":\"commandTopic\",\"state_topic\":\"stateTopic\",\"unit_of_measurement\":\"kbit/s\",\"min\":0,\"max\":1000,\"step\":1,\"mode\":\"box\",\"name\":\"deviceName_entityName\",\"unique_id\":\"deviceID_aabbccddeeff_entityName\",\"object_id\":\"deviceName_entityName\",\"device\":{\"identifiers\":[\"deviceID_aabbccddeeff\"],\"connections\":[[\"mac\",\"AA:BB:CC:DD:EE:FF\"]],\"manufacturer\":\"BlenderistDev keeneticToMqtt\",\"name\":\"deviceName\",\"via_device\":\"deviceID\"},\"origin\":{\"name\":\"keeneticToMqtt\"}}"),
+		gomock.Eq(true),
+	)
+
+	discovery := NewDiscovery(discoveryPrefix, deviceID, nil, nil, client)
+	err := discovery.SendDiscoveryNumber(commandTopic, stateTopic, dto.Client{Mac: mac, Name: deviceName}, entityName, homeassistantdto.NumberMeta{
+		Unit: "kbit/s",
+		Max:  1000,
+		Step: 1,
+		Mode: "box",
+	})
+	assert.Nil(t, err)
+}
+
 func TestDiscovery_SendDiscoverySensor(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -134,6 +193,8 @@ func TestDiscovery_SendDiscoverySensor(t *testing.T) {
 		discoveryPrefix = "discoveryPrefix"
 		deviceID        = "deviceID"
 		unit            = "unit"
+		deviceClass     = "deviceClass"
+		stateClass      = "stateClass"
 	)
 	options := []string{"option1", "option2"}
 
@@ -142,6 +203,7 @@ func TestDiscovery_SendDiscoverySensor(t *testing.T) {
 		commandTopic, stateTopic, deviceName, entityName string
 		discoveryPrefix, deviceID                        string
 		options                                          []string
+		meta                                             homeassistantdto.SensorMeta
 		mqttClient                                       func() mqttClient
 		expectedErr                                      error
 	}{
@@ -149,9 +211,10 @@ func TestDiscovery_SendDiscoverySensor(t *testing.T) {
 			name: "success sending sensor discovery message",
 			mqttClient: func() mqttClient {
 				client := mock_discovery.NewMockmqttClient(ctrl)
+				client.EXPECT().SendMessage(gomock.Eq("discoveryPrefix/sensor/deviceIDdeviceName_entityName/config"), gomock.Eq(""), gomock.Eq(true))
 				client.EXPECT().SendMessage(
-					gomock.Eq("discoveryPrefix/sensor/deviceIDentityName/config"),
-					gomock.Eq("{\"state_topic\":\"stateTopic\",\"name\":\"entityName\",\"Device\":{\"manufacturer\":\"BlenderistDev keeneticToMqtt\",\"name\":\"deviceName\"},\"unit_of_measurement\":\"unit\"}"),
+					gomock.Eq("discoveryPrefix/sensor/deviceID_aabbccddeeff_entityName/config"),
+					gomock.Eq("{\"state_topic\":\"stateTopic\",\"unit_of_measurement\":\"unit\",\"name\":\"deviceName_entityName\",\"unique_id\":\"deviceID_aabbccddeeff_entityName\",\"object_id\":\"deviceName_entityName\",\"device\":{\"identifiers\":[\"deviceID_aabbccddeeff\"],\"connections\":[[\"mac\",\"AA:BB:CC:DD:EE:FF\"]],\"manufacturer\":\"BlenderistDev keeneticToMqtt\",\"name\":\"deviceName\",\"via_device\":\"deviceID\"},\"origin\":{\"name\":\"keeneticToMqtt\"}}"),
 					gomock.Eq(true),
 				)
 
@@ -164,13 +227,238 @@ func TestDiscovery_SendDiscoverySensor(t *testing.T) {
 			options:         options,
 			deviceID:        deviceID,
 			discoveryPrefix: discoveryPrefix,
+			meta:            homeassistantdto.SensorMeta{Unit: unit},
+		},
+		{
+			name: "success sending sensor discovery message with device and state class",
+			mqttClient: func() mqttClient {
+				client := mock_discovery.NewMockmqttClient(ctrl)
+				client.EXPECT().SendMessage(gomock.Eq("discoveryPrefix/sensor/deviceIDdeviceName_entityName/config"), gomock.Eq(""), gomock.Eq(true))
+				client.EXPECT().SendMessage(
+					gomock.Eq("discoveryPrefix/sensor/deviceID_aabbccddeeff_entityName/config"),
+					gomock.Eq("{\"state_topic\":\"stateTopic\",\"unit_of_measurement\":\"unit\",\"name\":\"deviceName_entityName\",\"unique_id\":\"deviceID_aabbccddeeff_entityName\",\"object_id\":\"deviceName_entityName\",\"device\":{\"identifiers\":[\"deviceID_aabbccddeeff\"],\"connections\":[[\"mac\",\"AA:BB:CC:DD:EE:FF\"]],\"manufacturer\":\"BlenderistDev keeneticToMqtt\",\"name\":\"deviceName\",\"via_device\":\"deviceID\"},\"origin\":{\"name\":\"keeneticToMqtt\"},\"device_class\":\"deviceClass\",\"state_class\":\"stateClass\"}"),
+					gomock.Eq(true),
+				)
+
+				return client
+			},
+			stateTopic:      stateTopic,
+			deviceName:      deviceName,
+			entityName:      entityName,
+			deviceID:        deviceID,
+			discoveryPrefix: discoveryPrefix,
+			meta: homeassistantdto.SensorMeta{
+				Unit:       unit,
+				EntityMeta: homeassistantdto.EntityMeta{DeviceClass: deviceClass, StateClass: stateClass},
+			},
+		},
+		{
+			name: "success sending sensor discovery message with entity metadata",
+			mqttClient: func() mqttClient {
+				client := mock_discovery.NewMockmqttClient(ctrl)
+				client.EXPECT().SendMessage(gomock.Eq("discoveryPrefix/sensor/deviceIDdeviceName_entityName/config"), gomock.Eq(""), gomock.Eq(true))
+				client.EXPECT().SendMessage(
+					gomock.Eq("discoveryPrefix/sensor/deviceID_aabbccddeeff_entityName/config"),
+					gomock.Eq("{\"state_topic\":\"stateTopic\",\"unit_of_measurement\":\"B\",\"name\":\"deviceName_entityName\",\"unique_id\":\"deviceID_aabbccddeeff_entityName\",\"object_id\":\"deviceName_entityName\",\"device\":{\"identifiers\":[\"deviceID_aabbccddeeff\"],\"connections\":[[\"mac\",\"AA:BB:CC:DD:EE:FF\"]],\"manufacturer\":\"BlenderistDev keeneticToMqtt\",\"name\":\"deviceName\",\"via_device\":\"deviceID\"},\"origin\":{\"name\":\"keeneticToMqtt\"},\"device_class\":\"data_size\",\"state_class\":\"total_increasing\",\"entity_category\":\"diagnostic\",\"icon\":\"mdi:download\",\"suggested_display_precision\":0}"),
+					gomock.Eq(true),
+				)
+
+				return client
+			},
+			stateTopic:      stateTopic,
+			deviceName:      deviceName,
+			entityName:      entityName,
+			deviceID:        deviceID,
+			discoveryPrefix: discoveryPrefix,
+			meta: homeassistantdto.SensorMeta{
+				Unit: "B",
+				EntityMeta: homeassistantdto.EntityMeta{
+					DeviceClass:               "data_size",
+					StateClass:                "total_increasing",
+					EntityCategory:            homeassistantdto.EntityCategoryDiagnostic,
+					Icon:                      "mdi:download",
+					SuggestedDisplayPrecision: homeassistantdto.Precision(0),
+				},
+			},
+		},
+		{
+			name: "success sending sensor discovery message without unit",
+			mqttClient: func() mqttClient {
+				client := mock_discovery.NewMockmqttClient(ctrl)
+				client.EXPECT().SendMessage(gomock.Eq("discoveryPrefix/sensor/deviceIDdeviceName_entityName/config"), gomock.Eq(""), gomock.Eq(true))
+				client.EXPECT().SendMessage(
+					gomock.Eq("discoveryPrefix/sensor/deviceID_aabbccddeeff_entityName/config"),
+					gomock.Eq("{\"state_topic\":\"stateTopic\",\"name\":\"deviceName_entityName\",\"unique_id\":\"deviceID_aabbccddeeff_entityName\",\"object_id\":\"deviceName_entityName\",\"device\":{\"identifiers\":[\"deviceID_aabbccddeeff\"],\"connections\":[[\"mac\",\"AA:BB:CC:DD:EE:FF\"]],\"manufacturer\":\"BlenderistDev keeneticToMqtt\",\"name\":\"deviceName\",\"via_device\":\"deviceID\"},\"origin\":{\"name\":\"keeneticToMqtt\"}}"),
+					gomock.Eq(true),
+				)
+
+				return client
+			},
+			stateTopic:      stateTopic,
+			deviceName:      deviceName,
+			entityName:      entityName,
+			deviceID:        deviceID,
+			discoveryPrefix: discoveryPrefix,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discovery := NewDiscovery(tt.discoveryPrefix, tt.deviceID, nil, nil, tt.mqttClient())
+			err := discovery.SendDiscoverySensor(tt.stateTopic, dto.Client{Mac: mac, Name: tt.deviceName}, tt.entityName, tt.meta)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestDiscovery_SendDiscoveryBinarySensor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		stateTopic      = "stateTopic"
+		deviceName      = "deviceName"
+		entityName      = "entityName"
+		discoveryPrefix = "discoveryPrefix"
+		deviceID        = "deviceID"
+		deviceClass     = "connectivity"
+	)
+
+	tests := []struct {
+		name        string
+		meta        homeassistantdto.EntityMeta
+		mqttClient  func() mqttClient
+		expectedErr error
+	}{
+		{
+			name: "success sending binary sensor discovery message",
+			meta: homeassistantdto.EntityMeta{DeviceClass: deviceClass},
+			mqttClient: func() mqttClient {
+				client := mock_discovery.NewMockmqttClient(ctrl)
+				client.EXPECT().SendMessage(gomock.Eq("discoveryPrefix/binary_sensor/deviceIDdeviceName_entityName/config"), gomock.Eq(""), gomock.Eq(true))
+				client.EXPECT().SendMessage(
+					gomock.Eq("discoveryPrefix/binary_sensor/deviceID_aabbccddeeff_entityName/config"),
+					gomock.Eq("{\"state_topic\":\"stateTopic\",\"name\":\"deviceName_entityName\",\"unique_id\":\"deviceID_aabbccddeeff_entityName\",\"object_id\":\"deviceName_entityName\",\"device\":{\"identifiers\":[\"deviceID_aabbccddeeff\"],\"connections\":[[\"mac\",\"AA:BB:CC:DD:EE:FF\"]],\"manufacturer\":\"BlenderistDev keeneticToMqtt\",\"name\":\"deviceName\",\"via_device\":\"deviceID\"},\"origin\":{\"name\":\"keeneticToMqtt\"},\"device_class\":\"connectivity\"}"),
+					gomock.Eq(true),
+				)
+
+				return client
+			},
+		},
+		{
+			name: "success sending binary sensor discovery message without device class",
+			mqttClient: func() mqttClient {
+				client := mock_discovery.NewMockmqttClient(ctrl)
+				client.EXPECT().SendMessage(gomock.Eq("discoveryPrefix/binary_sensor/deviceIDdeviceName_entityName/config"), gomock.Eq(""), gomock.Eq(true))
+				client.EXPECT().SendMessage(
+					gomock.Eq("discoveryPrefix/binary_sensor/deviceID_aabbccddeeff_entityName/config"),
+					gomock.Eq("{\"state_topic\":\"stateTopic\",\"name\":\"deviceName_entityName\",\"unique_id\":\"deviceID_aabbccddeeff_entityName\",\"object_id\":\"deviceName_entityName\",\"device\":{\"identifiers\":[\"deviceID_aabbccddeeff\"],\"connections\":[[\"mac\",\"AA:BB:CC:DD:EE:FF\"]],\"manufacturer\":\"BlenderistDev keeneticToMqtt\",\"name\":\"deviceName\",\"via_device\":\"deviceID\"},\"origin\":{\"name\":\"keeneticToMqtt\"}}"),
+					gomock.Eq(true),
+				)
+
+				return client
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discovery := NewDiscovery(discoveryPrefix, deviceID, nil, nil, tt.mqttClient())
+			err := discovery.SendDiscoveryBinarySensor(stateTopic, dto.Client{Mac: mac, Name: deviceName}, entityName, tt.meta)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestDiscovery_SendDiscoveryDeviceTracker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		stateTopic      = "stateTopic"
+		deviceName      = "deviceName"
+		entityName      = "entityName"
+		discoveryPrefix = "discoveryPrefix"
+		deviceID        = "deviceID"
+	)
+
+	client := mock_discovery.NewMockmqttClient(ctrl)
+	client.EXPECT().SendMessage(gomock.Eq("discoveryPrefix/device_tracker/deviceIDdeviceName_entityName/config"), gomock.Eq(""), gomock.Eq(true))
+	client.EXPECT().SendMessage(
+		gomock.Eq("discoveryPrefix/device_tracker/deviceID_aabbccddeeff_entityName/config"),
+		gomock.Eq("{\"state_topic\":\"stateTopic\",\"payload_home\":\"home\",\"payload_not_home\":\"not_home\",\"source_type\":\"router\",\"name\":\"deviceName_entityName\",\"unique_id\":\"deviceID_aabbccddeeff_entityName\",\"object_id\":\"deviceName_entityName\",\"device\":{\"identifiers\":[\"deviceID_aabbccddeeff\"],\"connections\":[[\"mac\",\"AA:BB:CC:DD:EE:FF\"]],\"manufacturer\":\"BlenderistDev keeneticToMqtt\",\"name\":\"deviceName\",\"via_device\":\"deviceID\"},\"origin\":{\"name\":\"keeneticToMqtt\"}}"),
+		gomock.Eq(true),
+	)
+
+	discovery := NewDiscovery(discoveryPrefix, deviceID, nil, nil, client)
+	err := discovery.SendDiscoveryDeviceTracker(stateTopic, dto.Client{Mac: mac, Name: deviceName}, entityName, homeassistantdto.EntityMeta{})
+	assert.Nil(t, err)
+}
+
+func TestDiscovery_SendRouterDiscoverySensor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		stateTopic      = "stateTopic"
+		entityName      = "entityName"
+		discoveryPrefix = "discoveryPrefix"
+		deviceID        = "deviceID"
+	)
+
+	tests := []struct {
+		name        string
+		router      dto.Router
+		meta        homeassistantdto.SensorMeta
+		mqttClient  func() mqttClient
+		expectedErr error
+	}{
+		{
+			name: "success sending router sensor discovery message",
+			router: dto.Router{
+				Manufacturer: "Keenetic Ltd.",
+				Model:        "Giga",
+				Firmware:     "4.1.7",
+			},
+			meta: homeassistantdto.SensorMeta{Unit: "%", EntityMeta: homeassistantdto.EntityMeta{StateClass: "measurement"}},
+			mqttClient: func() mqttClient {
+				client := mock_discovery.NewMockmqttClient(ctrl)
+				client.EXPECT().SendMessage(
+					gomock.Eq("discoveryPrefix/sensor/deviceID_entityName/config"),
+					gomock.Eq("{\"state_topic\":\"stateTopic\",\"unit_of_measurement\":\"%\",\"name\":\"entityName\",\"unique_id\":\"deviceID_entityName\",\"object_id\":\"entityName\",\"device\":{\"identifiers\":[\"deviceID\"],\"manufacturer\":\"Keenetic Ltd.\",\"model\":\"Giga\",\"name\":\"Giga\",\"sw_version\":\"4.1.7\"},\"origin\":{\"name\":\"keeneticToMqtt\"},\"state_class\":\"measurement\"}"),
+					gomock.Eq(true),
+				)
+
+				return client
+			},
+		},
+		{
+			name: "success sending router sensor discovery message without router info",
+			mqttClient: func() mqttClient {
+				client := mock_discovery.NewMockmqttClient(ctrl)
+				client.EXPECT().SendMessage(
+					gomock.Eq("discoveryPrefix/sensor/deviceID_entityName/config"),
+					gomock.Eq("{\"state_topic\":\"stateTopic\",\"name\":\"entityName\",\"unique_id\":\"deviceID_entityName\",\"object_id\":\"entityName\",\"device\":{\"identifiers\":[\"deviceID\"],\"manufacturer\":\"Keenetic\",\"name\":\"deviceID\"},\"origin\":{\"name\":\"keeneticToMqtt\"}}"),
+					gomock.Eq(true),
+				)
+
+				return client
+			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			discovery := NewDiscovery(tt.discoveryPrefix, tt.deviceID, tt.mqttClient())
-			err := discovery.SendDiscoverySensor(tt.stateTopic, tt.deviceName, tt.entityName, unit)
+			discovery := NewDiscovery(discoveryPrefix, deviceID, nil, nil, tt.mqttClient())
+			err := discovery.SendRouterDiscoverySensor(stateTopic, entityName, tt.router, tt.meta)
 			if tt.expectedErr != nil {
 				assert.ErrorIs(t, err, tt.expectedErr)
 			} else {
@@ -180,7 +468,182 @@ func TestDiscovery_SendDiscoverySensor(t *testing.T) {
 	}
 }
 
+func TestDiscovery_SendRouterDiscoveryBinarySensor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		stateTopic      = "stateTopic"
+		entityName      = "entityName"
+		discoveryPrefix = "discoveryPrefix"
+		deviceID        = "deviceID"
+		deviceClass     = "connectivity"
+	)
+
+	router := dto.Router{Model: "Giga"}
+
+	client := mock_discovery.NewMockmqttClient(ctrl)
+	client.EXPECT().SendMessage(
+		gomock.Eq("discoveryPrefix/binary_sensor/deviceID_entityName/config"),
+		gomock.Eq("{\"state_topic\":\"stateTopic\",\"name\":\"entityName\",\"unique_id\":\"deviceID_entityName\",\"object_id\":\"entityName\",\"device\":{\"identifiers\":[\"deviceID\"],\"manufacturer\":\"Keenetic\",\"model\":\"Giga\",\"name\":\"Giga\"},\"origin\":{\"name\":\"keeneticToMqtt\"},\"device_class\":\"connectivity\"}"),
+		gomock.Eq(true),
+	)
+
+	discovery := NewDiscovery(discoveryPrefix, deviceID, nil, nil, client)
+	err := discovery.SendRouterDiscoveryBinarySensor(stateTopic, entityName, router, homeassistantdto.EntityMeta{DeviceClass: deviceClass})
+	assert.Nil(t, err)
+}
+
+func TestDiscovery_SendRouterDiscoverySwitch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		commandTopic    = "commandTopic"
+		stateTopic      = "stateTopic"
+		entityName      = "entityName"
+		discoveryPrefix = "discoveryPrefix"
+		deviceID        = "deviceID"
+	)
+
+	router := dto.Router{Model: "Giga"}
+
+	client := mock_discovery.NewMockmqttClient(ctrl)
+	client.EXPECT().SendMessage(
+		gomock.Eq("discoveryPrefix/switch/deviceID_entityName/config"),
+		gomock.Eq("{\"command_topic\":\"commandTopic\",\"state_topic\":\"stateTopic\",\"name\":\"entityName\",\"unique_id\":\"deviceID_entityName\",\"object_id\":\"entityName\",\"device\":{\"identifiers\":[\"deviceID\"],\"manufacturer\":\"Keenetic\",\"model\":\"Giga\",\"name\":\"Giga\"},\"origin\":{\"name\":\"keeneticToMqtt\"}}"),
+		gomock.Eq(true),
+	)
+
+	discovery := NewDiscovery(discoveryPrefix, deviceID, nil, nil, client)
+	err := discovery.SendRouterDiscoverySwitch(commandTopic, stateTopic, entityName, router, homeassistantdto.EntityMeta{})
+	assert.Nil(t, err)
+}
+
+func TestDiscovery_RemoveClientDiscovery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		discoveryPrefix = "discoveryPrefix"
+		deviceID        = "deviceID"
+		deviceName      = "deviceName"
+	)
+
+	client := mock_discovery.NewMockmqttClient(ctrl)
+	client.EXPECT().SendMessage("discoveryPrefix/switch/deviceIDdeviceName_permit/config", "", true)
+	client.EXPECT().SendMessage("discoveryPrefix/switch/deviceID_aabbccddeeff_permit/config", gomock.Any(), true)
+	client.EXPECT().SendMessage("discoveryPrefix/sensor/deviceIDdeviceName_rssi/config", "", true)
+	client.EXPECT().SendMessage("discoveryPrefix/sensor/deviceID_aabbccddeeff_rssi/config", gomock.Any(), true)
+	client.EXPECT().SendMessage("discoveryPrefix/sensor/deviceID_router/config", gomock.Any(), true)
+	client.EXPECT().SendMessage("discoveryPrefix/switch/deviceID_aabbccddeeff_permit/config", "", true)
+	client.EXPECT().SendMessage("discoveryPrefix/sensor/deviceID_aabbccddeeff_rssi/config", "", true)
+
+	discovery := NewDiscovery(discoveryPrefix, deviceID, nil, nil, client)
+	assert.Nil(t, discovery.SendDiscoverySwitch("commandTopic", "stateTopic", dto.Client{Mac: mac, Name: deviceName}, "permit", homeassistantdto.EntityMeta{}))
+	assert.Nil(t, discovery.SendDiscoverySensor("stateTopic", dto.Client{Mac: mac, Name: deviceName}, "rssi", homeassistantdto.SensorMeta{}))
+	assert.Nil(t, discovery.SendRouterDiscoverySensor("stateTopic", "router", dto.Router{}, homeassistantdto.SensorMeta{}))
+
+	discovery.RemoveClientDiscovery(mac)
+	assert.Empty(t, discovery.clientTopics)
+
+	// second call does nothing, all entities are already removed
+	discovery.RemoveClientDiscovery(mac)
+}
+
+func TestDiscovery_RemoveStaleClients(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mqtt := mock_discovery.NewMockmqttClient(ctrl)
+	mqtt.EXPECT().ReadRetained("discoveryPrefix/+/+/config", retainedWait).Return(map[string]string{
+		"discoveryPrefix/switch/deviceID_aabbccddeeff_permit/config":    "{\"state_topic\":\"base/AA_BB_CC_DD_EE_FF/permit\"}",
+		"discoveryPrefix/switch/deviceID_112233445566_permit/config":    "{\"state_topic\":\"base/11_22_33_44_55_66/permit\"}",
+		"discoveryPrefix/sensor/deviceID_112233445566_rssi/config":      "{\"state_topic\":\"base/11_22_33_44_55_66/rssi\",\"json_attributes_topic\":\"base/11_22_33_44_55_66/attributes\"}",
+		"discoveryPrefix/sensor/deviceID_665544332211_rssi/config":      "",
+		"discoveryPrefix/sensor/deviceID_router/config":                 "{\"state_topic\":\"base/router\"}",
+		"discoveryPrefix/sensor/deviceID_wan_rx_rate/config":            "{\"state_topic\":\"base/wan_rx_rate\"}",
+		"discoveryPrefix/sensor/otherDeviceID_112233445566_rssi/config": "{\"state_topic\":\"other/rssi\"}",
+	})
+	mqtt.EXPECT().SendMessage("discoveryPrefix/switch/deviceID_112233445566_permit/config", "", true)
+	mqtt.EXPECT().SendMessage("base/11_22_33_44_55_66/permit", "", true)
+	mqtt.EXPECT().SendMessage("discoveryPrefix/sensor/deviceID_112233445566_rssi/config", "", true)
+	mqtt.EXPECT().SendMessage("base/11_22_33_44_55_66/rssi", "", true)
+	mqtt.EXPECT().SendMessage("base/11_22_33_44_55_66/attributes", "", true)
+
+	discovery := NewDiscovery("discoveryPrefix", "deviceID", nil, nil, mqtt)
+	discovery.LoadRetainedTopics()
+	discovery.RemoveStaleClients([]dto.Client{{Mac: mac}})
+	assert.Nil(t, discovery.retainedTopics)
+
+	// second call does nothing, stale clients are already removed
+	discovery.RemoveStaleClients(nil)
+}
+
+func TestDiscovery_legacyTopic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := dto.Client{Mac: mac, Name: "deviceName"}
+
+	mqtt := mock_discovery.NewMockmqttClient(ctrl)
+	gomock.InOrder(
+		mqtt.EXPECT().SendMessage("discoveryPrefix/switch/deviceIDdeviceName_permit/config", "", true),
+		mqtt.EXPECT().SendMessage("discoveryPrefix/switch/deviceID_aabbccddeeff_permit/config", gomock.Any(), true).Times(2),
+	)
+
+	discovery := NewDiscovery("discoveryPrefix", "deviceID", nil, nil, mqtt)
+	assert.Nil(t, discovery.SendDiscoverySwitch("commandTopic", "stateTopic", client, "permit", homeassistantdto.EntityMeta{}))
+	// legacy topic is cleared only on first discovery of entity
+	assert.Nil(t, discovery.SendDiscoverySwitch("commandTopic", "stateTopic", client, "permit", homeassistantdto.EntityMeta{}))
+}
+
 func TestNewDiscovery_emptyDiscoveryPrefix(t *testing.T) {
-	discovery := NewDiscovery("", "", nil)
+	discovery := NewDiscovery("", "", nil, nil, nil)
 	assert.Equal(t, defaultDiscoveryPrefix, discovery.discoveryPrefix)
 }
+
+func TestDiscovery_availability(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock_discovery.NewMockmqttClient(ctrl)
+	client.EXPECT().SendMessage("discoveryPrefix/switch/deviceIDdeviceName_entityName/config", "", true)
+	client.EXPECT().SendMessage(
+		"discoveryPrefix/switch/deviceID_aabbccddeeff_entityName/config",
+		"{\"command_topic\":\"commandTopic\",\"state_topic\":\"stateTopic\",\"name\":\"deviceName_entityName\",\"unique_id\":\"deviceID_aabbccddeeff_entityName\",\"object_id\":\"deviceName_entityName\",\"device\":{\"identifiers\":[\"deviceID_aabbccddeeff\"],\"connections\":[[\"mac\",\"AA:BB:CC:DD:EE:FF\"]],\"manufacturer\":\"BlenderistDev keeneticToMqtt\",\"name\":\"deviceName\",\"via_device\":\"deviceID\"},\"origin\":{\"name\":\"keeneticToMqtt\"},\"availability\":[{\"topic\":\"base/bridge/state\"},{\"topic\":\"base/bridge/router\"}],\"availability_mode\":\"all\"}",
+		true,
+	)
+
+	discovery := NewDiscovery("discoveryPrefix", "deviceID", []string{"base/bridge/state", "base/bridge/router"}, nil, client)
+	err := discovery.SendDiscoverySwitch("commandTopic", "stateTopic", dto.Client{Mac: mac, Name: "deviceName"}, "entityName", homeassistantdto.EntityMeta{})
+	assert.Nil(t, err)
+}
+
+func TestDiscovery_attributesTopic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := dto.Client{Mac: mac, Name: "deviceName"}
+
+	topics := mock_discovery.NewMockclientTopics(ctrl)
+	topics.EXPECT().AttributesTopic(client).Return("base/AA_BB_CC_DD_EE_FF_attributes")
+
+	mqtt := mock_discovery.NewMockmqttClient(ctrl)
+	mqtt.EXPECT().SendMessage("discoveryPrefix/switch/deviceIDdeviceName_entityName/config", "", true)
+	mqtt.EXPECT().SendMessage(
+		"discoveryPrefix/switch/deviceID_aabbccddeeff_entityName/config",
+		"{\"command_topic\":\"commandTopic\",\"state_topic\":\"stateTopic\",\"name\":\"deviceName_entityName\",\"unique_id\":\"deviceID_aabbccddeeff_entityName\",\"object_id\":\"deviceName_entityName\",\"device\":{\"identifiers\":[\"deviceID_aabbccddeeff\"],\"connections\":[[\"mac\",\"AA:BB:CC:DD:EE:FF\"]],\"manufacturer\":\"BlenderistDev keeneticToMqtt\",\"name\":\"deviceName\",\"via_device\":\"deviceID\"},\"origin\":{\"name\":\"keeneticToMqtt\"},\"json_attributes_topic\":\"base/AA_BB_CC_DD_EE_FF_attributes\"}",
+		true,
+	)
+	mqtt.EXPECT().SendMessage(
+		"discoveryPrefix/sensor/deviceID_router/config",
+		"{\"state_topic\":\"stateTopic\",\"name\":\"router\",\"unique_id\":\"deviceID_router\",\"object_id\":\"router\",\"device\":{\"identifiers\":[\"deviceID\"],\"manufacturer\":\"Keenetic\",\"name\":\"deviceID\"},\"origin\":{\"name\":\"keeneticToMqtt\"}}",
+		true,
+	)
+
+	discovery := NewDiscovery("discoveryPrefix", "deviceID", nil, topics, mqtt)
+	assert.Nil(t, discovery.SendDiscoverySwitch("commandTopic", "stateTopic", client, "entityName", homeassistantdto.EntityMeta{}))
+	// router entities have no client attributes
+	assert.Nil(t, discovery.SendRouterDiscoverySensor("stateTopic", "router", dto.Router{}, homeassistantdto.SensorMeta{}))
+}
@@ -0,0 +1,64 @@
+package hastatus
+
+//go:generate mockgen -source=hastatus.go -destination=../../../test/mocks/gomock/services/hastatus/hastatus.go
+
+const statusOnline = "online"
+
+type (
+	mqttClient interface {
+		Subscribe(topic string) chan string
+	}
+	// Listener resends all discovery messages and states when home assistant restarts.
+	Listener interface {
+		Resync()
+	}
+	logger interface {
+		Info(msg string, args ...any)
+	}
+)
+
+// Watcher watches home assistant status topic.
+// It is the only subscriber of status topic, because mqtt client keeps one handler per topic,
+// and it notifies all listeners.
+type Watcher struct {
+	topic     string
+	mqtt      mqttClient
+	listeners []Listener
+	logger    logger
+}
+
+// NewWatcher creates new Watcher.
+func NewWatcher(topic string, mqtt mqttClient, listeners []Listener, logger logger) *Watcher {
+	return &Watcher{
+		topic:     topic,
+		mqtt:      mqtt,
+		listeners: listeners,
+		logger:    logger,
+	}
+}
+
+// Run starts watching home assistant status.
+func (w *Watcher) Run() chan struct{} {
+	done := make(chan struct{})
+	ch := w.mqtt.Subscribe(w.topic)
+
+	go func() {
+		for {
+			select {
+			case <-done:
+				w.logger.Info("shutdown home assistant status watcher")
+				return
+			case status := <-ch:
+				if status != statusOnline {
+					continue
+				}
+				w.logger.Info("home assistant is online, resync entities")
+				for _, listener := range w.listeners {
+					listener.Resync()
+				}
+			}
+		}
+	}()
+
+	return done
+}
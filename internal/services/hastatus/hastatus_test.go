@@ -0,0 +1,41 @@
+package hastatus
+
+import (
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	mock_hastatus "keeneticToMqtt/test/mocks/gomock/services/hastatus"
+)
+
+func TestWatcher_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const topic = "homeassistant/status"
+
+	ch := make(chan string)
+	stopped := make(chan struct{})
+
+	mqtt := mock_hastatus.NewMockmqttClient(ctrl)
+	mqtt.EXPECT().Subscribe(topic).Return(ch)
+
+	first := mock_hastatus.NewMockListener(ctrl)
+	first.EXPECT().Resync()
+	second := mock_hastatus.NewMockListener(ctrl)
+	second.EXPECT().Resync()
+
+	logger := mock_hastatus.NewMocklogger(ctrl)
+	logger.EXPECT().Info("home assistant is online, resync entities")
+	logger.EXPECT().Info("shutdown home assistant status watcher").Do(func(string, ...any) {
+		close(stopped)
+	})
+
+	watcher := NewWatcher(topic, mqtt, []Listener{first, second}, logger)
+	done := watcher.Run()
+
+	// offline status doesn't resync
+	ch <- "offline"
+	ch <- "online"
+	done <- struct{}{}
+	<-stopped
+}
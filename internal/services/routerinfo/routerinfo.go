@@ -0,0 +1,199 @@
+package routerinfo
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/keeneticdto"
+)
+
+//go:generate mockgen -source=routerinfo.go -destination=../../../test/mocks/gomock/services/routerinfo/routerinfo.go
+
+const (
+	accessPointType  = "AccessPoint"
+	interfaceStateUp = "up"
+)
+
+type (
+	systemClient interface {
+		GetRouterState(statNames []string) (keeneticdto.RouterState, error)
+	}
+	internetClient interface {
+		GetInterfaceStats(names []string) (map[string]keeneticdto.InterfaceStatResponse, error)
+	}
+)
+
+// RouterInfo struct for building keenetic router state.
+type RouterInfo struct {
+	systemClient   systemClient
+	internetClient internetClient
+	// wanIDs are wan interfaces of previous update, their stats are read in the same batch as router state.
+	wanIDs []string
+	mutex  sync.Mutex
+}
+
+// NewRouterInfo creates new RouterInfo.
+func NewRouterInfo(systemClient systemClient, internetClient internetClient) *RouterInfo {
+	return &RouterInfo{
+		systemClient:   systemClient,
+		internetClient: internetClient,
+	}
+}
+
+// GetRouterInfo returns dto.Router.
+func (r *RouterInfo) GetRouterInfo() (dto.Router, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	state, err := r.systemClient.GetRouterState(r.wanIDs)
+	if err != nil {
+		return dto.Router{}, fmt.Errorf("RouterInfo client error while getting router state: %w", err)
+	}
+	system := state.System
+	version := state.Version
+
+	router := dto.Router{
+		Manufacturer: version.Manufacturer,
+		Model:        version.Model,
+		Firmware:     version.Title,
+		Hostname:     system.Hostname,
+		CPULoad:      system.CPULoad,
+		MemoryUsage:  memoryUsage(system),
+	}
+
+	// keenetic returns uptime in seconds as string
+	if system.Uptime != "" {
+		router.Uptime, err = strconv.ParseInt(system.Uptime, 10, 64)
+		if err != nil {
+			return dto.Router{}, fmt.Errorf("RouterInfo error while parsing uptime: %w", err)
+		}
+	}
+
+	if err = r.fillInternet(&router, state); err != nil {
+		return dto.Router{}, err
+	}
+	fillAccessPoints(&router, state.Interfaces)
+
+	return router, nil
+}
+
+// fillInternet fills internet reachability, default gateway and wan interfaces of router.
+// Interfaces with global flag are considered wan interfaces.
+func (r *RouterInfo) fillInternet(router *dto.Router, state keeneticdto.RouterState) error {
+	router.Internet = state.InternetStatus.Internet
+	router.DefaultGateway = state.InternetStatus.Gateway.Interface
+
+	wans := make(map[string]keeneticdto.InterfaceResponse)
+	for id, iface := range state.Interfaces {
+		if !iface.Global {
+			continue
+		}
+		if iface.ID != "" {
+			id = iface.ID
+		}
+		wans[id] = iface
+	}
+
+	var ids []string
+	for id := range wans {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	r.wanIDs = ids
+
+	stats, err := r.fillMissingStats(ids, state.InterfaceStats)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		iface := wans[id]
+		router.Wans = append(router.Wans, dto.Wan{
+			ID:          id,
+			Description: iface.Description,
+			Address:     iface.Address,
+			Connected:   iface.Connected == "yes",
+			RxBytes:     stats[id].RxBytes,
+			TxBytes:     stats[id].TxBytes,
+		})
+
+		if router.DefaultGateway == "" && iface.DefaultGw {
+			router.DefaultGateway = id
+		}
+	}
+
+	for _, wan := range router.Wans {
+		if wan.ID == router.DefaultGateway {
+			router.WanIP = wan.Address
+		}
+	}
+
+	return nil
+}
+
+// fillMissingStats returns stats of all ids. Wan interfaces are known only from the interface list,
+// so stats of wans which were not in the previous update are read with one more request.
+func (r *RouterInfo) fillMissingStats(
+	ids []string,
+	stats map[string]keeneticdto.InterfaceStatResponse,
+) (map[string]keeneticdto.InterfaceStatResponse, error) {
+	var missing []string
+	for _, id := range ids {
+		if _, ok := stats[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return stats, nil
+	}
+
+	missingStats, err := r.internetClient.GetInterfaceStats(missing)
+	if err != nil {
+		return nil, fmt.Errorf("RouterInfo client error while getting interface stats: %w", err)
+	}
+
+	res := make(map[string]keeneticdto.InterfaceStatResponse, len(stats)+len(missingStats))
+	for id, stat := range stats {
+		res[id] = stat
+	}
+	for id, stat := range missingStats {
+		res[id] = stat
+	}
+
+	return res, nil
+}
+
+// memoryUsage returns used memory percent. Buffers and cache are considered free memory.
+func memoryUsage(system keeneticdto.SystemResponse) int {
+	if system.MemTotal == 0 {
+		return 0
+	}
+	used := system.MemTotal - system.MemFree - system.MemBuffers - system.MemCache
+
+	return int(used * 100 / system.MemTotal)
+}
+
+// fillAccessPoints fills wifi access points (including guest network) of router.
+func fillAccessPoints(router *dto.Router, interfaces map[string]keeneticdto.InterfaceResponse) {
+	for id, iface := range interfaces {
+		if iface.Type != accessPointType {
+			continue
+		}
+		if iface.ID != "" {
+			id = iface.ID
+		}
+
+		router.AccessPoints = append(router.AccessPoints, dto.AccessPoint{
+			ID:          id,
+			Description: iface.Description,
+			SSID:        iface.SSID,
+			Up:          iface.State == interfaceStateUp,
+		})
+	}
+	sort.Slice(router.AccessPoints, func(i, j int) bool {
+		return router.AccessPoints[i].ID < router.AccessPoints[j].ID
+	})
+}
@@ -0,0 +1,277 @@
+package routerinfo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"keeneticToMqtt/internal/dto"
+	"keeneticToMqtt/internal/dto/keeneticdto"
+	mock_routerinfo "keeneticToMqtt/test/mocks/gomock/services/routerinfo"
+)
+
+func TestRouterInfo_GetRouterInfo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	someErr := errors.New("some err")
+
+	system := keeneticdto.SystemResponse{
+		Hostname:   "Keenetic",
+		CPULoad:    12,
+		MemTotal:   1000,
+		MemFree:    300,
+		MemBuffers: 100,
+		MemCache:   100,
+		Uptime:     "3600",
+	}
+	version := keeneticdto.VersionResponse{
+		Manufacturer: "Keenetic Ltd.",
+		Model:        "Giga",
+		Title:        "4.1.7",
+	}
+	status := keeneticdto.InternetStatusResponse{
+		Internet: true,
+		Gateway:  keeneticdto.InternetGateway{Interface: "PPPoE0"},
+	}
+	interfaces := map[string]keeneticdto.InterfaceResponse{
+		"PPPoE0": {
+			ID:          "PPPoE0",
+			Description: "ISP",
+			Address:     "10.0.0.2",
+			Connected:   "yes",
+			Global:      true,
+		},
+		"UsbLte0": {
+			ID:        "UsbLte0",
+			Address:   "10.1.0.2",
+			Connected: "no",
+			Global:    true,
+		},
+		"Bridge0": {
+			ID:      "Bridge0",
+			Address: "192.168.1.1",
+		},
+		"WifiMaster0/AccessPoint0": {
+			ID:    "WifiMaster0/AccessPoint0",
+			Type:  "AccessPoint",
+			SSID:  "home",
+			State: "up",
+		},
+		"WifiMaster0/AccessPoint1": {
+			ID:          "WifiMaster0/AccessPoint1",
+			Type:        "AccessPoint",
+			Description: "Guest",
+			SSID:        "guest",
+			State:       "down",
+		},
+	}
+	wans := []dto.Wan{
+		{
+			ID:          "PPPoE0",
+			Description: "ISP",
+			Address:     "10.0.0.2",
+			Connected:   true,
+			RxBytes:     100,
+			TxBytes:     200,
+		},
+		{
+			ID:      "UsbLte0",
+			Address: "10.1.0.2",
+		},
+	}
+
+	stats := map[string]keeneticdto.InterfaceStatResponse{
+		"PPPoE0":  {RxBytes: 100, TxBytes: 200},
+		"UsbLte0": {},
+	}
+	state := keeneticdto.RouterState{
+		System:         system,
+		Version:        version,
+		Interfaces:     interfaces,
+		InternetStatus: status,
+		InterfaceStats: stats,
+	}
+	router := dto.Router{
+		Manufacturer:   "Keenetic Ltd.",
+		Model:          "Giga",
+		Firmware:       "4.1.7",
+		Hostname:       "Keenetic",
+		CPULoad:        12,
+		MemoryUsage:    50,
+		Uptime:         3600,
+		Internet:       true,
+		WanIP:          "10.0.0.2",
+		DefaultGateway: "PPPoE0",
+		Wans:           wans,
+		AccessPoints: []dto.AccessPoint{
+			{ID: "WifiMaster0/AccessPoint0", SSID: "home", Up: true},
+			{ID: "WifiMaster0/AccessPoint1", Description: "Guest", SSID: "guest"},
+		},
+	}
+	wanIDs := []string{"PPPoE0", "UsbLte0"}
+
+	tests := []struct {
+		name           string
+		wanIDs         []string
+		systemClient   func() systemClient
+		internetClient func() internetClient
+		expected       dto.Router
+		expectedWanIDs []string
+		expectedErr    error
+		expectedErrStr string
+	}{
+		{
+			name:   "success router info building in one request",
+			wanIDs: wanIDs,
+			systemClient: func() systemClient {
+				systemClient := mock_routerinfo.NewMocksystemClient(ctrl)
+				systemClient.EXPECT().GetRouterState(wanIDs).Return(state, nil)
+				return systemClient
+			},
+			internetClient: func() internetClient {
+				return mock_routerinfo.NewMockinternetClient(ctrl)
+			},
+			expected:       router,
+			expectedWanIDs: wanIDs,
+		},
+		{
+			name: "stats of unknown wans are read with additional request",
+			systemClient: func() systemClient {
+				systemClient := mock_routerinfo.NewMocksystemClient(ctrl)
+				noStatsState := state
+				noStatsState.InterfaceStats = map[string]keeneticdto.InterfaceStatResponse{}
+				systemClient.EXPECT().GetRouterState(nil).Return(noStatsState, nil)
+				return systemClient
+			},
+			internetClient: func() internetClient {
+				internetClient := mock_routerinfo.NewMockinternetClient(ctrl)
+				internetClient.EXPECT().GetInterfaceStats(wanIDs).Return(stats, nil)
+				return internetClient
+			},
+			expected:       router,
+			expectedWanIDs: wanIDs,
+		},
+		{
+			name:   "stats of new wan are read with additional request",
+			wanIDs: []string{"PPPoE0"},
+			systemClient: func() systemClient {
+				systemClient := mock_routerinfo.NewMocksystemClient(ctrl)
+				oneStatState := state
+				oneStatState.InterfaceStats = map[string]keeneticdto.InterfaceStatResponse{
+					"PPPoE0": {RxBytes: 100, TxBytes: 200},
+				}
+				systemClient.EXPECT().GetRouterState([]string{"PPPoE0"}).Return(oneStatState, nil)
+				return systemClient
+			},
+			internetClient: func() internetClient {
+				internetClient := mock_routerinfo.NewMockinternetClient(ctrl)
+				internetClient.EXPECT().GetInterfaceStats([]string{"UsbLte0"}).Return(map[string]keeneticdto.InterfaceStatResponse{
+					"UsbLte0": {},
+				}, nil)
+				return internetClient
+			},
+			expected:       router,
+			expectedWanIDs: wanIDs,
+		},
+		{
+			name: "empty memory and uptime",
+			systemClient: func() systemClient {
+				systemClient := mock_routerinfo.NewMocksystemClient(ctrl)
+				systemClient.EXPECT().GetRouterState(nil).Return(keeneticdto.RouterState{Version: version}, nil)
+				return systemClient
+			},
+			internetClient: func() internetClient {
+				return mock_routerinfo.NewMockinternetClient(ctrl)
+			},
+			expected: dto.Router{
+				Manufacturer: "Keenetic Ltd.",
+				Model:        "Giga",
+				Firmware:     "4.1.7",
+			},
+		},
+		{
+			name: "default gateway from interface list",
+			systemClient: func() systemClient {
+				systemClient := mock_routerinfo.NewMocksystemClient(ctrl)
+				systemClient.EXPECT().GetRouterState(nil).Return(keeneticdto.RouterState{
+					Interfaces: map[string]keeneticdto.InterfaceResponse{
+						"GigabitEthernet1": {Address: "10.0.0.3", Global: true, DefaultGw: true},
+					},
+				}, nil)
+				return systemClient
+			},
+			internetClient: func() internetClient {
+				internetClient := mock_routerinfo.NewMockinternetClient(ctrl)
+				internetClient.EXPECT().GetInterfaceStats([]string{"GigabitEthernet1"}).Return(map[string]keeneticdto.InterfaceStatResponse{}, nil)
+				return internetClient
+			},
+			expected: dto.Router{
+				WanIP:          "10.0.0.3",
+				DefaultGateway: "GigabitEthernet1",
+				Wans:           []dto.Wan{{ID: "GigabitEthernet1", Address: "10.0.0.3"}},
+			},
+			expectedWanIDs: []string{"GigabitEthernet1"},
+		},
+		{
+			name: "GetRouterState error",
+			systemClient: func() systemClient {
+				systemClient := mock_routerinfo.NewMocksystemClient(ctrl)
+				systemClient.EXPECT().GetRouterState(nil).Return(keeneticdto.RouterState{}, someErr)
+				return systemClient
+			},
+			internetClient: func() internetClient {
+				return mock_routerinfo.NewMockinternetClient(ctrl)
+			},
+			expectedErr: someErr,
+		},
+		{
+			name: "GetInterfaceStats error",
+			systemClient: func() systemClient {
+				systemClient := mock_routerinfo.NewMocksystemClient(ctrl)
+				noStatsState := state
+				noStatsState.InterfaceStats = nil
+				systemClient.EXPECT().GetRouterState(nil).Return(noStatsState, nil)
+				return systemClient
+			},
+			internetClient: func() internetClient {
+				internetClient := mock_routerinfo.NewMockinternetClient(ctrl)
+				internetClient.EXPECT().GetInterfaceStats(wanIDs).Return(nil, someErr)
+				return internetClient
+			},
+			expectedErr: someErr,
+		},
+		{
+			name: "invalid uptime",
+			systemClient: func() systemClient {
+				systemClient := mock_routerinfo.NewMocksystemClient(ctrl)
+				systemClient.EXPECT().GetRouterState(nil).Return(keeneticdto.RouterState{
+					System: keeneticdto.SystemResponse{Uptime: "invalid"},
+				}, nil)
+				return systemClient
+			},
+			internetClient: func() internetClient {
+				return mock_routerinfo.NewMockinternetClient(ctrl)
+			},
+			expectedErrStr: "RouterInfo error while parsing uptime",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			routerInfo := NewRouterInfo(tt.systemClient(), tt.internetClient())
+			routerInfo.wanIDs = tt.wanIDs
+			res, err := routerInfo.GetRouterInfo()
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else if tt.expectedErrStr != "" {
+				assert.Regexp(t, tt.expectedErrStr+".*", err.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.expected, res)
+				assert.Equal(t, tt.expectedWanIDs, routerInfo.wanIDs)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package lists
+
+import (
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"keeneticToMqtt/internal/dto/homeassistantdto"
+	"keeneticToMqtt/internal/dto/keeneticdto"
+)
+
+//go:generate mockgen -source=lists.go -destination=../../../test/mocks/gomock/storages/lists/lists.go
+
+type (
+	listClient interface {
+		GetAccessLists() (map[string]keeneticdto.Policy, map[string]keeneticdto.Schedule, error)
+	}
+	logger interface {
+		Error(msg string, args ...any)
+		Info(msg string, args ...any)
+	}
+
+	// Storage store keenetic policies and schedules in-memory.
+	Storage struct {
+		listClient      listClient
+		refreshInterval time.Duration
+		policies        *List
+		schedules       *List
+		logger          logger
+	}
+
+	// List is a sorted list of keenetic names, the first item is always the "none" item.
+	List struct {
+		name        string
+		refresh     func()
+		items       []string
+		mutex       sync.RWMutex
+		subscribers []chan struct{}
+		logger      logger
+	}
+)
+
+// NewStorage creates Storage
+func NewStorage(listClient listClient, refreshInterval time.Duration, logger logger) *Storage {
+	s := &Storage{
+		listClient:      listClient,
+		refreshInterval: refreshInterval,
+		logger:          logger,
+	}
+	s.policies = &List{name: "policies", refresh: s.refresh, logger: logger}
+	s.schedules = &List{name: "schedules", refresh: s.refresh, logger: logger}
+
+	return s
+}
+
+// Policies returns policy list.
+func (s *Storage) Policies() *List {
+	return s.policies
+}
+
+// Schedules returns schedule list.
+func (s *Storage) Schedules() *List {
+	return s.schedules
+}
+
+// Run start storage updates.
+func (s *Storage) Run() chan struct{} {
+	ticker := time.NewTicker(s.refreshInterval)
+
+	return s.run(ticker.C)
+}
+
+func (s *Storage) run(ticks <-chan time.Time) chan struct{} {
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-done:
+				s.logger.Info("shutdown lists storage")
+				return
+			case _ = <-ticks:
+				s.refresh()
+			}
+		}
+	}()
+
+	return done
+}
+
+// refresh refreshes all lists with one keenetic request.
+func (s *Storage) refresh() {
+	policies, schedules, err := s.listClient.GetAccessLists()
+	if err != nil {
+		s.logger.Error("error while refresh lists storage", "error", err)
+		return
+	}
+	s.policies.set(homeassistantdto.NonePolicy, keys(policies))
+	s.schedules.set(homeassistantdto.NoneSchedule, keys(schedules))
+}
+
+// GetList returns list items.
+func (l *List) GetList() []string {
+	l.mutex.RLock()
+	empty := len(l.items) == 0
+	l.mutex.RUnlock()
+	if empty {
+		l.refresh()
+	}
+
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	return l.items
+}
+
+// Subscribe returns channel, which receives notification when list changes.
+func (l *List) Subscribe() <-chan struct{} {
+	ch := make(chan struct{}, 1)
+	l.mutex.Lock()
+	l.subscribers = append(l.subscribers, ch)
+	l.mutex.Unlock()
+	return ch
+}
+
+func (l *List) set(none string, names []string) {
+	items := make([]string, 0, len(names)+1)
+	items = append(items, none)
+	items = append(items, names...)
+	// keep stable order, so home assistant options and changes detection don't depend on map order
+	sort.Strings(items[1:])
+
+	l.mutex.Lock()
+	// first fill notifies too, discovery sent while list was unavailable has no options
+	changed := !slices.Equal(l.items, items)
+	l.items = items
+	subscribers := l.subscribers
+	l.mutex.Unlock()
+	l.logger.Info("update "+l.name, l.name, items)
+
+	if changed {
+		for _, ch := range subscribers {
+			// skip if notification is already pending, subscriber will get the actual list anyway
+			select {
+			case ch <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+func keys[T any](m map[string]T) []string {
+	res := make([]string, 0, len(m))
+	for k := range m {
+		res = append(res, k)
+	}
+
+	return res
+}
@@ -0,0 +1,209 @@
+package lists
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"keeneticToMqtt/internal/dto/keeneticdto"
+	mock_lists "keeneticToMqtt/test/mocks/gomock/storages/lists"
+)
+
+func TestStorage_GetList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		name = "name"
+	)
+	someErr := errors.New("some err")
+	items := []string{"none", name}
+
+	tests := []struct {
+		name        string
+		listClient  func() listClient
+		logger      func() logger
+		list        func(s *Storage) *List
+		items       []string
+		expectedRes []string
+	}{
+		{
+			name: "success get policy list with refresh",
+			listClient: func() listClient {
+				listClient := mock_lists.NewMocklistClient(ctrl)
+				listClient.EXPECT().GetAccessLists().Return(
+					map[string]keeneticdto.Policy{name: {}},
+					map[string]keeneticdto.Schedule{},
+					nil,
+				)
+				return listClient
+			},
+			logger: func() logger {
+				logger := mock_lists.NewMocklogger(ctrl)
+				logger.EXPECT().Info(gomock.Eq("update policies"), gomock.Eq("policies"), gomock.Eq(items))
+				logger.EXPECT().Info(gomock.Eq("update schedules"), gomock.Eq("schedules"), gomock.Eq([]string{"none"}))
+				return logger
+			},
+			list:        (*Storage).Policies,
+			expectedRes: items,
+		},
+		{
+			name: "success get schedule list with refresh",
+			listClient: func() listClient {
+				listClient := mock_lists.NewMocklistClient(ctrl)
+				listClient.EXPECT().GetAccessLists().Return(
+					map[string]keeneticdto.Policy{},
+					map[string]keeneticdto.Schedule{name: {}},
+					nil,
+				)
+				return listClient
+			},
+			logger: func() logger {
+				logger := mock_lists.NewMocklogger(ctrl)
+				logger.EXPECT().Info(gomock.Eq("update policies"), gomock.Eq("policies"), gomock.Eq([]string{"none"}))
+				logger.EXPECT().Info(gomock.Eq("update schedules"), gomock.Eq("schedules"), gomock.Eq(items))
+				return logger
+			},
+			list:        (*Storage).Schedules,
+			expectedRes: items,
+		},
+		{
+			name:  "success get policy list without refresh",
+			items: items,
+			listClient: func() listClient {
+				return mock_lists.NewMocklistClient(ctrl)
+			},
+			logger: func() logger {
+				return mock_lists.NewMocklogger(ctrl)
+			},
+			list:        (*Storage).Policies,
+			expectedRes: items,
+		},
+		{
+			name: "get policy list with error while refresh",
+			listClient: func() listClient {
+				listClient := mock_lists.NewMocklistClient(ctrl)
+				listClient.EXPECT().GetAccessLists().Return(nil, nil, someErr)
+				return listClient
+			},
+			logger: func() logger {
+				logger := mock_lists.NewMocklogger(ctrl)
+				logger.EXPECT().Error(
+					gomock.Eq("error while refresh lists storage"),
+					gomock.Eq("error"),
+					gomock.Eq(someErr),
+				)
+				return logger
+			},
+			list: (*Storage).Policies,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := NewStorage(tt.listClient(), time.Second, tt.logger())
+			list := tt.list(storage)
+			if len(tt.items) > 0 {
+				list.items = tt.items
+			}
+			res := list.GetList()
+			assert.Equal(t, tt.expectedRes, res)
+		})
+	}
+}
+
+func TestStorage_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		name = "name"
+	)
+	items := []string{"none", name}
+
+	listClient := mock_lists.NewMocklistClient(ctrl)
+	listClient.EXPECT().GetAccessLists().Return(
+		map[string]keeneticdto.Policy{name: {}},
+		map[string]keeneticdto.Schedule{name: {}},
+		nil,
+	)
+
+	stopped := make(chan struct{})
+	logger := mock_lists.NewMocklogger(ctrl)
+	logger.EXPECT().Info(gomock.Eq("update policies"), gomock.Eq("policies"), gomock.Eq(items))
+	logger.EXPECT().Info(gomock.Eq("update schedules"), gomock.Eq("schedules"), gomock.Eq(items))
+	logger.EXPECT().Info("shutdown lists storage").Do(func(string, ...any) {
+		close(stopped)
+	})
+
+	storage := NewStorage(listClient, time.Second, logger)
+	ticks := make(chan time.Time)
+	done := storage.run(ticks)
+
+	ticks <- time.Now()
+	done <- struct{}{}
+	<-stopped
+	assert.Equal(t, items, storage.Policies().GetList())
+	assert.Equal(t, items, storage.Schedules().GetList())
+}
+
+func TestList_Subscribe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		name    = "name"
+		nameNew = "nameNew"
+	)
+
+	tests := []struct {
+		name           string
+		items          []string
+		names          []string
+		expectedItems  []string
+		expectedNotify bool
+	}{
+		{
+			name:           "first refresh notifies",
+			names:          []string{name},
+			expectedItems:  []string{"none", name},
+			expectedNotify: true,
+		},
+		{
+			name:          "same items don't notify",
+			items:         []string{"none", name},
+			names:         []string{name},
+			expectedItems: []string{"none", name},
+		},
+		{
+			name:           "new item notifies",
+			items:          []string{"none", name},
+			names:          []string{nameNew, name},
+			expectedItems:  []string{"none", name, nameNew},
+			expectedNotify: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := mock_lists.NewMocklogger(ctrl)
+			logger.EXPECT().Info("update policies", "policies", tt.expectedItems)
+
+			list := &List{name: "policies", logger: logger}
+			list.items = tt.items
+			ch := list.Subscribe()
+			list.set("none", tt.names)
+
+			var notified bool
+			select {
+			case <-ch:
+				notified = true
+			default:
+			}
+			assert.Equal(t, tt.expectedNotify, notified)
+			assert.Equal(t, tt.expectedItems, list.GetList())
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Options tls connection options.
+type Options struct {
+	// CAFile is PEM file with CA certificates. System CA certificates are used if empty.
+	CAFile string `mapstructure:"caFile"`
+	// CertFile and KeyFile are PEM files with client certificate and key.
+	CertFile           string `mapstructure:"certFile"`
+	KeyFile            string `mapstructure:"keyFile"`
+	ServerName         string `mapstructure:"serverName"`
+	InsecureSkipVerify bool   `mapstructure:"insecureSkipVerify"`
+}
+
+// IsEmpty returns true if no options are set.
+func (o Options) IsEmpty() bool {
+	return o == Options{}
+}
+
+// Validate checks that tls config can be built from options.
+func (o Options) Validate() error {
+	_, err := New(o)
+	return err
+}
+
+// New creates tls config from options. It returns nil config for empty options.
+func New(o Options) (*tls.Config, error) {
+	if o.IsEmpty() {
+		return nil, nil
+	}
+
+	conf := &tls.Config{
+		ServerName:         o.ServerName,
+		InsecureSkipVerify: o.InsecureSkipVerify,
+	}
+
+	if o.CAFile != "" {
+		ca, err := os.ReadFile(o.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("error while reading ca file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificates found in ca file %s", o.CAFile)
+		}
+		conf.RootCAs = pool
+	}
+
+	if (o.CertFile == "") != (o.KeyFile == "") {
+		return nil, errors.New("both client certificate and key files must be set")
+	}
+	if o.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(o.CertFile, o.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error while loading client certificate: %w", err)
+		}
+		conf.Certificates = []tls.Certificate{cert}
+	}
+
+	return conf, nil
+}
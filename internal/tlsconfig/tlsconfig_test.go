@@ -0,0 +1,117 @@
+package tlsconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeCert writes self-signed certificate and its key to dir.
+func writeCert(t *testing.T, dir string) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	assert.Nil(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.Nil(t, err)
+
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	assert.Nil(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	assert.Nil(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600))
+
+	return certFile, keyFile
+}
+
+func TestNew(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeCert(t, dir)
+	invalidFile := filepath.Join(dir, "invalid.pem")
+	assert.Nil(t, os.WriteFile(invalidFile, []byte("invalid"), 0o600))
+
+	tests := []struct {
+		name        string
+		options     Options
+		validate    func(conf *tls.Config)
+		expectedErr string
+	}{
+		{
+			name: "empty options",
+			validate: func(conf *tls.Config) {
+				assert.Nil(t, conf)
+			},
+		},
+		{
+			name:    "server name and insecure skip verify",
+			options: Options{ServerName: "broker", InsecureSkipVerify: true},
+			validate: func(conf *tls.Config) {
+				assert.Equal(t, "broker", conf.ServerName)
+				assert.True(t, conf.InsecureSkipVerify)
+				assert.Nil(t, conf.RootCAs)
+				assert.Empty(t, conf.Certificates)
+			},
+		},
+		{
+			name:    "ca and client certificate",
+			options: Options{CAFile: certFile, CertFile: certFile, KeyFile: keyFile},
+			validate: func(conf *tls.Config) {
+				assert.NotNil(t, conf.RootCAs)
+				assert.Len(t, conf.Certificates, 1)
+			},
+		},
+		{
+			name:        "ca file not found",
+			options:     Options{CAFile: filepath.Join(dir, "none.pem")},
+			expectedErr: "error while reading ca file",
+		},
+		{
+			name:        "ca file without certificates",
+			options:     Options{CAFile: invalidFile},
+			expectedErr: "no certificates found in ca file",
+		},
+		{
+			name:        "certificate without key",
+			options:     Options{CertFile: certFile},
+			expectedErr: "both client certificate and key files must be set",
+		},
+		{
+			name:        "invalid client certificate",
+			options:     Options{CertFile: invalidFile, KeyFile: keyFile},
+			expectedErr: "error while loading client certificate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf, err := New(tt.options)
+			if tt.expectedErr != "" {
+				assert.Regexp(t, tt.expectedErr+".*", err.Error())
+				assert.Regexp(t, tt.expectedErr+".*", tt.options.Validate().Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Nil(t, tt.options.Validate())
+			tt.validate(conf)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: availability.go
+//
+// Generated by this command:
+//
+//	mockgen -source=availability.go -destination=../../../test/mocks/gomock/clients/keenetic/availability.go
+//
+// Package mock_keenetic is a generated GoMock package.
+package mock_keenetic
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockavailability is a mock of availability interface.
+type Mockavailability struct {
+	ctrl     *gomock.Controller
+	recorder *MockavailabilityMockRecorder
+}
+
+// MockavailabilityMockRecorder is the mock recorder for Mockavailability.
+type MockavailabilityMockRecorder struct {
+	mock *Mockavailability
+}
+
+// NewMockavailability creates a new mock instance.
+func NewMockavailability(ctrl *gomock.Controller) *Mockavailability {
+	mock := &Mockavailability{ctrl: ctrl}
+	mock.recorder = &MockavailabilityMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockavailability) EXPECT() *MockavailabilityMockRecorder {
+	return m.recorder
+}
+
+// SetRouterAvailable mocks base method.
+func (m *Mockavailability) SetRouterAvailable(available bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetRouterAvailable", available)
+}
+
+// SetRouterAvailable indicates an expected call of SetRouterAvailable.
+func (mr *MockavailabilityMockRecorder) SetRouterAvailable(available any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRouterAvailable", reflect.TypeOf((*Mockavailability)(nil).SetRouterAvailable), available)
+}
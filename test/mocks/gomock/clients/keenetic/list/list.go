@@ -9,46 +9,45 @@
 package mock_list
 
 import (
-	http "net/http"
+	rci "keeneticToMqtt/internal/clients/keenetic/rci"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
 )
 
-// Mockclient is a mock of client interface.
-type Mockclient struct {
+// Mockcollector is a mock of collector interface.
+type Mockcollector struct {
 	ctrl     *gomock.Controller
-	recorder *MockclientMockRecorder
+	recorder *MockcollectorMockRecorder
 }
 
-// MockclientMockRecorder is the mock recorder for Mockclient.
-type MockclientMockRecorder struct {
-	mock *Mockclient
+// MockcollectorMockRecorder is the mock recorder for Mockcollector.
+type MockcollectorMockRecorder struct {
+	mock *Mockcollector
 }
 
-// NewMockclient creates a new mock instance.
-func NewMockclient(ctrl *gomock.Controller) *Mockclient {
-	mock := &Mockclient{ctrl: ctrl}
-	mock.recorder = &MockclientMockRecorder{mock}
+// NewMockcollector creates a new mock instance.
+func NewMockcollector(ctrl *gomock.Controller) *Mockcollector {
+	mock := &Mockcollector{ctrl: ctrl}
+	mock.recorder = &MockcollectorMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *Mockclient) EXPECT() *MockclientMockRecorder {
+func (m *Mockcollector) EXPECT() *MockcollectorMockRecorder {
 	return m.recorder
 }
 
-// Do mocks base method.
-func (m *Mockclient) Do(req *http.Request) (*http.Response, error) {
+// NewBatch mocks base method.
+func (m *Mockcollector) NewBatch() *rci.Batch {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Do", req)
-	ret0, _ := ret[0].(*http.Response)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "NewBatch")
+	ret0, _ := ret[0].(*rci.Batch)
+	return ret0
 }
 
-// Do indicates an expected call of Do.
-func (mr *MockclientMockRecorder) Do(req any) *gomock.Call {
+// NewBatch indicates an expected call of NewBatch.
+func (mr *MockcollectorMockRecorder) NewBatch() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*Mockclient)(nil).Do), req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewBatch", reflect.TypeOf((*Mockcollector)(nil).NewBatch))
 }
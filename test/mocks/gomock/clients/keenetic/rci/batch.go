@@ -1,12 +1,12 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: policy.go
+// Source: batch.go
 //
 // Generated by this command:
 //
-//	mockgen -source=policy.go -destination=../../../../test/mocks/gomock/clients/keenetic/policylist/policy.go
+//	mockgen -source=batch.go -destination=../../../../test/mocks/gomock/clients/keenetic/rci/batch.go
 //
-// Package mock_policylist is a generated GoMock package.
-package mock_policylist
+// Package mock_rci is a generated GoMock package.
+package mock_rci
 
 import (
 	http "net/http"
@@ -0,0 +1,53 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: system.go
+//
+// Generated by this command:
+//
+//	mockgen -source=system.go -destination=../../../../test/mocks/gomock/clients/keenetic/system/system.go
+//
+// Package mock_system is a generated GoMock package.
+package mock_system
+
+import (
+	rci "keeneticToMqtt/internal/clients/keenetic/rci"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockcollector is a mock of collector interface.
+type Mockcollector struct {
+	ctrl     *gomock.Controller
+	recorder *MockcollectorMockRecorder
+}
+
+// MockcollectorMockRecorder is the mock recorder for Mockcollector.
+type MockcollectorMockRecorder struct {
+	mock *Mockcollector
+}
+
+// NewMockcollector creates a new mock instance.
+func NewMockcollector(ctrl *gomock.Controller) *Mockcollector {
+	mock := &Mockcollector{ctrl: ctrl}
+	mock.recorder = &MockcollectorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockcollector) EXPECT() *MockcollectorMockRecorder {
+	return m.recorder
+}
+
+// NewBatch mocks base method.
+func (m *Mockcollector) NewBatch() *rci.Batch {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewBatch")
+	ret0, _ := ret[0].(*rci.Batch)
+	return ret0
+}
+
+// NewBatch indicates an expected call of NewBatch.
+func (mr *MockcollectorMockRecorder) NewBatch() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewBatch", reflect.TypeOf((*Mockcollector)(nil).NewBatch))
+}
@@ -126,6 +126,20 @@ func (mr *MockmqttClientMockRecorder) Connect() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Connect", reflect.TypeOf((*MockmqttClient)(nil).Connect))
 }
 
+// IsConnected mocks base method.
+func (m *MockmqttClient) IsConnected() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsConnected")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsConnected indicates an expected call of IsConnected.
+func (mr *MockmqttClientMockRecorder) IsConnected() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsConnected", reflect.TypeOf((*MockmqttClient)(nil).IsConnected))
+}
+
 // Publish mocks base method.
 func (m *MockmqttClient) Publish(topic string, qos byte, retained bool, payload any) mqtt.Token {
 	m.ctrl.T.Helper()
@@ -153,3 +167,21 @@ func (mr *MockmqttClientMockRecorder) Subscribe(topic, qos, callback any) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockmqttClient)(nil).Subscribe), topic, qos, callback)
 }
+
+// Unsubscribe mocks base method.
+func (m *MockmqttClient) Unsubscribe(topics ...string) mqtt.Token {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range topics {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Unsubscribe", varargs...)
+	ret0, _ := ret[0].(mqtt.Token)
+	return ret0
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockmqttClientMockRecorder) Unsubscribe(topics ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockmqttClient)(nil).Unsubscribe), topics...)
+}
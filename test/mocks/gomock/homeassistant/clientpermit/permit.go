@@ -9,6 +9,8 @@
 package mock_clientpermit
 
 import (
+	dto "keeneticToMqtt/internal/dto"
+	homeassistantdto "keeneticToMqtt/internal/dto/homeassistantdto"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -38,17 +40,17 @@ func (m *Mockdiscovery) EXPECT() *MockdiscoveryMockRecorder {
 }
 
 // SendDiscoverySwitch mocks base method.
-func (m *Mockdiscovery) SendDiscoverySwitch(commandTopic, stateTopic, deviceName, name string) error {
+func (m *Mockdiscovery) SendDiscoverySwitch(commandTopic, stateTopic string, client dto.Client, entityType string, meta homeassistantdto.EntityMeta) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SendDiscoverySwitch", commandTopic, stateTopic, deviceName, name)
+	ret := m.ctrl.Call(m, "SendDiscoverySwitch", commandTopic, stateTopic, client, entityType, meta)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SendDiscoverySwitch indicates an expected call of SendDiscoverySwitch.
-func (mr *MockdiscoveryMockRecorder) SendDiscoverySwitch(commandTopic, stateTopic, deviceName, name any) *gomock.Call {
+func (mr *MockdiscoveryMockRecorder) SendDiscoverySwitch(commandTopic, stateTopic, client, entityType, meta any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendDiscoverySwitch", reflect.TypeOf((*Mockdiscovery)(nil).SendDiscoverySwitch), commandTopic, stateTopic, deviceName, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendDiscoverySwitch", reflect.TypeOf((*Mockdiscovery)(nil).SendDiscoverySwitch), commandTopic, stateTopic, client, entityType, meta)
 }
 
 // MockaccessUpdate is a mock of accessUpdate interface.
@@ -87,92 +89,3 @@ func (mr *MockaccessUpdateMockRecorder) SetPermit(mac, permit any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPermit", reflect.TypeOf((*MockaccessUpdate)(nil).SetPermit), mac, permit)
 }
-
-// Mockmqtt is a mock of mqtt interface.
-type Mockmqtt struct {
-	ctrl     *gomock.Controller
-	recorder *MockmqttMockRecorder
-}
-
-// MockmqttMockRecorder is the mock recorder for Mockmqtt.
-type MockmqttMockRecorder struct {
-	mock *Mockmqtt
-}
-
-// NewMockmqtt creates a new mock instance.
-func NewMockmqtt(ctrl *gomock.Controller) *Mockmqtt {
-	mock := &Mockmqtt{ctrl: ctrl}
-	mock.recorder = &MockmqttMockRecorder{mock}
-	return mock
-}
-
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *Mockmqtt) EXPECT() *MockmqttMockRecorder {
-	return m.recorder
-}
-
-// SendMessage mocks base method.
-func (m *Mockmqtt) SendMessage(topic, message string) {
-	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SendMessage", topic, message)
-}
-
-// SendMessage indicates an expected call of SendMessage.
-func (mr *MockmqttMockRecorder) SendMessage(topic, message any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessage", reflect.TypeOf((*Mockmqtt)(nil).SendMessage), topic, message)
-}
-
-// Subscribe mocks base method.
-func (m *Mockmqtt) Subscribe(topic string) chan string {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Subscribe", topic)
-	ret0, _ := ret[0].(chan string)
-	return ret0
-}
-
-// Subscribe indicates an expected call of Subscribe.
-func (mr *MockmqttMockRecorder) Subscribe(topic any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*Mockmqtt)(nil).Subscribe), topic)
-}
-
-// Mocklogger is a mock of logger interface.
-type Mocklogger struct {
-	ctrl     *gomock.Controller
-	recorder *MockloggerMockRecorder
-}
-
-// MockloggerMockRecorder is the mock recorder for Mocklogger.
-type MockloggerMockRecorder struct {
-	mock *Mocklogger
-}
-
-// NewMocklogger creates a new mock instance.
-func NewMocklogger(ctrl *gomock.Controller) *Mocklogger {
-	mock := &Mocklogger{ctrl: ctrl}
-	mock.recorder = &MockloggerMockRecorder{mock}
-	return mock
-}
-
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *Mocklogger) EXPECT() *MockloggerMockRecorder {
-	return m.recorder
-}
-
-// Error mocks base method.
-func (m *Mocklogger) Error(msg string, args ...any) {
-	m.ctrl.T.Helper()
-	varargs := []any{msg}
-	for _, a := range args {
-		varargs = append(varargs, a)
-	}
-	m.ctrl.Call(m, "Error", varargs...)
-}
-
-// Error indicates an expected call of Error.
-func (mr *MockloggerMockRecorder) Error(msg any, args ...any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{msg}, args...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*Mocklogger)(nil).Error), varargs...)
-}
@@ -9,6 +9,8 @@
 package mock_clientpolicy
 
 import (
+	dto "keeneticToMqtt/internal/dto"
+	homeassistantdto "keeneticToMqtt/internal/dto/homeassistantdto"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -38,17 +40,17 @@ func (m *Mockdiscovery) EXPECT() *MockdiscoveryMockRecorder {
 }
 
 // SendDiscoverySelect mocks base method.
-func (m *Mockdiscovery) SendDiscoverySelect(commandTopic, stateTopic, deviceName, name string, options []string) error {
+func (m *Mockdiscovery) SendDiscoverySelect(commandTopic, stateTopic string, client dto.Client, entityType string, options []string, meta homeassistantdto.EntityMeta) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SendDiscoverySelect", commandTopic, stateTopic, deviceName, name, options)
+	ret := m.ctrl.Call(m, "SendDiscoverySelect", commandTopic, stateTopic, client, entityType, options, meta)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SendDiscoverySelect indicates an expected call of SendDiscoverySelect.
-func (mr *MockdiscoveryMockRecorder) SendDiscoverySelect(commandTopic, stateTopic, deviceName, name, options any) *gomock.Call {
+func (mr *MockdiscoveryMockRecorder) SendDiscoverySelect(commandTopic, stateTopic, client, entityType, options, meta any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendDiscoverySelect", reflect.TypeOf((*Mockdiscovery)(nil).SendDiscoverySelect), commandTopic, stateTopic, deviceName, name, options)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendDiscoverySelect", reflect.TypeOf((*Mockdiscovery)(nil).SendDiscoverySelect), commandTopic, stateTopic, client, entityType, options, meta)
 }
 
 // MockaccessUpdate is a mock of accessUpdate interface.
@@ -88,55 +90,6 @@ func (mr *MockaccessUpdateMockRecorder) SetPolicy(mac, policy any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPolicy", reflect.TypeOf((*MockaccessUpdate)(nil).SetPolicy), mac, policy)
 }
 
-// Mockmqtt is a mock of mqtt interface.
-type Mockmqtt struct {
-	ctrl     *gomock.Controller
-	recorder *MockmqttMockRecorder
-}
-
-// MockmqttMockRecorder is the mock recorder for Mockmqtt.
-type MockmqttMockRecorder struct {
-	mock *Mockmqtt
-}
-
-// NewMockmqtt creates a new mock instance.
-func NewMockmqtt(ctrl *gomock.Controller) *Mockmqtt {
-	mock := &Mockmqtt{ctrl: ctrl}
-	mock.recorder = &MockmqttMockRecorder{mock}
-	return mock
-}
-
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *Mockmqtt) EXPECT() *MockmqttMockRecorder {
-	return m.recorder
-}
-
-// SendMessage mocks base method.
-func (m *Mockmqtt) SendMessage(topic, message string) {
-	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SendMessage", topic, message)
-}
-
-// SendMessage indicates an expected call of SendMessage.
-func (mr *MockmqttMockRecorder) SendMessage(topic, message any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessage", reflect.TypeOf((*Mockmqtt)(nil).SendMessage), topic, message)
-}
-
-// Subscribe mocks base method.
-func (m *Mockmqtt) Subscribe(topic string) chan string {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Subscribe", topic)
-	ret0, _ := ret[0].(chan string)
-	return ret0
-}
-
-// Subscribe indicates an expected call of Subscribe.
-func (mr *MockmqttMockRecorder) Subscribe(topic any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*Mockmqtt)(nil).Subscribe), topic)
-}
-
 // MockpolicyStorage is a mock of policyStorage interface.
 type MockpolicyStorage struct {
 	ctrl     *gomock.Controller
@@ -160,56 +113,30 @@ func (m *MockpolicyStorage) EXPECT() *MockpolicyStorageMockRecorder {
 	return m.recorder
 }
 
-// GetPolicyList mocks base method.
-func (m *MockpolicyStorage) GetPolicyList() []string {
+// GetList mocks base method.
+func (m *MockpolicyStorage) GetList() []string {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPolicyList")
+	ret := m.ctrl.Call(m, "GetList")
 	ret0, _ := ret[0].([]string)
 	return ret0
 }
 
-// GetPolicyList indicates an expected call of GetPolicyList.
-func (mr *MockpolicyStorageMockRecorder) GetPolicyList() *gomock.Call {
+// GetList indicates an expected call of GetList.
+func (mr *MockpolicyStorageMockRecorder) GetList() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicyList", reflect.TypeOf((*MockpolicyStorage)(nil).GetPolicyList))
-}
-
-// Mocklogger is a mock of logger interface.
-type Mocklogger struct {
-	ctrl     *gomock.Controller
-	recorder *MockloggerMockRecorder
-}
-
-// MockloggerMockRecorder is the mock recorder for Mocklogger.
-type MockloggerMockRecorder struct {
-	mock *Mocklogger
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockpolicyStorage)(nil).GetList))
 }
 
-// NewMocklogger creates a new mock instance.
-func NewMocklogger(ctrl *gomock.Controller) *Mocklogger {
-	mock := &Mocklogger{ctrl: ctrl}
-	mock.recorder = &MockloggerMockRecorder{mock}
-	return mock
-}
-
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *Mocklogger) EXPECT() *MockloggerMockRecorder {
-	return m.recorder
-}
-
-// Error mocks base method.
-func (m *Mocklogger) Error(msg string, args ...any) {
+// Subscribe mocks base method.
+func (m *MockpolicyStorage) Subscribe() <-chan struct{} {
 	m.ctrl.T.Helper()
-	varargs := []any{msg}
-	for _, a := range args {
-		varargs = append(varargs, a)
-	}
-	m.ctrl.Call(m, "Error", varargs...)
+	ret := m.ctrl.Call(m, "Subscribe")
+	ret0, _ := ret[0].(<-chan struct{})
+	return ret0
 }
 
-// Error indicates an expected call of Error.
-func (mr *MockloggerMockRecorder) Error(msg any, args ...any) *gomock.Call {
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockpolicyStorageMockRecorder) Subscribe() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{msg}, args...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*Mocklogger)(nil).Error), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockpolicyStorage)(nil).Subscribe))
 }
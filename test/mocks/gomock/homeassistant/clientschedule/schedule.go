@@ -0,0 +1,142 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: schedule.go
+//
+// Generated by this command:
+//
+//	mockgen -source=schedule.go -destination=../../../test/mocks/gomock/homeassistant/clientschedule/schedule.go
+//
+// Package mock_clientschedule is a generated GoMock package.
+package mock_clientschedule
+
+import (
+	dto "keeneticToMqtt/internal/dto"
+	homeassistantdto "keeneticToMqtt/internal/dto/homeassistantdto"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockdiscovery is a mock of discovery interface.
+type Mockdiscovery struct {
+	ctrl     *gomock.Controller
+	recorder *MockdiscoveryMockRecorder
+}
+
+// MockdiscoveryMockRecorder is the mock recorder for Mockdiscovery.
+type MockdiscoveryMockRecorder struct {
+	mock *Mockdiscovery
+}
+
+// NewMockdiscovery creates a new mock instance.
+func NewMockdiscovery(ctrl *gomock.Controller) *Mockdiscovery {
+	mock := &Mockdiscovery{ctrl: ctrl}
+	mock.recorder = &MockdiscoveryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockdiscovery) EXPECT() *MockdiscoveryMockRecorder {
+	return m.recorder
+}
+
+// SendDiscoverySelect mocks base method.
+func (m *Mockdiscovery) SendDiscoverySelect(commandTopic, stateTopic string, client dto.Client, entityType string, options []string, meta homeassistantdto.EntityMeta) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendDiscoverySelect", commandTopic, stateTopic, client, entityType, options, meta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendDiscoverySelect indicates an expected call of SendDiscoverySelect.
+func (mr *MockdiscoveryMockRecorder) SendDiscoverySelect(commandTopic, stateTopic, client, entityType, options, meta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendDiscoverySelect", reflect.TypeOf((*Mockdiscovery)(nil).SendDiscoverySelect), commandTopic, stateTopic, client, entityType, options, meta)
+}
+
+// MockaccessUpdate is a mock of accessUpdate interface.
+type MockaccessUpdate struct {
+	ctrl     *gomock.Controller
+	recorder *MockaccessUpdateMockRecorder
+}
+
+// MockaccessUpdateMockRecorder is the mock recorder for MockaccessUpdate.
+type MockaccessUpdateMockRecorder struct {
+	mock *MockaccessUpdate
+}
+
+// NewMockaccessUpdate creates a new mock instance.
+func NewMockaccessUpdate(ctrl *gomock.Controller) *MockaccessUpdate {
+	mock := &MockaccessUpdate{ctrl: ctrl}
+	mock.recorder = &MockaccessUpdateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockaccessUpdate) EXPECT() *MockaccessUpdateMockRecorder {
+	return m.recorder
+}
+
+// SetSchedule mocks base method.
+func (m *MockaccessUpdate) SetSchedule(mac, schedule string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSchedule", mac, schedule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSchedule indicates an expected call of SetSchedule.
+func (mr *MockaccessUpdateMockRecorder) SetSchedule(mac, schedule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSchedule", reflect.TypeOf((*MockaccessUpdate)(nil).SetSchedule), mac, schedule)
+}
+
+// MockscheduleStorage is a mock of scheduleStorage interface.
+type MockscheduleStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockscheduleStorageMockRecorder
+}
+
+// MockscheduleStorageMockRecorder is the mock recorder for MockscheduleStorage.
+type MockscheduleStorageMockRecorder struct {
+	mock *MockscheduleStorage
+}
+
+// NewMockscheduleStorage creates a new mock instance.
+func NewMockscheduleStorage(ctrl *gomock.Controller) *MockscheduleStorage {
+	mock := &MockscheduleStorage{ctrl: ctrl}
+	mock.recorder = &MockscheduleStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockscheduleStorage) EXPECT() *MockscheduleStorageMockRecorder {
+	return m.recorder
+}
+
+// GetList mocks base method.
+func (m *MockscheduleStorage) GetList() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockscheduleStorageMockRecorder) GetList() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockscheduleStorage)(nil).GetList))
+}
+
+// Subscribe mocks base method.
+func (m *MockscheduleStorage) Subscribe() <-chan struct{} {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe")
+	ret0, _ := ret[0].(<-chan struct{})
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockscheduleStorageMockRecorder) Subscribe() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockscheduleStorage)(nil).Subscribe))
+}
@@ -38,6 +38,20 @@ func (m *Mockmqtt) EXPECT() *MockmqttMockRecorder {
 	return m.recorder
 }
 
+// IsConnected mocks base method.
+func (m *Mockmqtt) IsConnected() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsConnected")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsConnected indicates an expected call of IsConnected.
+func (mr *MockmqttMockRecorder) IsConnected() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsConnected", reflect.TypeOf((*Mockmqtt)(nil).IsConnected))
+}
+
 // SendMessage mocks base method.
 func (m *Mockmqtt) SendMessage(topic, message string, retained bool) {
 	m.ctrl.T.Helper()
@@ -64,6 +78,18 @@ func (mr *MockmqttMockRecorder) Subscribe(topic any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*Mockmqtt)(nil).Subscribe), topic)
 }
 
+// Unsubscribe mocks base method.
+func (m *Mockmqtt) Unsubscribe(topic string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Unsubscribe", topic)
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockmqttMockRecorder) Unsubscribe(topic any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*Mockmqtt)(nil).Unsubscribe), topic)
+}
+
 // MockEntity is a mock of Entity interface.
 type MockEntity struct {
 	ctrl     *gomock.Controller
@@ -158,6 +184,80 @@ func (mr *MockEntityMockRecorder) SendDiscoveryMessage(client any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendDiscoveryMessage", reflect.TypeOf((*MockEntity)(nil).SendDiscoveryMessage), client)
 }
 
+// MockDiscoveryNotifier is a mock of DiscoveryNotifier interface.
+type MockDiscoveryNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockDiscoveryNotifierMockRecorder
+}
+
+// MockDiscoveryNotifierMockRecorder is the mock recorder for MockDiscoveryNotifier.
+type MockDiscoveryNotifierMockRecorder struct {
+	mock *MockDiscoveryNotifier
+}
+
+// NewMockDiscoveryNotifier creates a new mock instance.
+func NewMockDiscoveryNotifier(ctrl *gomock.Controller) *MockDiscoveryNotifier {
+	mock := &MockDiscoveryNotifier{ctrl: ctrl}
+	mock.recorder = &MockDiscoveryNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDiscoveryNotifier) EXPECT() *MockDiscoveryNotifierMockRecorder {
+	return m.recorder
+}
+
+// DiscoveryChanges mocks base method.
+func (m *MockDiscoveryNotifier) DiscoveryChanges() <-chan struct{} {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscoveryChanges")
+	ret0, _ := ret[0].(<-chan struct{})
+	return ret0
+}
+
+// DiscoveryChanges indicates an expected call of DiscoveryChanges.
+func (mr *MockDiscoveryNotifierMockRecorder) DiscoveryChanges() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoveryChanges", reflect.TypeOf((*MockDiscoveryNotifier)(nil).DiscoveryChanges))
+}
+
+// MockRetainedEntity is a mock of RetainedEntity interface.
+type MockRetainedEntity struct {
+	ctrl     *gomock.Controller
+	recorder *MockRetainedEntityMockRecorder
+}
+
+// MockRetainedEntityMockRecorder is the mock recorder for MockRetainedEntity.
+type MockRetainedEntityMockRecorder struct {
+	mock *MockRetainedEntity
+}
+
+// NewMockRetainedEntity creates a new mock instance.
+func NewMockRetainedEntity(ctrl *gomock.Controller) *MockRetainedEntity {
+	mock := &MockRetainedEntity{ctrl: ctrl}
+	mock.recorder = &MockRetainedEntityMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRetainedEntity) EXPECT() *MockRetainedEntityMockRecorder {
+	return m.recorder
+}
+
+// Retained mocks base method.
+func (m *MockRetainedEntity) Retained() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Retained")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Retained indicates an expected call of Retained.
+func (mr *MockRetainedEntityMockRecorder) Retained() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retained", reflect.TypeOf((*MockRetainedEntity)(nil).Retained))
+}
+
 // MockclientList is a mock of clientList interface.
 type MockclientList struct {
 	ctrl     *gomock.Controller
@@ -196,6 +296,90 @@ func (mr *MockclientListMockRecorder) GetClientList() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientList", reflect.TypeOf((*MockclientList)(nil).GetClientList))
 }
 
+// Mockdiscovery is a mock of discovery interface.
+type Mockdiscovery struct {
+	ctrl     *gomock.Controller
+	recorder *MockdiscoveryMockRecorder
+}
+
+// MockdiscoveryMockRecorder is the mock recorder for Mockdiscovery.
+type MockdiscoveryMockRecorder struct {
+	mock *Mockdiscovery
+}
+
+// NewMockdiscovery creates a new mock instance.
+func NewMockdiscovery(ctrl *gomock.Controller) *Mockdiscovery {
+	mock := &Mockdiscovery{ctrl: ctrl}
+	mock.recorder = &MockdiscoveryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockdiscovery) EXPECT() *MockdiscoveryMockRecorder {
+	return m.recorder
+}
+
+// RemoveClientDiscovery mocks base method.
+func (m *Mockdiscovery) RemoveClientDiscovery(mac string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RemoveClientDiscovery", mac)
+}
+
+// RemoveClientDiscovery indicates an expected call of RemoveClientDiscovery.
+func (mr *MockdiscoveryMockRecorder) RemoveClientDiscovery(mac any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveClientDiscovery", reflect.TypeOf((*Mockdiscovery)(nil).RemoveClientDiscovery), mac)
+}
+
+// RemoveStaleClients mocks base method.
+func (m *Mockdiscovery) RemoveStaleClients(clients []dto.Client) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RemoveStaleClients", clients)
+}
+
+// RemoveStaleClients indicates an expected call of RemoveStaleClients.
+func (mr *MockdiscoveryMockRecorder) RemoveStaleClients(clients any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveStaleClients", reflect.TypeOf((*Mockdiscovery)(nil).RemoveStaleClients), clients)
+}
+
+// MockstatePublisher is a mock of statePublisher interface.
+type MockstatePublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockstatePublisherMockRecorder
+}
+
+// MockstatePublisherMockRecorder is the mock recorder for MockstatePublisher.
+type MockstatePublisherMockRecorder struct {
+	mock *MockstatePublisher
+}
+
+// NewMockstatePublisher creates a new mock instance.
+func NewMockstatePublisher(ctrl *gomock.Controller) *MockstatePublisher {
+	mock := &MockstatePublisher{ctrl: ctrl}
+	mock.recorder = &MockstatePublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockstatePublisher) EXPECT() *MockstatePublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockstatePublisher) Publish(clients []dto.Client) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", clients)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockstatePublisherMockRecorder) Publish(clients any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockstatePublisher)(nil).Publish), clients)
+}
+
 // Mocklogger is a mock of logger interface.
 type Mocklogger struct {
 	ctrl     *gomock.Controller
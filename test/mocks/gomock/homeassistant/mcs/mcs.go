@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: mcs.go
+//
+// Generated by this command:
+//
+//	mockgen -source=mcs.go -destination=../../../test/mocks/gomock/homeassistant/mcs/mcs.go
+//
+// Package mock_mcs is a generated GoMock package.
+package mock_mcs
+
+import (
+	dto "keeneticToMqtt/internal/dto"
+	homeassistantdto "keeneticToMqtt/internal/dto/homeassistantdto"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockdiscovery is a mock of discovery interface.
+type Mockdiscovery struct {
+	ctrl     *gomock.Controller
+	recorder *MockdiscoveryMockRecorder
+}
+
+// MockdiscoveryMockRecorder is the mock recorder for Mockdiscovery.
+type MockdiscoveryMockRecorder struct {
+	mock *Mockdiscovery
+}
+
+// NewMockdiscovery creates a new mock instance.
+func NewMockdiscovery(ctrl *gomock.Controller) *Mockdiscovery {
+	mock := &Mockdiscovery{ctrl: ctrl}
+	mock.recorder = &MockdiscoveryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockdiscovery) EXPECT() *MockdiscoveryMockRecorder {
+	return m.recorder
+}
+
+// SendDiscoverySensor mocks base method.
+func (m *Mockdiscovery) SendDiscoverySensor(stateTopic string, client dto.Client, entityType string, meta homeassistantdto.SensorMeta) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendDiscoverySensor", stateTopic, client, entityType, meta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendDiscoverySensor indicates an expected call of SendDiscoverySensor.
+func (mr *MockdiscoveryMockRecorder) SendDiscoverySensor(stateTopic, client, entityType, meta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendDiscoverySensor", reflect.TypeOf((*Mockdiscovery)(nil).SendDiscoverySensor), stateTopic, client, entityType, meta)
+}
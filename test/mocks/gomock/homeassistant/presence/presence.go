@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: presence.go
+//
+// Generated by this command:
+//
+//	mockgen -source=presence.go -destination=../../../test/mocks/gomock/homeassistant/presence/presence.go
+//
+// Package mock_presence is a generated GoMock package.
+package mock_presence
+
+import (
+	dto "keeneticToMqtt/internal/dto"
+	homeassistantdto "keeneticToMqtt/internal/dto/homeassistantdto"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockdiscovery is a mock of discovery interface.
+type Mockdiscovery struct {
+	ctrl     *gomock.Controller
+	recorder *MockdiscoveryMockRecorder
+}
+
+// MockdiscoveryMockRecorder is the mock recorder for Mockdiscovery.
+type MockdiscoveryMockRecorder struct {
+	mock *Mockdiscovery
+}
+
+// NewMockdiscovery creates a new mock instance.
+func NewMockdiscovery(ctrl *gomock.Controller) *Mockdiscovery {
+	mock := &Mockdiscovery{ctrl: ctrl}
+	mock.recorder = &MockdiscoveryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockdiscovery) EXPECT() *MockdiscoveryMockRecorder {
+	return m.recorder
+}
+
+// SendDiscoveryDeviceTracker mocks base method.
+func (m *Mockdiscovery) SendDiscoveryDeviceTracker(stateTopic string, client dto.Client, entityType string, meta homeassistantdto.EntityMeta) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendDiscoveryDeviceTracker", stateTopic, client, entityType, meta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendDiscoveryDeviceTracker indicates an expected call of SendDiscoveryDeviceTracker.
+func (mr *MockdiscoveryMockRecorder) SendDiscoveryDeviceTracker(stateTopic, client, entityType, meta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendDiscoveryDeviceTracker", reflect.TypeOf((*Mockdiscovery)(nil).SendDiscoveryDeviceTracker), stateTopic, client, entityType, meta)
+}
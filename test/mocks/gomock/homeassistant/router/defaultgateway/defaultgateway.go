@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: defaultgateway.go
+//
+// Generated by this command:
+//
+//	mockgen -source=defaultgateway.go -destination=../../../../test/mocks/gomock/homeassistant/router/defaultgateway/defaultgateway.go
+//
+// Package mock_defaultgateway is a generated GoMock package.
+package mock_defaultgateway
+
+import (
+	dto "keeneticToMqtt/internal/dto"
+	homeassistantdto "keeneticToMqtt/internal/dto/homeassistantdto"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockdiscovery is a mock of discovery interface.
+type Mockdiscovery struct {
+	ctrl     *gomock.Controller
+	recorder *MockdiscoveryMockRecorder
+}
+
+// MockdiscoveryMockRecorder is the mock recorder for Mockdiscovery.
+type MockdiscoveryMockRecorder struct {
+	mock *Mockdiscovery
+}
+
+// NewMockdiscovery creates a new mock instance.
+func NewMockdiscovery(ctrl *gomock.Controller) *Mockdiscovery {
+	mock := &Mockdiscovery{ctrl: ctrl}
+	mock.recorder = &MockdiscoveryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockdiscovery) EXPECT() *MockdiscoveryMockRecorder {
+	return m.recorder
+}
+
+// SendRouterDiscoverySensor mocks base method.
+func (m *Mockdiscovery) SendRouterDiscoverySensor(stateTopic, name string, router dto.Router, meta homeassistantdto.SensorMeta) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendRouterDiscoverySensor", stateTopic, name, router, meta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendRouterDiscoverySensor indicates an expected call of SendRouterDiscoverySensor.
+func (mr *MockdiscoveryMockRecorder) SendRouterDiscoverySensor(stateTopic, name, router, meta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendRouterDiscoverySensor", reflect.TypeOf((*Mockdiscovery)(nil).SendRouterDiscoverySensor), stateTopic, name, router, meta)
+}
@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internet.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internet.go -destination=../../../../test/mocks/gomock/homeassistant/router/internet/internet.go
+//
+// Package mock_internet is a generated GoMock package.
+package mock_internet
+
+import (
+	dto "keeneticToMqtt/internal/dto"
+	homeassistantdto "keeneticToMqtt/internal/dto/homeassistantdto"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockdiscovery is a mock of discovery interface.
+type Mockdiscovery struct {
+	ctrl     *gomock.Controller
+	recorder *MockdiscoveryMockRecorder
+}
+
+// MockdiscoveryMockRecorder is the mock recorder for Mockdiscovery.
+type MockdiscoveryMockRecorder struct {
+	mock *Mockdiscovery
+}
+
+// NewMockdiscovery creates a new mock instance.
+func NewMockdiscovery(ctrl *gomock.Controller) *Mockdiscovery {
+	mock := &Mockdiscovery{ctrl: ctrl}
+	mock.recorder = &MockdiscoveryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockdiscovery) EXPECT() *MockdiscoveryMockRecorder {
+	return m.recorder
+}
+
+// SendRouterDiscoveryBinarySensor mocks base method.
+func (m *Mockdiscovery) SendRouterDiscoveryBinarySensor(stateTopic, name string, router dto.Router, meta homeassistantdto.EntityMeta) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendRouterDiscoveryBinarySensor", stateTopic, name, router, meta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendRouterDiscoveryBinarySensor indicates an expected call of SendRouterDiscoveryBinarySensor.
+func (mr *MockdiscoveryMockRecorder) SendRouterDiscoveryBinarySensor(stateTopic, name, router, meta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendRouterDiscoveryBinarySensor", reflect.TypeOf((*Mockdiscovery)(nil).SendRouterDiscoveryBinarySensor), stateTopic, name, router, meta)
+}
@@ -0,0 +1,91 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: wifi.go
+//
+// Generated by this command:
+//
+//	mockgen -source=wifi.go -destination=../../../../test/mocks/gomock/homeassistant/router/wifi/wifi.go
+//
+// Package mock_wifi is a generated GoMock package.
+package mock_wifi
+
+import (
+	dto "keeneticToMqtt/internal/dto"
+	homeassistantdto "keeneticToMqtt/internal/dto/homeassistantdto"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockdiscovery is a mock of discovery interface.
+type Mockdiscovery struct {
+	ctrl     *gomock.Controller
+	recorder *MockdiscoveryMockRecorder
+}
+
+// MockdiscoveryMockRecorder is the mock recorder for Mockdiscovery.
+type MockdiscoveryMockRecorder struct {
+	mock *Mockdiscovery
+}
+
+// NewMockdiscovery creates a new mock instance.
+func NewMockdiscovery(ctrl *gomock.Controller) *Mockdiscovery {
+	mock := &Mockdiscovery{ctrl: ctrl}
+	mock.recorder = &MockdiscoveryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockdiscovery) EXPECT() *MockdiscoveryMockRecorder {
+	return m.recorder
+}
+
+// SendRouterDiscoverySwitch mocks base method.
+func (m *Mockdiscovery) SendRouterDiscoverySwitch(commandTopic, stateTopic, name string, router dto.Router, meta homeassistantdto.EntityMeta) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendRouterDiscoverySwitch", commandTopic, stateTopic, name, router, meta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendRouterDiscoverySwitch indicates an expected call of SendRouterDiscoverySwitch.
+func (mr *MockdiscoveryMockRecorder) SendRouterDiscoverySwitch(commandTopic, stateTopic, name, router, meta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendRouterDiscoverySwitch", reflect.TypeOf((*Mockdiscovery)(nil).SendRouterDiscoverySwitch), commandTopic, stateTopic, name, router, meta)
+}
+
+// MockinterfaceUpdate is a mock of interfaceUpdate interface.
+type MockinterfaceUpdate struct {
+	ctrl     *gomock.Controller
+	recorder *MockinterfaceUpdateMockRecorder
+}
+
+// MockinterfaceUpdateMockRecorder is the mock recorder for MockinterfaceUpdate.
+type MockinterfaceUpdateMockRecorder struct {
+	mock *MockinterfaceUpdate
+}
+
+// NewMockinterfaceUpdate creates a new mock instance.
+func NewMockinterfaceUpdate(ctrl *gomock.Controller) *MockinterfaceUpdate {
+	mock := &MockinterfaceUpdate{ctrl: ctrl}
+	mock.recorder = &MockinterfaceUpdateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockinterfaceUpdate) EXPECT() *MockinterfaceUpdateMockRecorder {
+	return m.recorder
+}
+
+// SetUp mocks base method.
+func (m *MockinterfaceUpdate) SetUp(name string, up bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUp", name, up)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUp indicates an expected call of SetUp.
+func (mr *MockinterfaceUpdateMockRecorder) SetUp(name, up any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUp", reflect.TypeOf((*MockinterfaceUpdate)(nil).SetUp), name, up)
+}
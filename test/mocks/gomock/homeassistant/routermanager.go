@@ -0,0 +1,134 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: routermanager.go
+//
+// Generated by this command:
+//
+//	mockgen -source=routermanager.go -destination=../../test/mocks/gomock/homeassistant/routermanager.go
+//
+// Package mock_homeassistant is a generated GoMock package.
+package mock_homeassistant
+
+import (
+	dto "keeneticToMqtt/internal/dto"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRouterEntity is a mock of RouterEntity interface.
+type MockRouterEntity struct {
+	ctrl     *gomock.Controller
+	recorder *MockRouterEntityMockRecorder
+}
+
+// MockRouterEntityMockRecorder is the mock recorder for MockRouterEntity.
+type MockRouterEntityMockRecorder struct {
+	mock *MockRouterEntity
+}
+
+// NewMockRouterEntity creates a new mock instance.
+func NewMockRouterEntity(ctrl *gomock.Controller) *MockRouterEntity {
+	mock := &MockRouterEntity{ctrl: ctrl}
+	mock.recorder = &MockRouterEntityMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRouterEntity) EXPECT() *MockRouterEntityMockRecorder {
+	return m.recorder
+}
+
+// Consume mocks base method.
+func (m *MockRouterEntity) Consume(router dto.Router, commandTopic, message string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Consume", router, commandTopic, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Consume indicates an expected call of Consume.
+func (mr *MockRouterEntityMockRecorder) Consume(router, commandTopic, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Consume", reflect.TypeOf((*MockRouterEntity)(nil).Consume), router, commandTopic, message)
+}
+
+// GetCommandTopics mocks base method.
+func (m *MockRouterEntity) GetCommandTopics(router dto.Router) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommandTopics", router)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetCommandTopics indicates an expected call of GetCommandTopics.
+func (mr *MockRouterEntityMockRecorder) GetCommandTopics(router any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommandTopics", reflect.TypeOf((*MockRouterEntity)(nil).GetCommandTopics), router)
+}
+
+// GetStates mocks base method.
+func (m *MockRouterEntity) GetStates(router dto.Router) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStates", router)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStates indicates an expected call of GetStates.
+func (mr *MockRouterEntityMockRecorder) GetStates(router any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStates", reflect.TypeOf((*MockRouterEntity)(nil).GetStates), router)
+}
+
+// SendDiscoveryMessage mocks base method.
+func (m *MockRouterEntity) SendDiscoveryMessage(router dto.Router) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendDiscoveryMessage", router)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendDiscoveryMessage indicates an expected call of SendDiscoveryMessage.
+func (mr *MockRouterEntityMockRecorder) SendDiscoveryMessage(router any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendDiscoveryMessage", reflect.TypeOf((*MockRouterEntity)(nil).SendDiscoveryMessage), router)
+}
+
+// MockrouterInfo is a mock of routerInfo interface.
+type MockrouterInfo struct {
+	ctrl     *gomock.Controller
+	recorder *MockrouterInfoMockRecorder
+}
+
+// MockrouterInfoMockRecorder is the mock recorder for MockrouterInfo.
+type MockrouterInfoMockRecorder struct {
+	mock *MockrouterInfo
+}
+
+// NewMockrouterInfo creates a new mock instance.
+func NewMockrouterInfo(ctrl *gomock.Controller) *MockrouterInfo {
+	mock := &MockrouterInfo{ctrl: ctrl}
+	mock.recorder = &MockrouterInfoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockrouterInfo) EXPECT() *MockrouterInfoMockRecorder {
+	return m.recorder
+}
+
+// GetRouterInfo mocks base method.
+func (m *MockrouterInfo) GetRouterInfo() (dto.Router, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRouterInfo")
+	ret0, _ := ret[0].(dto.Router)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRouterInfo indicates an expected call of GetRouterInfo.
+func (mr *MockrouterInfoMockRecorder) GetRouterInfo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRouterInfo", reflect.TypeOf((*MockrouterInfo)(nil).GetRouterInfo))
+}
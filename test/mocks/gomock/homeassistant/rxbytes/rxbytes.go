@@ -9,6 +9,8 @@
 package mock_rxbytes
 
 import (
+	dto "keeneticToMqtt/internal/dto"
+	homeassistantdto "keeneticToMqtt/internal/dto/homeassistantdto"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -38,15 +40,15 @@ func (m *Mockdiscovery) EXPECT() *MockdiscoveryMockRecorder {
 }
 
 // SendDiscoverySensor mocks base method.
-func (m *Mockdiscovery) SendDiscoverySensor(stateTopic, deviceName, name, unit string) error {
+func (m *Mockdiscovery) SendDiscoverySensor(stateTopic string, client dto.Client, entityType string, meta homeassistantdto.SensorMeta) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SendDiscoverySensor", stateTopic, deviceName, name, unit)
+	ret := m.ctrl.Call(m, "SendDiscoverySensor", stateTopic, client, entityType, meta)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SendDiscoverySensor indicates an expected call of SendDiscoverySensor.
-func (mr *MockdiscoveryMockRecorder) SendDiscoverySensor(stateTopic, deviceName, name, unit any) *gomock.Call {
+func (mr *MockdiscoveryMockRecorder) SendDiscoverySensor(stateTopic, client, entityType, meta any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendDiscoverySensor", reflect.TypeOf((*Mockdiscovery)(nil).SendDiscoverySensor), stateTopic, deviceName, name, unit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendDiscoverySensor", reflect.TypeOf((*Mockdiscovery)(nil).SendDiscoverySensor), stateTopic, client, entityType, meta)
 }
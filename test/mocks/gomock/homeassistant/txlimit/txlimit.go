@@ -0,0 +1,91 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: txlimit.go
+//
+// Generated by this command:
+//
+//	mockgen -source=txlimit.go -destination=../../../test/mocks/gomock/homeassistant/txlimit/txlimit.go
+//
+// Package mock_txlimit is a generated GoMock package.
+package mock_txlimit
+
+import (
+	dto "keeneticToMqtt/internal/dto"
+	homeassistantdto "keeneticToMqtt/internal/dto/homeassistantdto"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockdiscovery is a mock of discovery interface.
+type Mockdiscovery struct {
+	ctrl     *gomock.Controller
+	recorder *MockdiscoveryMockRecorder
+}
+
+// MockdiscoveryMockRecorder is the mock recorder for Mockdiscovery.
+type MockdiscoveryMockRecorder struct {
+	mock *Mockdiscovery
+}
+
+// NewMockdiscovery creates a new mock instance.
+func NewMockdiscovery(ctrl *gomock.Controller) *Mockdiscovery {
+	mock := &Mockdiscovery{ctrl: ctrl}
+	mock.recorder = &MockdiscoveryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockdiscovery) EXPECT() *MockdiscoveryMockRecorder {
+	return m.recorder
+}
+
+// SendDiscoveryNumber mocks base method.
+func (m *Mockdiscovery) SendDiscoveryNumber(commandTopic, stateTopic string, client dto.Client, entityType string, meta homeassistantdto.NumberMeta) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendDiscoveryNumber", commandTopic, stateTopic, client, entityType, meta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendDiscoveryNumber indicates an expected call of SendDiscoveryNumber.
+func (mr *MockdiscoveryMockRecorder) SendDiscoveryNumber(commandTopic, stateTopic, client, entityType, meta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendDiscoveryNumber", reflect.TypeOf((*Mockdiscovery)(nil).SendDiscoveryNumber), commandTopic, stateTopic, client, entityType, meta)
+}
+
+// MockaccessUpdate is a mock of accessUpdate interface.
+type MockaccessUpdate struct {
+	ctrl     *gomock.Controller
+	recorder *MockaccessUpdateMockRecorder
+}
+
+// MockaccessUpdateMockRecorder is the mock recorder for MockaccessUpdate.
+type MockaccessUpdateMockRecorder struct {
+	mock *MockaccessUpdate
+}
+
+// NewMockaccessUpdate creates a new mock instance.
+func NewMockaccessUpdate(ctrl *gomock.Controller) *MockaccessUpdate {
+	mock := &MockaccessUpdate{ctrl: ctrl}
+	mock.recorder = &MockaccessUpdateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockaccessUpdate) EXPECT() *MockaccessUpdateMockRecorder {
+	return m.recorder
+}
+
+// SetTrafficShape mocks base method.
+func (m *MockaccessUpdate) SetTrafficShape(mac string, rx, tx int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTrafficShape", mac, rx, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTrafficShape indicates an expected call of SetTrafficShape.
+func (mr *MockaccessUpdateMockRecorder) SetTrafficShape(mac, rx, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrafficShape", reflect.TypeOf((*MockaccessUpdate)(nil).SetTrafficShape), mac, rx, tx)
+}
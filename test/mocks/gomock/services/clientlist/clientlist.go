@@ -38,32 +38,18 @@ func (m *MocklistClient) EXPECT() *MocklistClientMockRecorder {
 	return m.recorder
 }
 
-// GetClientPolicyList mocks base method.
-func (m *MocklistClient) GetClientPolicyList() ([]keeneticdto.DevicePolicy, error) {
+// GetClientLists mocks base method.
+func (m *MocklistClient) GetClientLists() ([]keeneticdto.DeviceInfoResponse, []keeneticdto.DevicePolicy, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetClientPolicyList")
-	ret0, _ := ret[0].([]keeneticdto.DevicePolicy)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// GetClientPolicyList indicates an expected call of GetClientPolicyList.
-func (mr *MocklistClientMockRecorder) GetClientPolicyList() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientPolicyList", reflect.TypeOf((*MocklistClient)(nil).GetClientPolicyList))
-}
-
-// GetDeviceList mocks base method.
-func (m *MocklistClient) GetDeviceList() ([]keeneticdto.DeviceInfoResponse, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDeviceList")
+	ret := m.ctrl.Call(m, "GetClientLists")
 	ret0, _ := ret[0].([]keeneticdto.DeviceInfoResponse)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].([]keeneticdto.DevicePolicy)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// GetDeviceList indicates an expected call of GetDeviceList.
-func (mr *MocklistClientMockRecorder) GetDeviceList() *gomock.Call {
+// GetClientLists indicates an expected call of GetClientLists.
+func (mr *MocklistClientMockRecorder) GetClientLists() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeviceList", reflect.TypeOf((*MocklistClient)(nil).GetDeviceList))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientLists", reflect.TypeOf((*MocklistClient)(nil).GetClientLists))
 }
@@ -9,7 +9,9 @@
 package mock_discovery
 
 import (
+	dto "keeneticToMqtt/internal/dto"
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -37,6 +39,20 @@ func (m *MockmqttClient) EXPECT() *MockmqttClientMockRecorder {
 	return m.recorder
 }
 
+// ReadRetained mocks base method.
+func (m *MockmqttClient) ReadRetained(topic string, wait time.Duration) map[string]string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadRetained", topic, wait)
+	ret0, _ := ret[0].(map[string]string)
+	return ret0
+}
+
+// ReadRetained indicates an expected call of ReadRetained.
+func (mr *MockmqttClientMockRecorder) ReadRetained(topic, wait any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadRetained", reflect.TypeOf((*MockmqttClient)(nil).ReadRetained), topic, wait)
+}
+
 // SendMessage mocks base method.
 func (m *MockmqttClient) SendMessage(topic, message string, retained bool) {
 	m.ctrl.T.Helper()
@@ -48,3 +64,40 @@ func (mr *MockmqttClientMockRecorder) SendMessage(topic, message, retained any)
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessage", reflect.TypeOf((*MockmqttClient)(nil).SendMessage), topic, message, retained)
 }
+
+// MockclientTopics is a mock of clientTopics interface.
+type MockclientTopics struct {
+	ctrl     *gomock.Controller
+	recorder *MockclientTopicsMockRecorder
+}
+
+// MockclientTopicsMockRecorder is the mock recorder for MockclientTopics.
+type MockclientTopicsMockRecorder struct {
+	mock *MockclientTopics
+}
+
+// NewMockclientTopics creates a new mock instance.
+func NewMockclientTopics(ctrl *gomock.Controller) *MockclientTopics {
+	mock := &MockclientTopics{ctrl: ctrl}
+	mock.recorder = &MockclientTopicsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockclientTopics) EXPECT() *MockclientTopicsMockRecorder {
+	return m.recorder
+}
+
+// AttributesTopic mocks base method.
+func (m *MockclientTopics) AttributesTopic(client dto.Client) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttributesTopic", client)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// AttributesTopic indicates an expected call of AttributesTopic.
+func (mr *MockclientTopicsMockRecorder) AttributesTopic(client any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttributesTopic", reflect.TypeOf((*MockclientTopics)(nil).AttributesTopic), client)
+}
@@ -0,0 +1,92 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: routerinfo.go
+//
+// Generated by this command:
+//
+//	mockgen -source=routerinfo.go -destination=../../../test/mocks/gomock/services/routerinfo/routerinfo.go
+//
+// Package mock_routerinfo is a generated GoMock package.
+package mock_routerinfo
+
+import (
+	keeneticdto "keeneticToMqtt/internal/dto/keeneticdto"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MocksystemClient is a mock of systemClient interface.
+type MocksystemClient struct {
+	ctrl     *gomock.Controller
+	recorder *MocksystemClientMockRecorder
+}
+
+// MocksystemClientMockRecorder is the mock recorder for MocksystemClient.
+type MocksystemClientMockRecorder struct {
+	mock *MocksystemClient
+}
+
+// NewMocksystemClient creates a new mock instance.
+func NewMocksystemClient(ctrl *gomock.Controller) *MocksystemClient {
+	mock := &MocksystemClient{ctrl: ctrl}
+	mock.recorder = &MocksystemClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksystemClient) EXPECT() *MocksystemClientMockRecorder {
+	return m.recorder
+}
+
+// GetRouterState mocks base method.
+func (m *MocksystemClient) GetRouterState(statNames []string) (keeneticdto.RouterState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRouterState", statNames)
+	ret0, _ := ret[0].(keeneticdto.RouterState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRouterState indicates an expected call of GetRouterState.
+func (mr *MocksystemClientMockRecorder) GetRouterState(statNames any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRouterState", reflect.TypeOf((*MocksystemClient)(nil).GetRouterState), statNames)
+}
+
+// MockinternetClient is a mock of internetClient interface.
+type MockinternetClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockinternetClientMockRecorder
+}
+
+// MockinternetClientMockRecorder is the mock recorder for MockinternetClient.
+type MockinternetClientMockRecorder struct {
+	mock *MockinternetClient
+}
+
+// NewMockinternetClient creates a new mock instance.
+func NewMockinternetClient(ctrl *gomock.Controller) *MockinternetClient {
+	mock := &MockinternetClient{ctrl: ctrl}
+	mock.recorder = &MockinternetClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockinternetClient) EXPECT() *MockinternetClientMockRecorder {
+	return m.recorder
+}
+
+// GetInterfaceStats mocks base method.
+func (m *MockinternetClient) GetInterfaceStats(names []string) (map[string]keeneticdto.InterfaceStatResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInterfaceStats", names)
+	ret0, _ := ret[0].(map[string]keeneticdto.InterfaceStatResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInterfaceStats indicates an expected call of GetInterfaceStats.
+func (mr *MockinternetClientMockRecorder) GetInterfaceStats(names any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterfaceStats", reflect.TypeOf((*MockinternetClient)(nil).GetInterfaceStats), names)
+}
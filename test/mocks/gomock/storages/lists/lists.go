@@ -1,12 +1,12 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: policy.go
+// Source: lists.go
 //
 // Generated by this command:
 //
-//	mockgen -source=policy.go -destination=../../../test/mocks/gomock/storages/policy/policy.go
+//	mockgen -source=lists.go -destination=../../../test/mocks/gomock/storages/lists/lists.go
 //
-// Package mock_policy is a generated GoMock package.
-package mock_policy
+// Package mock_lists is a generated GoMock package.
+package mock_lists
 
 import (
 	keeneticdto "keeneticToMqtt/internal/dto/keeneticdto"
@@ -15,42 +15,43 @@ import (
 	gomock "go.uber.org/mock/gomock"
 )
 
-// MockpolicyClient is a mock of policyClient interface.
-type MockpolicyClient struct {
+// MocklistClient is a mock of listClient interface.
+type MocklistClient struct {
 	ctrl     *gomock.Controller
-	recorder *MockpolicyClientMockRecorder
+	recorder *MocklistClientMockRecorder
 }
 
-// MockpolicyClientMockRecorder is the mock recorder for MockpolicyClient.
-type MockpolicyClientMockRecorder struct {
-	mock *MockpolicyClient
+// MocklistClientMockRecorder is the mock recorder for MocklistClient.
+type MocklistClientMockRecorder struct {
+	mock *MocklistClient
 }
 
-// NewMockpolicyClient creates a new mock instance.
-func NewMockpolicyClient(ctrl *gomock.Controller) *MockpolicyClient {
-	mock := &MockpolicyClient{ctrl: ctrl}
-	mock.recorder = &MockpolicyClientMockRecorder{mock}
+// NewMocklistClient creates a new mock instance.
+func NewMocklistClient(ctrl *gomock.Controller) *MocklistClient {
+	mock := &MocklistClient{ctrl: ctrl}
+	mock.recorder = &MocklistClientMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockpolicyClient) EXPECT() *MockpolicyClientMockRecorder {
+func (m *MocklistClient) EXPECT() *MocklistClientMockRecorder {
 	return m.recorder
 }
 
-// GetPolicyList mocks base method.
-func (m *MockpolicyClient) GetPolicyList() (map[string]keeneticdto.Policy, error) {
+// GetAccessLists mocks base method.
+func (m *MocklistClient) GetAccessLists() (map[string]keeneticdto.Policy, map[string]keeneticdto.Schedule, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPolicyList")
+	ret := m.ctrl.Call(m, "GetAccessLists")
 	ret0, _ := ret[0].(map[string]keeneticdto.Policy)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(map[string]keeneticdto.Schedule)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// GetPolicyList indicates an expected call of GetPolicyList.
-func (mr *MockpolicyClientMockRecorder) GetPolicyList() *gomock.Call {
+// GetAccessLists indicates an expected call of GetAccessLists.
+func (mr *MocklistClientMockRecorder) GetAccessLists() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicyList", reflect.TypeOf((*MockpolicyClient)(nil).GetPolicyList))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccessLists", reflect.TypeOf((*MocklistClient)(nil).GetAccessLists))
 }
 
 // Mocklogger is a mock of logger interface.